@@ -0,0 +1,488 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mailru/easyjson"
+
+	repoConsistency "project/internal/consistency/repository"
+	usecaseConsistency "project/internal/consistency/usecase"
+	forumModels "project/internal/forum/delivery/models"
+	repoForum "project/internal/forum/repository"
+	usecaseForum "project/internal/forum/usecase"
+	"project/internal/models"
+	"project/internal/pkg"
+	"project/internal/pkg/cache"
+	"project/internal/pkg/sqltools"
+	repoPost "project/internal/post/repository"
+	seedModels "project/internal/seed/delivery/models"
+	usecaseSeed "project/internal/seed/usecase"
+	serviceModels "project/internal/service/delivery/models"
+	repoService "project/internal/service/repository"
+	usecaseService "project/internal/service/usecase"
+	threadModels "project/internal/thread/delivery/models"
+	repoThread "project/internal/thread/repository"
+	usecaseThread "project/internal/thread/usecase"
+	userModels "project/internal/user/delivery/models"
+	repoUser "project/internal/user/repository"
+	usecaseUser "project/internal/user/usecase"
+	repoVote "project/internal/vote/repository"
+	usecaseVote "project/internal/vote/usecase"
+)
+
+// admin holds the usecases of the admin commands. They run on the primary alone and without caches, so that what
+// they show is what is stored; the caches of running servers are left to expire.
+type admin struct {
+	users       usecaseUser.UserService
+	forums      usecaseForum.ForumService
+	threads     usecaseThread.ThreadService
+	service     usecaseService.Service
+	consistency usecaseConsistency.ConsistencyService
+	seed        usecaseSeed.SeedService
+}
+
+func newAdmin(dsn string) *admin {
+	cluster := sqltools.NewCluster(openDB(dsn))
+
+	forumStorage := repoForum.NewForumPostgres(cluster)
+	userStorage := repoUser.NewUserPostgres(cluster)
+	threadStorage := repoThread.NewThreadPostgres(cluster)
+	consistencyStorage := repoConsistency.NewConsistencyPostgres(cluster)
+
+	res := &admin{
+		users:       usecaseUser.NewUserService(userStorage),
+		forums:      usecaseForum.NewForumService(forumStorage, userStorage),
+		threads:     usecaseThread.NewThreadService(threadStorage, forumStorage, userStorage, repoPost.NewPostPostgres(cluster), nil),
+		service:     usecaseService.NewService(repoService.NewServicePostgres(cluster), consistencyStorage, cache.NewSet()),
+		consistency: usecaseConsistency.NewConsistencyService(consistencyStorage, 0, false, cache.NewSet()),
+	}
+
+	res.seed = usecaseSeed.NewSeedService(res.users, res.forums, res.threads,
+		usecaseVote.NewVoteService(repoVote.NewVotePostgres(cluster), threadStorage, userStorage, forumStorage), cache.NewSet())
+
+	return res
+}
+
+// adminContext acts as the user, to see private forums for instance, when as is given.
+func adminContext(as string) context.Context {
+	ctx := context.Background()
+
+	if as != "" {
+		ctx = context.WithValue(ctx, pkg.NicknameKey, as)
+	}
+
+	return ctx
+}
+
+// parseCommand parses the flags around the single argument of a command, which may come before or after them.
+func parseCommand(flags *flag.FlagSet, args []string) string {
+	arg := ""
+
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		arg = args[0]
+		args = args[1:]
+	}
+
+	_ = flags.Parse(args)
+
+	if arg == "" {
+		arg = flags.Arg(0)
+	}
+
+	if arg == "" {
+		fmt.Fprintf(os.Stderr, "usage: main %s ARG [flags]\n", flags.Name())
+		flags.PrintDefaults()
+		os.Exit(2)
+	}
+
+	return arg
+}
+
+// printTable writes value as the API would when asJSON is set, else a table of the rows under the header.
+func printTable(asJSON bool, value easyjson.Marshaler, header []string, rows ...[]string) {
+	if asJSON {
+		body, err := easyjson.Marshal(value)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Println(string(body))
+
+		return
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintln(writer, strings.Join(header, "\t"))
+
+	for _, row := range rows {
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+
+	err := writer.Flush()
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// printFields writes a single entity as a table of its fields.
+func printFields(asJSON bool, value easyjson.Marshaler, fields ...[]string) {
+	printTable(asJSON, value, []string{"FIELD", "VALUE"}, fields...)
+}
+
+func itoa(value int64) string {
+	return strconv.FormatInt(value, 10)
+}
+
+func userFields(user *models.User) [][]string {
+	return [][]string{
+		{"nickname", user.Nickname},
+		{"fullname", user.FullName},
+		{"about", user.About},
+		{"email", user.Email},
+		{"version", itoa(user.Version)},
+	}
+}
+
+func runUser(dsn string, args []string) {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	flags := flag.NewFlagSet("user "+args[0], flag.ExitOnError)
+	asJSON := flags.Bool("json", false, "print JSON instead of a table")
+
+	switch args[0] {
+	case "create":
+		fullname := flags.String("fullname", "", "full name")
+		about := flags.String("about", "", "about")
+		email := flags.String("email", "", "email")
+		nickname := parseCommand(flags, args[1:])
+
+		users, err := newAdmin(dsn).users.CreateUser(context.Background(), &models.User{
+			Nickname: nickname,
+			FullName: *fullname,
+			About:    *about,
+			Email:    *email,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		printFields(*asJSON, userModels.NewUserCreateResponse(&users[0]), userFields(&users[0])...)
+	case "show":
+		nickname := parseCommand(flags, args[1:])
+
+		user, err := newAdmin(dsn).users.GetProfile(context.Background(), &models.User{Nickname: nickname})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		printFields(*asJSON, userModels.NewProfileGetResponse(&user), userFields(&user)...)
+	case "update":
+		fullname := flags.String("fullname", "", "new full name, unchanged when empty")
+		about := flags.String("about", "", "new about, unchanged when empty")
+		email := flags.String("email", "", "new email, unchanged when empty")
+		version := flags.Int64("version", 0, "update only if the user still has this version")
+		nickname := parseCommand(flags, args[1:])
+
+		user, err := newAdmin(dsn).users.UpdateProfile(context.Background(), &models.User{
+			Nickname: nickname,
+			FullName: *fullname,
+			About:    *about,
+			Email:    *email,
+			Version:  *version,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		printFields(*asJSON, userModels.NewProfileUpdateResponse(&user), userFields(&user)...)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+func runForum(dsn string, args []string) {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	flags := flag.NewFlagSet("forum "+args[0], flag.ExitOnError)
+	asJSON := flags.Bool("json", false, "print JSON instead of a table")
+
+	var forum *models.Forum
+
+	var err error
+
+	switch args[0] {
+	case "create":
+		title := flags.String("title", "", "title")
+		user := flags.String("user", "", "nickname of the owner")
+		parent := flags.String("parent", "", "slug of the parent forum")
+		rollUp := flags.Bool("roll-up", false, "count posts and threads into the parent too")
+		visibility := flags.String("visibility", pkg.ForumVisibilityPublic, "public, private or read-only")
+		slug := parseCommand(flags, args[1:])
+
+		forum, err = newAdmin(dsn).forums.CreateForum(adminContext(*user), &models.Forum{
+			Slug:       slug,
+			Title:      *title,
+			User:       *user,
+			Parent:     *parent,
+			RollUp:     *rollUp,
+			Visibility: *visibility,
+		})
+	case "show":
+		as := flags.String("as", "", "act as this user, to see private forums")
+		slug := parseCommand(flags, args[1:])
+
+		forum, err = newAdmin(dsn).forums.GetDetailsForum(adminContext(*as), &models.Forum{Slug: slug})
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	printFields(*asJSON, forumModels.NewForumGetDetailsResponse(forum),
+		[]string{"slug", forum.Slug},
+		[]string{"title", forum.Title},
+		[]string{"user", forum.User},
+		[]string{"parent", forum.Parent},
+		[]string{"roll-up", strconv.FormatBool(forum.RollUp)},
+		[]string{"visibility", forum.Visibility},
+		[]string{"threads", itoa(forum.Threads)},
+		[]string{"posts", itoa(forum.Posts)},
+		[]string{"version", itoa(forum.Version)})
+}
+
+func runThread(dsn string, args []string) {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	flags := flag.NewFlagSet("thread "+args[0], flag.ExitOnError)
+	asJSON := flags.Bool("json", false, "print JSON instead of a table")
+	as := flags.String("as", "", "act as this user, to see threads of private forums")
+
+	switch args[0] {
+	case "show":
+		request := threadModels.ThreadGetDetailsRequest{SlugOrID: parseCommand(flags, args[1:])}
+
+		thread, err := newAdmin(dsn).threads.GetDetailsThread(adminContext(*as), request.GetThread())
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		printFields(*asJSON, threadModels.NewThreadGetDetailsResponse(&thread),
+			[]string{"id", itoa(thread.ID)},
+			[]string{"slug", thread.Slug},
+			[]string{"title", thread.Title},
+			[]string{"author", thread.Author},
+			[]string{"forum", thread.Forum},
+			[]string{"votes", itoa(thread.Votes)},
+			[]string{"created", thread.Created},
+			[]string{"version", itoa(thread.Version)},
+			[]string{"message", thread.Message})
+	case "posts":
+		sort := flags.String("sort", pkg.TypeSortFlat, "flat, tree or parent_tree")
+		limit := flags.Int64("limit", 100, "number of posts, or of parent posts for parent_tree")
+		since := flags.Int64("since", -1, "list the posts after this one")
+		desc := flags.Bool("desc", false, "newest first")
+		request := threadModels.ThreadGetDetailsRequest{SlugOrID: parseCommand(flags, args[1:])}
+
+		posts, err := newAdmin(dsn).threads.GetPosts(adminContext(*as), request.GetThread(), &pkg.GetPostsParams{
+			Limit: *limit,
+			Since: *since,
+			Desc:  *desc,
+			Sort:  *sort,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		rows := make([][]string, len(posts))
+		for idx, post := range posts {
+			rows[idx] = []string{itoa(post.ID), itoa(post.Parent), post.Author.Nickname, post.Created, post.Message}
+		}
+
+		printTable(*asJSON, threadModels.NewThreadGetPostsResponse(posts), []string{"ID", "PARENT", "AUTHOR", "CREATED", "MESSAGE"}, rows...)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+// runStats prints the counts of the whole database, or the stats of a forum when one is given.
+func runStats(dsn string, args []string) {
+	flags := flag.NewFlagSet("stats", flag.ExitOnError)
+	asJSON := flags.Bool("json", false, "print JSON instead of a table")
+	as := flags.String("as", "", "act as this user, to see private forums")
+	period := flags.String("period", pkg.StatsPeriodDay, "day or week, for a forum")
+	limit := flags.Int64("limit", 10, "number of leaders, for a forum")
+	_ = flags.Parse(args)
+
+	if flags.NArg() == 0 {
+		status, err := newAdmin(dsn).service.GetStatus(context.Background())
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		printFields(*asJSON, serviceModels.NewServiceGetStatusResponse(status),
+			[]string{"users", itoa(status.User)},
+			[]string{"forums", itoa(status.Forum)},
+			[]string{"threads", itoa(status.Thread)},
+			[]string{"posts", itoa(status.Post)})
+
+		return
+	}
+
+	stats, err := newAdmin(dsn).forums.GetStats(adminContext(*as), &models.Forum{Slug: flags.Arg(0)}, &pkg.GetForumStatsParams{
+		Period: *period,
+		Limit:  *limit,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *asJSON {
+		printTable(true, forumModels.NewForumGetStatsResponse(stats), nil)
+		return
+	}
+
+	rows := make([][]string, len(stats.Activity))
+	for idx, activity := range stats.Activity {
+		rows[idx] = []string{activity.Start, itoa(activity.Threads), itoa(activity.Posts), itoa(activity.Users)}
+	}
+
+	printTable(false, nil, []string{"FROM", "THREADS", "POSTS", "USERS"}, rows...)
+
+	fmt.Printf("\nactive users: %d, average thread depth: %.2f\n\n", stats.ActiveUsers, stats.AverageDepth)
+
+	rows = make([][]string, 0, len(stats.TopPosters)+len(stats.TopVoted))
+	for _, author := range stats.TopPosters {
+		rows = append(rows, []string{"posts", author.Nickname, itoa(author.Posts), itoa(author.Votes)})
+	}
+
+	for _, author := range stats.TopVoted {
+		rows = append(rows, []string{"votes", author.Nickname, itoa(author.Posts), itoa(author.Votes)})
+	}
+
+	printTable(false, nil, []string{"TOP BY", "AUTHOR", "POSTS", "VOTES"}, rows...)
+
+	fmt.Println()
+
+	rows = make([][]string, len(stats.TopThreads))
+	for idx, thread := range stats.TopThreads {
+		rows[idx] = []string{itoa(thread.ID), thread.Author, itoa(thread.Votes), thread.Title}
+	}
+
+	printTable(false, nil, []string{"THREAD", "AUTHOR", "VOTES", "TITLE"}, rows...)
+}
+
+func runClear(dsn string, args []string) {
+	flags := flag.NewFlagSet("clear", flag.ExitOnError)
+	yes := flags.Bool("yes", false, "confirm deleting all forum data")
+	_ = flags.Parse(args)
+
+	if !*yes {
+		log.Fatal("clear deletes all forum data, run it with -yes to confirm")
+	}
+
+	err := newAdmin(dsn).service.Clear(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// runRecount recomputes the counters kept by the triggers and prints how many rows had drifted.
+func runRecount(dsn string, args []string) {
+	flags := flag.NewFlagSet("recount", flag.ExitOnError)
+	asJSON := flags.Bool("json", false, "print JSON instead of a table")
+	_ = flags.Parse(args)
+
+	recount, err := newAdmin(dsn).service.Recount(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	printTable(*asJSON, serviceModels.NewServiceRecountResponse(recount), []string{"COUNTERS", "FIXED"},
+		[]string{"forums", itoa(recount.Forums)},
+		[]string{"threads", itoa(recount.Threads)},
+		[]string{"user_forums", itoa(recount.UserForums)},
+		[]string{"stats rollups rebuilt", itoa(recount.Rollups)})
+}
+
+// runCheck looks for derived values which differ from those recomputed from the source data, repairing them with
+// -repair, and prints what each check found.
+func runCheck(dsn string, args []string) {
+	flags := flag.NewFlagSet("check", flag.ExitOnError)
+	asJSON := flags.Bool("json", false, "print JSON instead of a table")
+	repair := flags.Bool("repair", false, "repair what is found, batch by batch")
+	_ = flags.Parse(args)
+
+	report, err := newAdmin(dsn).consistency.Check(context.Background(), *repair)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *asJSON {
+		printTable(true, serviceModels.NewConsistencyReportResponse(report), nil)
+		return
+	}
+
+	rows := make([][]string, 0, len(report.Checks))
+	for _, check := range report.Checks {
+		rows = append(rows, []string{check.Name, itoa(check.Found), itoa(check.Repaired)})
+	}
+
+	printTable(false, nil, []string{"CHECK", "FOUND", "REPAIRED"}, rows...)
+
+	rows = make([][]string, 0)
+	for _, check := range report.Checks {
+		for _, issue := range check.Samples {
+			rows = append(rows, []string{check.Name, issue.Key, issue.Field, issue.Stored, issue.Expected})
+		}
+	}
+
+	if len(rows) > 0 {
+		fmt.Println()
+		printTable(false, nil, []string{"CHECK", "KEY", "FIELD", "STORED", "EXPECTED"}, rows...)
+	}
+}
+
+// runSeed creates the dataset of a profile, the same for the same seed.
+func runSeed(dsn string, args []string) {
+	flags := flag.NewFlagSet("seed", flag.ExitOnError)
+	asJSON := flags.Bool("json", false, "print JSON instead of a table")
+	profile := flags.String("profile", pkg.SeedProfileSmall, "small, medium, deep-tree or hot-forum")
+	seed := flags.Int64("seed", 1, "seed of the generated data")
+	_ = flags.Parse(args)
+
+	result, err := newAdmin(dsn).seed.Seed(context.Background(), *profile, *seed)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	printFields(*asJSON, seedModels.NewSeedResponse(result),
+		[]string{"profile", result.Profile},
+		[]string{"seed", itoa(result.Seed)},
+		[]string{"prefix", result.Prefix},
+		[]string{"users", itoa(result.Users)},
+		[]string{"forums", itoa(result.Forums)},
+		[]string{"threads", itoa(result.Threads)},
+		[]string{"posts", itoa(result.Posts)},
+		[]string{"votes", itoa(result.Votes)})
+}
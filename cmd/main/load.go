@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"project/internal/loadgen"
+)
+
+// runLoad fills the database through the API and replays a mix of calls on it, against the server at -url or one
+// started in the process on the database otherwise.
+func runLoad(dsn string, args []string) {
+	fillOpts := loadgen.DefaultFillOptions()
+	runOpts := loadgen.DefaultRunOptions()
+
+	flags := flag.NewFlagSet("load", flag.ExitOnError)
+	url := flags.String("url", "", "server to load, as in http://localhost:5000; one in the process by default")
+	fill := flags.Bool("fill", true, "fill the database before the run")
+	dataset := flags.String("dataset", "", "file to save the filled dataset to, or to read it from with -fill=false")
+	mix := flags.String("mix", "", "weights of the endpoints, as in thread_posts=5,vote=1, of "+strings.Join(loadgen.Endpoints(), ", "))
+	asJSON := flags.Bool("json", false, "print JSON instead of a table")
+	flags.StringVar(&fillOpts.Prefix, "prefix", fillOpts.Prefix, "prefix of the names of the filled users and forums")
+	flags.Int64Var(&fillOpts.Seed, "seed", fillOpts.Seed, "seed of the random data and calls")
+	flags.IntVar(&fillOpts.Users, "users", fillOpts.Users, "users to fill")
+	flags.IntVar(&fillOpts.Forums, "forums", fillOpts.Forums, "forums to fill")
+	flags.IntVar(&fillOpts.Threads, "threads", fillOpts.Threads, "threads to fill")
+	flags.IntVar(&fillOpts.Posts, "posts", fillOpts.Posts, "posts to fill")
+	flags.IntVar(&fillOpts.Votes, "votes", fillOpts.Votes, "votes to fill")
+	flags.IntVar(&fillOpts.PostsPerBatch, "batch", fillOpts.PostsPerBatch, "posts per call when filling, smaller makes deeper trees")
+	flags.IntVar(&fillOpts.MaxDepth, "depth", fillOpts.MaxDepth, "maximum nesting of the filled posts")
+	flags.IntVar(&runOpts.Workers, "workers", runOpts.Workers, "concurrent callers")
+	flags.DurationVar(&runOpts.Duration, "duration", runOpts.Duration, "length of the run, 0 to skip it")
+	flags.IntVar(&runOpts.Requests, "requests", 0, "stop the run after this many calls")
+	_ = flags.Parse(args)
+
+	fillOpts.Workers = runOpts.Workers
+	runOpts.Seed = fillOpts.Seed
+
+	if *mix != "" {
+		var err error
+
+		runOpts.Mix, err = loadgen.ParseMix(*mix)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if *url == "" {
+		conn := openDB(dsn)
+
+		handler, _ := newServer(conn, openCluster(conn), dsn)
+
+		server := httptest.NewServer(handler)
+		defer server.Close()
+
+		*url = server.URL
+	}
+
+	client := loadgen.NewClient(*url, nil)
+	ctx := context.Background()
+
+	data := &loadgen.Dataset{}
+
+	if *fill {
+		var err error
+
+		data, err = loadgen.Fill(ctx, client, fillOpts)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if *dataset != "" {
+			writeDataset(*dataset, data)
+		}
+	} else {
+		if *dataset == "" {
+			log.Fatal("load needs -dataset to run without -fill")
+		}
+
+		data = readDataset(*dataset)
+	}
+
+	if runOpts.Duration == 0 && runOpts.Requests == 0 {
+		return
+	}
+
+	report, err := loadgen.Run(ctx, client, data, runOpts)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	printLoadReport(*asJSON, report)
+}
+
+func writeDataset(path string, data *loadgen.Dataset) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = os.WriteFile(path, body, 0o644)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func readDataset(path string) *loadgen.Dataset {
+	body, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	res := &loadgen.Dataset{}
+
+	err = json.Unmarshal(body, res)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return res
+}
+
+func printLoadReport(asJSON bool, report *loadgen.Report) {
+	if asJSON {
+		body, err := json.Marshal(report)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Println(string(body))
+
+		return
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', tabwriter.AlignRight)
+
+	fmt.Fprintln(writer, "ENDPOINT\tREQUESTS\t4XX\tFAILED\tRPS\tP50\tP90\tP95\tP99\tMAX\t")
+
+	for _, endpoint := range append(report.Endpoints, report.Total) {
+		fmt.Fprintf(writer, "%s\t%d\t%d\t%d\t%.1f\t%s\t%s\t%s\t%s\t%s\t\n", endpoint.Name, endpoint.Requests,
+			endpoint.ClientErrors, endpoint.Failures, endpoint.Throughput, millis(endpoint.P50), millis(endpoint.P90),
+			millis(endpoint.P95), millis(endpoint.P99), millis(endpoint.Max))
+	}
+
+	err := writer.Flush()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("\n%d calls in %s\n", report.Total.Requests, report.Elapsed.Round(time.Millisecond))
+
+	for _, endpoint := range report.Endpoints {
+		if endpoint.LastError != "" {
+			fmt.Printf("%s failed %d times, last with: %s\n", endpoint.Name, endpoint.Failures, endpoint.LastError)
+		}
+	}
+}
+
+// millis writes a latency in milliseconds.
+func millis(latency time.Duration) string {
+	return strconv.FormatFloat(float64(latency)/float64(time.Millisecond), 'f', 2, 64) + "ms"
+}
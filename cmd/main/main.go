@@ -2,43 +2,46 @@ package main
 
 import (
 	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
 	_ "github.com/jackc/pgx/stdlib"
 	_ "github.com/lib/pq"
-	"log"
-	"net/http"
-
-	handlForum "project/internal/forum/delivery/http"
-	handlPost "project/internal/post/delivery/http"
-	handlService "project/internal/service/delivery/http"
-	handlThread "project/internal/thread/delivery/http"
-	handlUser "project/internal/user/delivery/http"
-	handlVote "project/internal/vote/delivery/http"
-
-	usecaseForum "project/internal/forum/usecase"
-	usecasePost "project/internal/post/usecase"
-	usecaseSerivce "project/internal/service/usecase"
-	usecaseThread "project/internal/thread/usecase"
-	usecaseUser "project/internal/user/usecase"
-	usecaseVote "project/internal/vote/usecase"
-
-	repoForum "project/internal/forum/repository"
-	repoPost "project/internal/post/repository"
-	repoService "project/internal/service/repository"
-	repoThread "project/internal/thread/repository"
-	repoUser "project/internal/user/repository"
-	repoVote "project/internal/vote/repository"
-
-	"github.com/gorilla/mux"
-	"github.com/sirupsen/logrus"
+
+	"project/internal/attachment/storage"
+	usecaseAttachment "project/internal/attachment/usecase"
 	"project/internal/pkg"
+	"project/internal/pkg/cache"
+	"project/internal/pkg/ratelimit"
+	"project/internal/pkg/sqltools"
 )
 
-func main() {
-	logger := logrus.Logger{}
+const usage = `usage: main [command] [flags]
 
-	router := mux.NewRouter()
+commands:
+  serve                          start the HTTP server (default)
+  export                         write forum data as NDJSON
+  import                         read forum data written by export
+  user create|show|update NICK   manage a user
+  forum create|show SLUG         manage a forum
+  thread show|posts SLUG_OR_ID   show a thread or its posts
+  stats [SLUG]                   show the counts of the database, or the stats of a forum
+  clear -yes                     delete all forum data
+  recount                        recompute the counters kept by triggers
+  check [-repair]                look for counters and paths which drifted, and repair them
+  load [-url URL]                fill the database and report the latencies of a mix of API calls
+  seed [-profile P] [-seed N]    create the same dataset for the same profile and seed
 
-	conn, err := sql.Open("pgx", "user=brabra password=brabra dbname=brabra host=localhost port=5432 sslmode=disable")
+Commands showing data print a table, or the JSON of the API with -json. Run a command with -h for its flags.
+`
+
+func openDB(dsn string) *sql.DB {
+	conn, err := sql.Open("pgx", dsn)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -51,55 +54,239 @@ func main() {
 		log.Fatal(err)
 	}
 
-	forumStorage := repoForum.NewForumPostgres(conn)
-	userStorage := repoUser.NewUserPostgres(conn)
-	postStorage := repoPost.NewPostPostgres(conn)
-	threadStorage := repoThread.NewThreadPostgres(conn)
-	voteStorage := repoVote.NewVotePostgres(conn)
-	serviceStorage := repoService.NewServicePostgres(conn)
+	return conn
+}
+
+// openCluster adds the replicas listed in POSTGRES_REPLICAS to the primary. They are not pinged here: a replica
+// which is down at start is skipped by the health checks until it comes up.
+func openCluster(conn *sql.DB) *sqltools.Cluster {
+	var replicas []*sql.DB
+
+	for _, replicaDSN := range strings.Split(os.Getenv(pkg.EnvReplicaDSNs), pkg.ReplicaDSNsDelim) {
+		replicaDSN = strings.TrimSpace(replicaDSN)
+		if replicaDSN == "" {
+			continue
+		}
+
+		replica, err := sql.Open("pgx", replicaDSN)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		replica.SetMaxOpenConns(100)
+		replica.SetMaxIdleConns(100)
+
+		replicas = append(replicas, replica)
+	}
+
+	cluster := sqltools.NewCluster(conn, replicas...)
+
+	sticky := os.Getenv(pkg.EnvReplicaSticky)
+	if sticky != "" {
+		window, err := time.ParseDuration(sticky)
+		if err != nil {
+			log.Fatal(err)
+		}
 
-	forumService := usecaseForum.NewForumService(forumStorage, userStorage)
-	userService := usecaseUser.NewUserService(userStorage)
-	postService := usecasePost.NewPostService(postStorage)
-	threadService := usecaseThread.NewThreadService(threadStorage, forumStorage, userStorage, postStorage)
-	voteService := usecaseVote.NewVoteService(voteStorage, threadStorage, userStorage)
-	serivceService := usecaseSerivce.NewService(serviceStorage)
+		cluster.SetStickyWindow(window)
+	}
 
-	forumHandler := handlForum.NewForumHandler(forumService, router)
-	router.HandleFunc("/api/forum/create", forumHandler.CreateForumHandler).Methods(http.MethodPost)
-	router.HandleFunc("/api/forum/{slug}/details", forumHandler.GetForumHandler).Methods(http.MethodGet)
-	router.HandleFunc("/api/forum/{slug}/threads", forumHandler.GetForumThreads).Methods(http.MethodGet)
-	router.HandleFunc("/api/forum/{slug}/users", forumHandler.GetForumUsersHandler).Methods(http.MethodGet)
+	return cluster
+}
 
-	postHandler := handlPost.NewPostHandler(postService, router)
-	router.HandleFunc("/api/post/{id}/details", postHandler.GetPostHandler).Methods(http.MethodGet)
-	router.HandleFunc("/api/post/{id}/details", postHandler.UpdatePostHandler).Methods(http.MethodPost)
+// cacheConfig reads the bounds of each cache from CACHE_SIZE and CACHE_TTL. A size of 0 turns caching off.
+func cacheConfig() (int, time.Duration) {
+	capacity := cache.DefaultCapacity
+	ttl := cache.DefaultTTL
 
-	serviceHandler := handlService.NewServiceHandler(serivceService, router)
-	router.HandleFunc("/api/service/clear", serviceHandler.ServiceClearHandler).Methods(http.MethodPost)
-	router.HandleFunc("/api/service/status", serviceHandler.ServiceStatusHandler).Methods(http.MethodGet)
+	size := os.Getenv(pkg.EnvCacheSize)
+	if size != "" {
+		value, err := strconv.Atoi(size)
+		if err != nil {
+			log.Fatal(err)
+		}
 
-	threadHandler := handlThread.NewThreadHandler(threadService, router)
-	router.HandleFunc("/api/thread/{slug_or_id}/create", threadHandler.CreatePostsHandler).Methods(http.MethodPost)
-	router.HandleFunc("/api/forum/{slug}/create", threadHandler.CreateThreadHandler).Methods(http.MethodPost)
-	router.HandleFunc("/api/thread/{slug_or_id}/details", threadHandler.GetThreadHandler).Methods(http.MethodGet)
-	router.HandleFunc("/api/thread/{slug_or_id}/posts", threadHandler.GetPostsHandler).Methods(http.MethodGet)
-	router.HandleFunc("/api/thread/{slug_or_id}/details", threadHandler.UpdateThreadHandler).Methods(http.MethodPost)
+		capacity = value
+	}
 
-	voteHandler := handlVote.NewVoteHandler(voteService, router)
-	router.HandleFunc("/api/thread/{slug_or_id}/vote", voteHandler.VoteHandler).Methods(http.MethodPost)
+	expiry := os.Getenv(pkg.EnvCacheTTL)
+	if expiry != "" {
+		value, err := time.ParseDuration(expiry)
+		if err != nil {
+			log.Fatal(err)
+		}
 
-	userHandler := handlUser.NewUserHandler(userService, router)
-	router.HandleFunc("/api/user/{nickname}/create", userHandler.CreateUserHandler).Methods(http.MethodPost)
-	router.HandleFunc("/api/user/{nickname}/profile", userHandler.GetProfileHandler).Methods(http.MethodGet)
-	router.HandleFunc("/api/user/{nickname}/profile", userHandler.UpdateProfileHandler).Methods(http.MethodPost)
+		ttl = value
+	}
 
-	logrus.Info("server started :5000")
+	return capacity, ttl
+}
 
-	server := pkg.NewServerHTTP(&logger)
+// rateLimitConfig reads the rules of the rate limiter from RATE_LIMIT_IP, RATE_LIMIT_USER and RATE_LIMIT_ROUTES,
+// and the flood interval from FLOOD_INTERVAL. Everything is off by default.
+func rateLimitConfig() (ratelimit.Rules, time.Duration) {
+	rules := ratelimit.Rules{}
 
-	err = server.Launch(router)
+	var err error
+
+	rules.IP, err = ratelimit.ParseRule(os.Getenv(pkg.EnvRateLimitIP))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	rules.User, err = ratelimit.ParseRule(os.Getenv(pkg.EnvRateLimitUser))
 	if err != nil {
-		logrus.Fatal(err)
+		log.Fatal(err)
+	}
+
+	rules.Routes, err = ratelimit.ParseRoutes(os.Getenv(pkg.EnvRateLimitRoutes))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var interval time.Duration
+
+	flood := os.Getenv(pkg.EnvFloodInterval)
+	if flood != "" {
+		interval, err = time.ParseDuration(flood)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	return rules, interval
+}
+
+// openLimiter keeps the buckets in the process, or in Postgres when RATE_LIMIT_SHARED is set, for the replicas of
+// the server to share them.
+func openLimiter(cluster *sqltools.Cluster) ratelimit.Limiter {
+	shared, _ := strconv.ParseBool(os.Getenv(pkg.EnvRateLimitShared))
+	if shared {
+		return ratelimit.NewPostgres(cluster)
+	}
+
+	return ratelimit.NewMemory()
+}
+
+// consistencyConfig reads how often the consistency checker runs from CONSISTENCY_INTERVAL, off by default, and
+// whether it repairs what it finds from CONSISTENCY_REPAIR.
+func consistencyConfig() (time.Duration, bool) {
+	var interval time.Duration
+
+	var err error
+
+	value := os.Getenv(pkg.EnvConsistencyInterval)
+	if value != "" {
+		interval, err = time.ParseDuration(value)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	repair, _ := strconv.ParseBool(os.Getenv(pkg.EnvConsistencyRepair))
+
+	return interval, repair
+}
+
+// attachmentConfig opens the storage of the attachments in ATTACHMENT_DIR, refusing files over ATTACHMENT_MAX_SIZE
+// bytes and of types not in ATTACHMENT_TYPES, and reads how long uploads no post took are kept from
+// ATTACHMENT_ORPHAN_TTL.
+func attachmentConfig() (storage.Storage, time.Duration) {
+	dir := os.Getenv(pkg.EnvAttachmentDir)
+	if dir == "" {
+		dir = filepath.Join(os.TempDir(), "attachments")
+	}
+
+	maxSize := int64(storage.DefaultMaxSize)
+
+	size := os.Getenv(pkg.EnvAttachmentMaxSize)
+	if size != "" {
+		value, err := strconv.ParseInt(size, 10, 64)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		maxSize = value
+	}
+
+	types := storage.DefaultTypes
+
+	allowed := os.Getenv(pkg.EnvAttachmentTypes)
+	if allowed != "" {
+		types = strings.Split(allowed, pkg.AttachmentTypesDelim)
+	}
+
+	ttl := usecaseAttachment.DefaultOrphanTTL
+
+	expiry := os.Getenv(pkg.EnvAttachmentOrphanTTL)
+	if expiry != "" {
+		value, err := time.ParseDuration(expiry)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		ttl = value
+	}
+
+	local, err := storage.NewLocal(dir, maxSize, types)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return local, ttl
+}
+
+// wsOrigins reads the origins, besides the own one of the server, which pages may open the WebSocket gateway from,
+// from WS_ALLOWED_ORIGINS.
+func wsOrigins() []string {
+	origins := os.Getenv(pkg.EnvWSOrigins)
+	if origins == "" {
+		return nil
+	}
+
+	return strings.Split(origins, pkg.WSOriginsDelim)
+}
+
+func main() {
+	dsn := "user=brabra password=brabra dbname=brabra host=localhost port=5432 sslmode=disable"
+
+	command := "serve"
+	args := []string{}
+
+	if len(os.Args) > 1 {
+		command = os.Args[1]
+		args = os.Args[2:]
+	}
+
+	switch command {
+	case "serve":
+		conn := openDB(dsn)
+
+		runServer(conn, openCluster(conn), dsn)
+	case "export":
+		runExport(dsn, args)
+	case "import":
+		runImport(dsn, args)
+	case "user":
+		runUser(dsn, args)
+	case "forum":
+		runForum(dsn, args)
+	case "thread":
+		runThread(dsn, args)
+	case "stats":
+		runStats(dsn, args)
+	case "clear":
+		runClear(dsn, args)
+	case "recount":
+		runRecount(dsn, args)
+	case "check":
+		runCheck(dsn, args)
+	case "load":
+		runLoad(dsn, args)
+	case "seed":
+		runSeed(dsn, args)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
 	}
 }
@@ -0,0 +1,303 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	handlAttachment "project/internal/attachment/delivery/http"
+	handlAudit "project/internal/audit/delivery/http"
+	handlBatch "project/internal/batch/delivery/http"
+	handlEvent "project/internal/event/delivery/http"
+	wsEvent "project/internal/event/delivery/ws"
+	handlFeed "project/internal/feed/delivery/http"
+	handlForum "project/internal/forum/delivery/http"
+	handlGraphQL "project/internal/graphql/delivery/http"
+	handlPost "project/internal/post/delivery/http"
+	handlSeed "project/internal/seed/delivery/http"
+	handlService "project/internal/service/delivery/http"
+	handlThread "project/internal/thread/delivery/http"
+	handlTransfer "project/internal/transfer/delivery/http"
+	handlUser "project/internal/user/delivery/http"
+	handlVote "project/internal/vote/delivery/http"
+	handlWebhook "project/internal/webhook/delivery/http"
+
+	resolverGraphQL "project/internal/graphql/resolver"
+
+	grpcForum "project/internal/forum/delivery/grpc"
+	grpcPost "project/internal/post/delivery/grpc"
+	grpcService "project/internal/service/delivery/grpc"
+	grpcThread "project/internal/thread/delivery/grpc"
+	grpcUser "project/internal/user/delivery/grpc"
+	grpcVote "project/internal/vote/delivery/grpc"
+
+	usecaseAttachment "project/internal/attachment/usecase"
+	usecaseAudit "project/internal/audit/usecase"
+	usecaseConsistency "project/internal/consistency/usecase"
+	usecaseEvent "project/internal/event/usecase"
+	usecaseFeed "project/internal/feed/usecase"
+	usecaseForum "project/internal/forum/usecase"
+	usecasePost "project/internal/post/usecase"
+	usecaseSeed "project/internal/seed/usecase"
+	usecaseSerivce "project/internal/service/usecase"
+	usecaseThread "project/internal/thread/usecase"
+	usecaseTransfer "project/internal/transfer/usecase"
+	usecaseUser "project/internal/user/usecase"
+	usecaseVote "project/internal/vote/usecase"
+	usecaseWebhook "project/internal/webhook/usecase"
+
+	repoAttachment "project/internal/attachment/repository"
+	repoAudit "project/internal/audit/repository"
+	repoConsistency "project/internal/consistency/repository"
+	repoEvent "project/internal/event/repository"
+	repoFeed "project/internal/feed/repository"
+	repoForum "project/internal/forum/repository"
+	repoPost "project/internal/post/repository"
+	repoService "project/internal/service/repository"
+	repoThread "project/internal/thread/repository"
+	repoTransfer "project/internal/transfer/repository"
+	repoUser "project/internal/user/repository"
+	repoVote "project/internal/vote/repository"
+	repoWebhook "project/internal/webhook/repository"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"project/internal/models"
+	"project/internal/pb"
+	"project/internal/pkg"
+	"project/internal/pkg/cache"
+	"project/internal/pkg/grpctools"
+	"project/internal/pkg/ratelimit"
+	"project/internal/pkg/sqltools"
+)
+
+// newServer wires the usecases and handlers of both APIs on the database and starts their background jobs.
+func newServer(conn *sql.DB, cluster *sqltools.Cluster, dsn string) (http.Handler, *grpc.Server) {
+	rules, floodInterval := rateLimitConfig()
+
+	limiter := openLimiter(cluster)
+
+	router := mux.NewRouter()
+	router.Use(pkg.RequestIDMiddleware)
+	router.Use(pkg.IdentityMiddleware)
+	router.Use(ratelimit.Middleware(limiter, rules))
+	router.Use(pkg.ConsistencyMiddleware)
+	router.Use(pkg.ConditionalMiddleware)
+	router.Use(pkg.TimeoutMiddleware(pkg.RequestTimeout))
+
+	capacity, ttl := cacheConfig()
+
+	forumCache := cache.NewLRU[models.Forum](capacity, ttl)
+	forumLinkCache := cache.NewLRU[repoForum.ForumLink](capacity, ttl)
+	threadCache := cache.NewLRU[models.Thread](capacity, ttl)
+	userCache := cache.NewLRU[models.User](capacity, ttl)
+
+	caches := cache.NewSet()
+	caches.Add("forum", forumCache)
+	caches.Add("forum_link", forumLinkCache)
+	caches.Add("thread", threadCache)
+	caches.Add("user", userCache)
+
+	forumStorage := repoForum.NewForumCache(repoForum.NewForumPostgres(cluster), forumCache, forumLinkCache)
+	userStorage := repoUser.NewUserCache(repoUser.NewUserPostgres(cluster), userCache)
+	postStorage := repoPost.NewPostPostgres(cluster)
+	threadStorage := repoThread.NewThreadCache(repoThread.NewThreadPostgres(cluster), threadCache, forumStorage)
+	voteStorage := repoVote.NewVoteCache(repoVote.NewVotePostgres(cluster), threadStorage)
+	serviceStorage := repoService.NewServicePostgres(cluster)
+	eventStorage := repoEvent.NewEventPostgres(conn, dsn)
+	webhookStorage := repoWebhook.NewWebhookPostgres(conn)
+	feedStorage := repoFeed.NewFeedPostgres(conn)
+	transferStorage := repoTransfer.NewTransferPostgres(conn)
+	auditStorage := repoAudit.NewAuditPostgres(cluster)
+	consistencyStorage := repoConsistency.NewConsistencyPostgres(cluster)
+	attachmentStorage := repoAttachment.NewAttachmentPostgres(cluster)
+
+	forumService := usecaseForum.NewForumService(forumStorage, userStorage)
+	userService := usecaseUser.NewUserService(userStorage)
+	postService := usecasePost.NewPostService(postStorage, forumStorage)
+	threadService := usecaseThread.NewThreadService(threadStorage, forumStorage, userStorage, postStorage, ratelimit.NewFlood(limiter, floodInterval))
+	voteService := usecaseVote.NewVoteService(voteStorage, threadStorage, userStorage, forumStorage)
+	serivceService := usecaseSerivce.NewService(serviceStorage, consistencyStorage, caches)
+	eventService := usecaseEvent.NewEventService(eventStorage, threadStorage, forumStorage, userStorage)
+	transferService := usecaseTransfer.NewTransferService(transferStorage, caches)
+	feedService := usecaseFeed.NewFeedService(feedStorage, forumStorage, threadStorage, postStorage, userStorage)
+	webhookService := usecaseWebhook.NewWebhookService(webhookStorage, forumStorage,
+		handlWebhook.NewSender(usecaseWebhook.PublicAddress), usecaseWebhook.PublicAddress)
+	auditService := usecaseAudit.NewAuditService(auditStorage)
+	// Seeding posts in quick succession, it goes without flood control
+	seedService := usecaseSeed.NewSeedService(userService, forumService,
+		usecaseThread.NewThreadService(threadStorage, forumStorage, userStorage, postStorage, nil), voteService, caches)
+
+	blobStorage, orphanTTL := attachmentConfig()
+	attachmentService := usecaseAttachment.NewAttachmentService(attachmentStorage, postStorage, forumStorage, userStorage, blobStorage, orphanTTL)
+
+	consistencyInterval, consistencyRepair := consistencyConfig()
+	consistencyService := usecaseConsistency.NewConsistencyService(consistencyStorage, consistencyInterval, consistencyRepair, caches)
+
+	go func() {
+		err := cluster.Run(context.Background())
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	go func() {
+		err := limiter.Run(context.Background())
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	go func() {
+		err := eventService.Run(context.Background())
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	go func() {
+		err := webhookService.Run(context.Background())
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	go func() {
+		err := consistencyService.Run(context.Background())
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	go func() {
+		err := attachmentService.Run(context.Background())
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	forumHandler := handlForum.NewForumHandler(forumService, router)
+	router.HandleFunc("/api/forum/create", forumHandler.CreateForumHandler).Methods(http.MethodPost)
+	router.HandleFunc("/api/forum/{slug}/details", forumHandler.GetForumHandler).Methods(http.MethodGet)
+	router.HandleFunc("/api/forum/{slug}/threads", forumHandler.GetForumThreads).Methods(http.MethodGet)
+	router.HandleFunc("/api/forum/{slug}/users", forumHandler.GetForumUsersHandler).Methods(http.MethodGet)
+	router.HandleFunc("/api/forum/{slug}/children", forumHandler.GetForumChildrenHandler).Methods(http.MethodGet)
+	router.HandleFunc("/api/forum/{slug}/stats", forumHandler.GetForumStatsHandler).Methods(http.MethodGet)
+	router.HandleFunc("/api/forum/{slug}/invite", forumHandler.InviteMemberHandler).Methods(http.MethodPost)
+	router.HandleFunc("/api/forum/{slug}/accept", forumHandler.AcceptInviteHandler).Methods(http.MethodPost)
+	router.HandleFunc("/api/forum/{slug}/leave", forumHandler.LeaveForumHandler).Methods(http.MethodPost)
+
+	postHandler := handlPost.NewPostHandler(postService, router)
+	router.HandleFunc("/api/post/{id}/details", postHandler.GetPostHandler).Methods(http.MethodGet)
+	router.HandleFunc("/api/post/{id}/details", postHandler.UpdatePostHandler).Methods(http.MethodPost)
+
+	serviceHandler := handlService.NewServiceHandler(serivceService, router)
+	router.HandleFunc("/api/service/clear", serviceHandler.ServiceClearHandler).Methods(http.MethodPost)
+	router.HandleFunc("/api/service/status", serviceHandler.ServiceStatusHandler).Methods(http.MethodGet)
+	router.HandleFunc("/api/service/cache", serviceHandler.ServiceCacheStatsHandler).Methods(http.MethodGet)
+
+	transferHandler := handlTransfer.NewTransferHandler(transferService, router)
+	router.Handle("/api/service/export", pkg.AdminMiddleware(http.HandlerFunc(transferHandler.ExportHandler))).Methods(http.MethodGet).Name(pkg.StreamRoutePrefix + "-export")
+	router.Handle("/api/service/import", pkg.AdminMiddleware(http.HandlerFunc(transferHandler.ImportHandler))).Methods(http.MethodPost).Name(pkg.StreamRoutePrefix + "-import")
+
+	auditHandler := handlAudit.NewAuditHandler(auditService, router)
+	router.Handle("/api/admin/audit", pkg.AdminMiddleware(http.HandlerFunc(auditHandler.GetAuditHandler))).Methods(http.MethodGet)
+
+	seedHandler := handlSeed.NewSeedHandler(seedService, router)
+	router.Handle("/api/service/seed", pkg.AdminMiddleware(http.HandlerFunc(seedHandler.SeedHandler))).Methods(http.MethodPost).Name(pkg.StreamRoutePrefix + "-seed")
+
+	attachmentHandler := handlAttachment.NewAttachmentHandler(attachmentService, router)
+	router.HandleFunc("/api/attachments", attachmentHandler.UploadHandler).Methods(http.MethodPost).Name(pkg.StreamRoutePrefix + "-attachment-upload")
+	router.HandleFunc("/api/attachments/{id}", attachmentHandler.GetAttachmentHandler).Methods(http.MethodGet).Name(pkg.StreamRoutePrefix + "-attachment")
+
+	threadHandler := handlThread.NewThreadHandler(threadService, router)
+	router.HandleFunc("/api/thread/{slug_or_id}/create", threadHandler.CreatePostsHandler).Methods(http.MethodPost)
+	router.HandleFunc("/api/forum/{slug}/create", threadHandler.CreateThreadHandler).Methods(http.MethodPost)
+	router.HandleFunc("/api/thread/{slug_or_id}/details", threadHandler.GetThreadHandler).Methods(http.MethodGet)
+	router.HandleFunc("/api/thread/{slug_or_id}/posts", threadHandler.GetPostsHandler).Methods(http.MethodGet)
+	router.HandleFunc("/api/thread/{slug_or_id}/details", threadHandler.UpdateThreadHandler).Methods(http.MethodPost)
+	router.HandleFunc("/api/thread/{slug_or_id}/read", threadHandler.MarkReadHandler).Methods(http.MethodPost)
+	router.HandleFunc("/api/threads/trending", threadHandler.GetTrendingHandler).Methods(http.MethodGet)
+
+	eventHandler := handlEvent.NewEventHandler(eventService, router)
+	router.HandleFunc("/api/thread/{slug_or_id}/stream", eventHandler.ThreadStreamHandler).Methods(http.MethodGet).Name(pkg.StreamRoutePrefix + "-thread")
+
+	gateway := wsEvent.NewGateway(eventService, router, wsOrigins())
+	router.HandleFunc("/api/ws", gateway.GatewayHandler).Methods(http.MethodGet).Name(pkg.StreamRoutePrefix + "-gateway")
+
+	webhookHandler := handlWebhook.NewWebhookHandler(webhookService, router)
+	router.HandleFunc("/api/forum/{slug}/webhooks", webhookHandler.CreateWebhookHandler).Methods(http.MethodPost)
+	router.HandleFunc("/api/forum/{slug}/webhooks", webhookHandler.GetWebhooksHandler).Methods(http.MethodGet)
+	router.HandleFunc("/api/forum/{slug}/webhooks/{id}", webhookHandler.DeleteWebhookHandler).Methods(http.MethodDelete)
+	router.HandleFunc("/api/forum/{slug}/webhooks/{id}/deliveries", webhookHandler.GetDeliveriesHandler).Methods(http.MethodGet)
+	router.HandleFunc("/api/forum/{slug}/webhooks/{id}/deliveries/{delivery}/replay", webhookHandler.ReplayDeliveryHandler).Methods(http.MethodPost)
+
+	feedHandler := handlFeed.NewFeedHandler(feedService, router)
+	router.HandleFunc("/api/forum/{slug}/feed.atom", feedHandler.ForumFeedHandler).Methods(http.MethodGet)
+	router.HandleFunc("/api/thread/{slug_or_id}/feed.rss", feedHandler.ThreadFeedHandler).Methods(http.MethodGet)
+	router.HandleFunc("/api/user/{nickname}/feed.atom", feedHandler.UserFeedHandler).Methods(http.MethodGet)
+
+	voteHandler := handlVote.NewVoteHandler(voteService, router)
+	router.HandleFunc("/api/thread/{slug_or_id}/vote", voteHandler.VoteHandler).Methods(http.MethodPost)
+
+	userHandler := handlUser.NewUserHandler(userService, router)
+	router.HandleFunc("/api/user/{nickname}/create", userHandler.CreateUserHandler).Methods(http.MethodPost)
+	router.HandleFunc("/api/users/batch", userHandler.CreateUsersHandler).Methods(http.MethodPost)
+	router.HandleFunc("/api/user/{nickname}/profile", userHandler.GetProfileHandler).Methods(http.MethodGet)
+	router.HandleFunc("/api/user/{nickname}/profile", userHandler.UpdateProfileHandler).Methods(http.MethodPost)
+
+	graphqlResolver := resolverGraphQL.NewResolver(forumService, threadService, postService, userService, voteService)
+
+	graphqlSchema, err := resolverGraphQL.NewSchema(graphqlResolver)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	graphqlHandler := handlGraphQL.NewGraphQLHandler(graphqlResolver, graphqlSchema, router)
+	router.HandleFunc("/api/graphql", graphqlHandler.GraphQLHandler).Methods(http.MethodGet, http.MethodPost)
+
+	batchHandler := handlBatch.NewBatchHandler(router)
+	router.HandleFunc("/api/batch", batchHandler.BatchHandler).Methods(http.MethodPost).Name(pkg.BatchRouteName)
+
+	grpcServer := grpctools.NewServer(grpctools.RateLimitUnaryInterceptor(limiter, rules))
+	pb.RegisterUserServiceServer(grpcServer, grpcUser.NewUserServer(userService))
+	pb.RegisterForumServiceServer(grpcServer, grpcForum.NewForumServer(forumService))
+	pb.RegisterThreadServiceServer(grpcServer, grpcThread.NewThreadServer(threadService))
+	pb.RegisterVoteServiceServer(grpcServer, grpcVote.NewVoteServer(voteService))
+	pb.RegisterPostServiceServer(grpcServer, grpcPost.NewPostServer(postService))
+	pb.RegisterStatusServiceServer(grpcServer, grpcService.NewStatusServer(serivceService))
+
+	return router, grpcServer
+}
+
+func runServer(conn *sql.DB, cluster *sqltools.Cluster, dsn string) {
+	logger := logrus.Logger{}
+
+	router, grpcServer := newServer(conn, cluster, dsn)
+
+	switch {
+	case pkg.IdentityTrusted():
+		logrus.Warn(pkg.EnvIdentityTrusted + " is set, X-Nickname is trusted as sent: serve behind a proxy which authenticates the users and sets it")
+	case pkg.IdentityOff():
+		logrus.Warn("neither " + pkg.EnvIdentitySecret + " nor " + pkg.EnvIdentityTrusted + " is set, requests with X-Nickname are refused")
+	}
+
+	go func() {
+		logrus.Info("grpc server started " + grpctools.ServerAddr)
+
+		err := grpctools.Launch(grpcServer, grpctools.ServerAddr)
+		if err != nil {
+			logrus.Fatal(err)
+		}
+	}()
+
+	logrus.Info("server started :5000")
+
+	server := pkg.NewServerHTTP(&logger)
+
+	err := server.Launch(router)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+}
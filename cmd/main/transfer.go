@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"project/internal/models"
+	"project/internal/pkg/cache"
+	transferModels "project/internal/transfer/delivery/models"
+	repoTransfer "project/internal/transfer/repository"
+	usecaseTransfer "project/internal/transfer/usecase"
+)
+
+func newTransferService(dsn string) usecaseTransfer.TransferService {
+	return usecaseTransfer.NewTransferService(repoTransfer.NewTransferPostgres(openDB(dsn)), cache.NewSet())
+}
+
+// runExport writes the database, or a single forum, to a file or to stdout.
+func runExport(dsn string, args []string) {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	forum := flags.String("forum", "", "export only this forum and its sub-forums")
+	output := flags.String("o", "", "output file, stdout by default")
+	_ = flags.Parse(args)
+
+	var out io.Writer = os.Stdout
+
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer file.Close()
+
+		out = file
+	}
+
+	writer := transferModels.NewTransferWriter(out)
+
+	err := newTransferService(dsn).Export(context.Background(), &models.Forum{Slug: *forum}, writer.Write)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// runImport reads an export from a file or from stdin.
+func runImport(dsn string, args []string) {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	ids := flags.String("ids", "keep", "keep original thread and post ids or remap them")
+	_ = flags.Parse(args)
+
+	var in io.Reader = os.Stdin
+
+	if flags.NArg() > 0 {
+		file, err := os.Open(flags.Arg(0))
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer file.Close()
+
+		in = file
+	}
+
+	reader := transferModels.NewTransferReader(in)
+
+	stats, err := newTransferService(dsn).Import(context.Background(), reader.Next, *ids)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("users: %d (%d existing), forums: %d, members: %d, threads: %d, posts: %d, votes: %d\n",
+		stats.Users, stats.SkippedUsers, stats.Forums, stats.Members, stats.Threads, stats.Posts, stats.Votes)
+}
@@ -5,7 +5,8 @@ CREATE UNLOGGED TABLE IF NOT EXISTS users (
     nickname citext COLLATE "ucs_basic" NOT NULL UNIQUE PRIMARY KEY,
     fullname text                       NOT NULL,
     about    text,
-    email    citext                     NOT NULL UNIQUE
+    email    citext                     NOT NULL UNIQUE,
+    version  bigint                     NOT NULL DEFAULT 1
 );
 
 CREATE UNLOGGED TABLE IF NOT EXISTS forums (
@@ -14,7 +15,20 @@ CREATE UNLOGGED TABLE IF NOT EXISTS forums (
     slug           citext NOT NULL PRIMARY KEY,
     title          text   NOT NULL,
     posts          int DEFAULT 0,
-    threads        int DEFAULT 0
+    threads        int DEFAULT 0,
+    parent         citext REFERENCES forums (slug),
+    roll_up        bool DEFAULT FALSE,
+    visibility     text   NOT NULL DEFAULT 'public' CHECK (visibility IN ('public', 'private', 'read-only')),
+    version        bigint NOT NULL DEFAULT 1
+);
+
+CREATE UNLOGGED TABLE IF NOT EXISTS forum_members (
+    forum      citext                     NOT NULL REFERENCES forums (slug),
+    nickname   citext COLLATE "ucs_basic" NOT NULL REFERENCES users (nickname),
+    status     text                       NOT NULL DEFAULT 'invited' CHECK (status IN ('invited', 'member')),
+    invited_by citext REFERENCES users (nickname),
+    created    timestamp with time zone DEFAULT now(),
+    CONSTRAINT forum_member_key unique (forum, nickname)
 );
 
 CREATE UNLOGGED TABLE IF NOT EXISTS threads (
@@ -24,8 +38,16 @@ CREATE UNLOGGED TABLE IF NOT EXISTS threads (
     title     text                  NOT NULL,
     message   text                  NOT NULL,
     votes     integer                  DEFAULT 0,
-    slug      citext,
-    created   timestamp with time zone DEFAULT now()
+    slug       citext,
+    created    timestamp with time zone DEFAULT now(),
+    modified   timestamp with time zone DEFAULT now(),
+    version    bigint                NOT NULL DEFAULT 1,
+    last_event bigint                   DEFAULT 0,
+    -- Ranking, see function_hot_score. last_post_at is the creation time until the first post.
+    hot_score        double precision NOT NULL DEFAULT 0,
+    last_post_at     timestamp with time zone DEFAULT now(),
+    last_post_author citext,
+    replies          integer                  DEFAULT 0
 );
 
 CREATE UNLOGGED TABLE IF NOT EXISTS posts (
@@ -37,6 +59,8 @@ CREATE UNLOGGED TABLE IF NOT EXISTS posts (
     message   text                  NOT NULL,
     is_edited bool                     DEFAULT FALSE,
     created   timestamp with time zone DEFAULT now(),
+    modified  timestamp with time zone DEFAULT now(),
+    version   bigint                NOT NULL DEFAULT 1,
     path      bigint[]                 DEFAULT ARRAY []::INTEGER[]
 );
 
@@ -46,6 +70,94 @@ CREATE UNLOGGED TABLE IF NOT EXISTS user_votes (
     voice     int    NOT NULL
 );
 
+CREATE UNLOGGED TABLE IF NOT EXISTS events (
+    thread_id bigint NOT NULL,
+    seq       bigint NOT NULL,
+    kind      text   NOT NULL,
+    entity_id bigint NOT NULL,
+    created   timestamp with time zone DEFAULT now(),
+    CONSTRAINT event_key PRIMARY KEY (thread_id, seq)
+);
+
+-- Retention deletes by age
+CREATE INDEX IF NOT EXISTS event_created ON events (created);
+
+-- The highest number retention has deleted from each thread. A stream resuming from below it has lost events.
+CREATE UNLOGGED TABLE IF NOT EXISTS events_horizon (
+    thread_id bigint PRIMARY KEY,
+    seq       bigint NOT NULL
+);
+
+-- Webhooks and their outbox are logged, unlike the rest, so that queued deliveries survive a crash.
+-- Logged tables cannot reference unlogged ones, hence no foreign keys to forums.
+CREATE TABLE IF NOT EXISTS webhooks (
+    webhook_id bigserial PRIMARY KEY,
+    forum      citext NOT NULL,
+    url        text   NOT NULL,
+    secret     text   NOT NULL,
+    events     text[] NOT NULL,
+    created    timestamp with time zone DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS webhook_forum ON webhooks (forum);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    delivery_id  bigserial PRIMARY KEY,
+    webhook_id   bigint NOT NULL REFERENCES webhooks (webhook_id) ON DELETE CASCADE,
+    kind         text   NOT NULL,
+    thread_id    bigint NOT NULL,
+    seq          bigint NOT NULL,
+    entity_id    bigint NOT NULL,
+    -- The post or thread as it was when the event happened, so that retries do not depend on events retention
+    data         jsonb,
+    payload      text,
+    status       text   NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'dead')),
+    attempts     int    NOT NULL DEFAULT 0,
+    next_attempt timestamp with time zone DEFAULT now(),
+    last_status  int    NOT NULL DEFAULT 0,
+    last_error   text   NOT NULL DEFAULT '',
+    created      timestamp with time zone DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS webhook_delivery_pending ON webhook_deliveries (next_attempt) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS webhook_delivery_webhook ON webhook_deliveries (webhook_id, delivery_id);
+
+-- The audit log outlives clearing the service and is append-only, entries are never changed or deleted.
+CREATE TABLE IF NOT EXISTS audit_log (
+    audit_id   bigserial PRIMARY KEY,
+    actor      citext,
+    request_id text,
+    entity     text NOT NULL,
+    entity_id  text NOT NULL,
+    action     text NOT NULL,
+    before     jsonb,
+    after      jsonb,
+    created    timestamp with time zone DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS audit_entity ON audit_log (entity, entity_id, audit_id);
+CREATE INDEX IF NOT EXISTS audit_actor ON audit_log (actor, audit_id);
+CREATE INDEX IF NOT EXISTS audit_created ON audit_log (created);
+
+CREATE OR REPLACE FUNCTION function_audit_append_only() RETURNS TRIGGER AS
+$$
+BEGIN
+    RAISE EXCEPTION 'audit log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_append_only
+    BEFORE UPDATE OR DELETE
+    ON audit_log
+    FOR EACH ROW
+EXECUTE PROCEDURE function_audit_append_only();
+
+CREATE TRIGGER audit_no_truncate
+    BEFORE TRUNCATE
+    ON audit_log
+    FOR EACH STATEMENT
+EXECUTE PROCEDURE function_audit_append_only();
+
 CREATE UNLOGGED TABLE IF NOT EXISTS user_forums (
     nickname citext COLLATE "ucs_basic" NOT NULL REFERENCES users (nickname),
     forum    citext                     NOT NULL REFERENCES forums (slug),
@@ -55,6 +167,114 @@ CREATE UNLOGGED TABLE IF NOT EXISTS user_forums (
     CONSTRAINT user_forum_key unique (nickname, forum)
 );
 
+-- Rollups behind the forum stats, kept up by the triggers of posts, threads and votes so that the stats never scan
+-- posts. Days are UTC.
+CREATE UNLOGGED TABLE IF NOT EXISTS forum_activity (
+    forum   citext NOT NULL REFERENCES forums (slug),
+    day     date   NOT NULL,
+    posts   bigint NOT NULL DEFAULT 0,
+    threads bigint NOT NULL DEFAULT 0,
+    PRIMARY KEY (forum, day)
+);
+
+CREATE UNLOGGED TABLE IF NOT EXISTS forum_active_users (
+    forum    citext NOT NULL REFERENCES forums (slug),
+    day      date   NOT NULL,
+    nickname citext NOT NULL,
+    PRIMARY KEY (forum, day, nickname)
+);
+
+-- Votes are the ones received by the threads of the author.
+CREATE UNLOGGED TABLE IF NOT EXISTS forum_authors (
+    forum    citext NOT NULL REFERENCES forums (slug),
+    nickname citext NOT NULL,
+    posts    bigint NOT NULL DEFAULT 0,
+    votes    bigint NOT NULL DEFAULT 0,
+    PRIMARY KEY (forum, nickname)
+);
+
+-- The depth of a thread is the longest path of its posts, 0 while it has none.
+CREATE UNLOGGED TABLE IF NOT EXISTS thread_depths (
+    thread_id bigint NOT NULL PRIMARY KEY REFERENCES threads (thread_id),
+    depth     int    NOT NULL DEFAULT 0
+);
+
+CREATE UNLOGGED TABLE IF NOT EXISTS forum_depths (
+    forum   citext NOT NULL PRIMARY KEY REFERENCES forums (slug),
+    threads bigint NOT NULL DEFAULT 0,
+    depth   bigint NOT NULL DEFAULT 0
+);
+
+-- Read positions of users in threads, as the count of replies seen when the thread was marked read.
+CREATE UNLOGGED TABLE IF NOT EXISTS thread_reads (
+    nickname  citext NOT NULL REFERENCES users (nickname),
+    thread_id bigint NOT NULL REFERENCES threads (thread_id),
+    replies   int    NOT NULL DEFAULT 0,
+    updated   timestamp with time zone DEFAULT now(),
+    PRIMARY KEY (nickname, thread_id)
+);
+
+-- Runs of the consistency checker, the last ones are kept for the service status.
+CREATE TABLE IF NOT EXISTS consistency_runs (
+    run_id   bigserial PRIMARY KEY,
+    started  timestamp with time zone NOT NULL,
+    finished timestamp with time zone NOT NULL DEFAULT now(),
+    repair   bool                     NOT NULL,
+    checks   jsonb                    NOT NULL
+);
+
+-- Files uploaded for posts, their content being in the attachment storage under storage_key. Uploads no post took
+-- are orphans, collected after a while along with the stored files no row knows of, such as those of rows lost in a
+-- crash of the database.
+CREATE UNLOGGED TABLE IF NOT EXISTS attachments (
+    attachment_id bigserial PRIMARY KEY,
+    post_id       bigint REFERENCES posts (post_id),
+    author        citext                   NOT NULL REFERENCES users (nickname),
+    filename      text                     NOT NULL,
+    mime_type     text                     NOT NULL,
+    size          bigint                   NOT NULL,
+    checksum      text                     NOT NULL,
+    storage_key   text                     NOT NULL UNIQUE,
+    created       timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS attachment_post ON attachments (post_id);
+CREATE INDEX IF NOT EXISTS attachment_orphan ON attachments (created) WHERE post_id IS NULL;
+
+-- Token buckets of the rate limiter shared by the replicas of the server, losing them on a crash is harmless.
+CREATE UNLOGGED TABLE IF NOT EXISTS rate_limits (
+    key     text PRIMARY KEY,
+    tokens  double precision         NOT NULL,
+    updated timestamp with time zone NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS rate_limit_updated ON rate_limits (updated);
+
+-- Refills the bucket of the key and takes a token from it, returning the seconds to wait for one if it is empty.
+-- The upsert locks the row, so concurrent calls for one key queue up.
+CREATE OR REPLACE FUNCTION function_take_token(_key text, _rate double precision, _burst double precision)
+    RETURNS double precision AS
+$$
+DECLARE
+    _now    timestamp with time zone := clock_timestamp();
+    _tokens double precision;
+BEGIN
+    INSERT INTO rate_limits(key, tokens, updated)
+    VALUES (_key, _burst, _now)
+    ON CONFLICT (key) DO UPDATE
+        SET tokens  = LEAST(_burst, rate_limits.tokens + EXTRACT(EPOCH FROM _now - rate_limits.updated) * _rate),
+            updated = _now
+    RETURNING tokens INTO _tokens;
+
+    IF _tokens < 1 THEN
+        RETURN (1 - _tokens) / _rate;
+    END IF;
+
+    UPDATE rate_limits SET tokens = tokens - 1 WHERE key = _key;
+    RETURN 0;
+END;
+$$ LANGUAGE plpgsql;
+
 CREATE OR REPLACE FUNCTION function_path_update() RETURNS TRIGGER AS
 $$
 BEGIN
@@ -69,15 +289,215 @@ CREATE TRIGGER path_upd
     FOR EACH ROW
 EXECUTE PROCEDURE function_path_update();
 
-CREATE OR REPLACE FUNCTION function_insert_votes_into_threads()
+-- Hot scores are the log of the activity of a thread, each post and vote weighted by e^(t / 45000) for its time t
+-- in seconds: a thread needs e times the activity to rank with one 12.5 hours younger. Kept in log space the scores
+-- neither decay nor overflow, so adding an event is a log-sum-exp on the row and listings stay on an index.
+CREATE OR REPLACE FUNCTION function_hot_score(_score double precision, _weight double precision, _at timestamptz)
+    RETURNS double precision AS
+$$
+DECLARE
+    _x   double precision := extract(EPOCH FROM _at) / 45000;
+    _max double precision := greatest(_score, _x);
+BEGIN
+    -- Downvotes may take the sum to nothing, which ranks the thread as if it had a sliver of activity
+    RETURN _max + ln(greatest(exp(_score - _max) + _weight * exp(_x - _max), 1e-9));
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION function_thread_rank() RETURNS TRIGGER AS
+$$
+BEGIN
+    NEW.hot_score = extract(EPOCH FROM coalesce(NEW.created, now())) / 45000;
+    NEW.last_post_at = NEW.created;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER thread_rank
+    BEFORE INSERT
+    ON threads
+    FOR EACH ROW
+EXECUTE PROCEDURE function_thread_rank();
+
+CREATE OR REPLACE FUNCTION function_rank_posts()
     RETURNS TRIGGER AS
 $$
 BEGIN
     UPDATE threads
-    SET votes = votes + NEW.voice
+    SET hot_score = function_hot_score(hot_score, 1, NEW.created)
     WHERE thread_id = NEW.thread_id;
     RETURN NEW;
 END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER update_rank_posts
+    AFTER INSERT
+    ON posts
+    FOR EACH ROW
+EXECUTE PROCEDURE function_rank_posts();
+
+-- The modification time versions threads and posts for ETags and If-Match, so it only moves when what the API
+-- shows changes: counting replies and numbering events touch the thread row too. The version number for
+-- optimistic updates only moves with what an update can change, so that votes do not fail the edits made meanwhile.
+CREATE OR REPLACE FUNCTION function_thread_modified() RETURNS TRIGGER AS
+$$
+BEGIN
+    IF (NEW.title, NEW.message, NEW.votes) IS DISTINCT FROM (OLD.title, OLD.message, OLD.votes) THEN
+        NEW.modified = clock_timestamp();
+    END IF;
+    IF (NEW.title, NEW.message) IS DISTINCT FROM (OLD.title, OLD.message) THEN
+        NEW.version = OLD.version + 1;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER thread_modified
+    BEFORE UPDATE
+    ON threads
+    FOR EACH ROW
+EXECUTE PROCEDURE function_thread_modified();
+
+CREATE OR REPLACE FUNCTION function_post_modified() RETURNS TRIGGER AS
+$$
+BEGIN
+    IF (NEW.message, NEW.is_edited) IS DISTINCT FROM (OLD.message, OLD.is_edited) THEN
+        NEW.modified = clock_timestamp();
+        NEW.version = OLD.version + 1;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER post_modified
+    BEFORE UPDATE
+    ON posts
+    FOR EACH ROW
+EXECUTE PROCEDURE function_post_modified();
+
+CREATE OR REPLACE FUNCTION function_user_version() RETURNS TRIGGER AS
+$$
+BEGIN
+    IF (NEW.fullname, NEW.about, NEW.email) IS DISTINCT FROM (OLD.fullname, OLD.about, OLD.email) THEN
+        NEW.version = OLD.version + 1;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER user_version
+    BEFORE UPDATE
+    ON users
+    FOR EACH ROW
+EXECUTE PROCEDURE function_user_version();
+
+-- Counters are a part of the forum as the API shows it, so they move its version as well
+CREATE OR REPLACE FUNCTION function_forum_version() RETURNS TRIGGER AS
+$$
+BEGIN
+    IF (NEW.title, NEW.posts, NEW.threads, NEW.parent, NEW.roll_up, NEW.visibility) IS DISTINCT FROM
+       (OLD.title, OLD.posts, OLD.threads, OLD.parent, OLD.roll_up, OLD.visibility) THEN
+        NEW.version = OLD.version + 1;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER forum_version
+    BEFORE UPDATE
+    ON forums
+    FOR EACH ROW
+EXECUTE PROCEDURE function_forum_version();
+
+-- The snapshot of the entity an event is about, kept with its webhook deliveries.
+CREATE OR REPLACE FUNCTION function_event_data(_kind text, _thread_id bigint, _entity_id bigint)
+    RETURNS jsonb AS
+$$
+BEGIN
+    IF _kind IN ('post.created', 'post.updated') THEN
+        RETURN (SELECT to_jsonb(p) FROM posts p WHERE p.post_id = _entity_id);
+    END IF;
+
+    RETURN (SELECT to_jsonb(t) FROM threads t WHERE t.thread_id = _thread_id);
+END;
+$$ LANGUAGE plpgsql;
+
+-- Events of a thread are numbered under the thread row lock, held until commit, so a later number is never
+-- visible before an earlier one and a client resuming from some number never skips an event committed later.
+-- _data is the snapshot for callers which cannot read the entity back yet, such as the statement inserting it.
+CREATE OR REPLACE FUNCTION function_emit_event(_kind text, _thread_id bigint, _entity_id bigint, _nickname citext,
+                                               _data jsonb DEFAULT NULL)
+    RETURNS bigint AS
+$$
+DECLARE
+    _seq   bigint;
+    _forum citext;
+BEGIN
+    -- Imported data is history, not news
+    IF current_setting('forum.importing', true) = 'on' THEN
+        RETURN 0;
+    END IF;
+
+    UPDATE threads
+    SET last_event = last_event + 1
+    WHERE thread_id = _thread_id
+    RETURNING last_event, forum INTO _seq, _forum;
+
+    INSERT INTO events(thread_id, seq, kind, entity_id)
+    VALUES (_thread_id, _seq, _kind, _entity_id);
+
+    IF EXISTS(SELECT 1 FROM webhooks WHERE forum = _forum AND _kind = ANY (events)) THEN
+        INSERT INTO webhook_deliveries(webhook_id, kind, thread_id, seq, entity_id, data)
+        SELECT webhook_id, _kind, _thread_id, _seq, _entity_id,
+               COALESCE(_data, function_event_data(_kind, _thread_id, _entity_id))
+        FROM webhooks
+        WHERE forum = _forum
+          AND _kind = ANY (events);
+    END IF;
+
+    PERFORM pg_notify('forum_events', json_build_object(
+            'kind', _kind,
+            'thread', _thread_id,
+            'seq', _seq,
+            'entity', _entity_id,
+            'forum', _forum,
+            'nickname', _nickname)::text);
+    RETURN _seq;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Events not bound to a thread are only announced, nobody replays them.
+CREATE OR REPLACE FUNCTION function_emit_user_event(_kind text, _nickname citext)
+    RETURNS void AS
+$$
+BEGIN
+    PERFORM pg_notify('forum_events', json_build_object(
+            'kind', _kind,
+            'nickname', _nickname)::text);
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION function_insert_votes_into_threads()
+    RETURNS TRIGGER AS
+$$
+DECLARE
+    _author citext;
+    _forum  citext;
+BEGIN
+    UPDATE threads
+    SET votes     = votes + NEW.voice,
+        hot_score = function_hot_score(hot_score, NEW.voice, now())
+    WHERE thread_id = NEW.thread_id
+    RETURNING author, forum INTO _author, _forum;
+
+    UPDATE forum_authors
+    SET votes = forum_authors.votes + NEW.voice
+    WHERE forum = _forum
+      AND nickname = _author;
+
+    PERFORM function_emit_event('vote.changed', NEW.thread_id, NEW.thread_id, NEW.nickname);
+    RETURN NEW;
+END;
 $$ language plpgsql;
 
 CREATE TRIGGER insert_votes
@@ -89,10 +509,22 @@ EXECUTE PROCEDURE function_insert_votes_into_threads();
 CREATE OR REPLACE FUNCTION function_update_votes_in_threads()
     RETURNS TRIGGER AS
 $$
+DECLARE
+    _author citext;
+    _forum  citext;
 BEGIN
     UPDATE threads
-    SET votes = votes + NEW.voice - OLD.voice
-    WHERE thread_id = NEW.thread_id;
+    SET votes     = votes + NEW.voice - OLD.voice,
+        hot_score = function_hot_score(hot_score, NEW.voice - OLD.voice, now())
+    WHERE thread_id = NEW.thread_id
+    RETURNING author, forum INTO _author, _forum;
+
+    UPDATE forum_authors
+    SET votes = forum_authors.votes + NEW.voice - OLD.voice
+    WHERE forum = _forum
+      AND nickname = _author;
+
+    PERFORM function_emit_event('vote.changed', NEW.thread_id, NEW.thread_id, NEW.nickname);
     RETURN NEW;
 END;
 $$ language plpgsql;
@@ -106,10 +538,31 @@ EXECUTE PROCEDURE function_update_votes_in_threads();
 CREATE OR REPLACE FUNCTION function_count_posts()
     RETURNS TRIGGER AS
 $$
+DECLARE
+    _parent  citext;
+    _roll_up bool;
 BEGIN
+    -- Posts of one batch share the creation time, the last one inserted is the last post
+    UPDATE threads
+    SET replies          = replies + 1,
+        last_post_author = CASE WHEN NEW.created >= last_post_at OR last_post_author IS NULL
+                                    THEN NEW.author
+                                ELSE last_post_author END,
+        last_post_at     = greatest(last_post_at, NEW.created)
+    WHERE thread_id = NEW.thread_id;
+
     UPDATE forums
     SET posts = forums.posts + 1
-    WHERE slug = NEW.forum;
+    WHERE slug = NEW.forum
+    RETURNING parent, roll_up INTO _parent, _roll_up;
+
+    WHILE _roll_up AND _parent IS NOT NULL
+        LOOP
+            UPDATE forums
+            SET posts = forums.posts + 1
+            WHERE slug = _parent
+            RETURNING parent, roll_up INTO _parent, _roll_up;
+        END LOOP;
     RETURN NEW;
 END;
 $$ LANGUAGE plpgsql;
@@ -123,10 +576,22 @@ EXECUTE PROCEDURE function_count_posts();
 CREATE OR REPLACE FUNCTION function_count_threads()
     RETURNS TRIGGER AS
 $$
+DECLARE
+    _parent  citext;
+    _roll_up bool;
 BEGIN
     UPDATE forums
     SET threads = forums.threads + 1
-    WHERE slug = NEW.forum;
+    WHERE slug = NEW.forum
+    RETURNING parent, roll_up INTO _parent, _roll_up;
+
+    WHILE _roll_up AND _parent IS NOT NULL
+        LOOP
+            UPDATE forums
+            SET threads = forums.threads + 1
+            WHERE slug = _parent
+            RETURNING parent, roll_up INTO _parent, _roll_up;
+        END LOOP;
     RETURN NEW;
 END;
 $$ LANGUAGE plpgsql;
@@ -137,6 +602,85 @@ CREATE TRIGGER update_count_threads
     FOR EACH ROW
 EXECUTE PROCEDURE function_count_threads();
 
+CREATE OR REPLACE FUNCTION function_stats_posts()
+    RETURNS TRIGGER AS
+$$
+DECLARE
+    _day   date := (NEW.created AT TIME ZONE 'UTC')::date;
+    _depth int  := coalesce(array_length(NEW.path, 1), 0);
+    _old   int;
+BEGIN
+    INSERT INTO forum_activity (forum, day, posts)
+    VALUES (NEW.forum, _day, 1)
+    ON CONFLICT (forum, day) DO UPDATE SET posts = forum_activity.posts + 1;
+
+    INSERT INTO forum_active_users (forum, day, nickname)
+    VALUES (NEW.forum, _day, NEW.author)
+    ON CONFLICT DO NOTHING;
+
+    INSERT INTO forum_authors (forum, nickname, posts)
+    VALUES (NEW.forum, NEW.author, 1)
+    ON CONFLICT (forum, nickname) DO UPDATE SET posts = forum_authors.posts + 1;
+
+    SELECT depth
+    FROM thread_depths
+    WHERE thread_id = NEW.thread_id
+    FOR UPDATE
+    INTO _old;
+
+    IF _depth > _old THEN
+        UPDATE thread_depths
+        SET depth = _depth
+        WHERE thread_id = NEW.thread_id;
+
+        UPDATE forum_depths
+        SET depth = forum_depths.depth + _depth - _old
+        WHERE forum = NEW.forum;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER update_stats_posts
+    AFTER INSERT
+    ON posts
+    FOR EACH ROW
+EXECUTE PROCEDURE function_stats_posts();
+
+CREATE OR REPLACE FUNCTION function_stats_threads()
+    RETURNS TRIGGER AS
+$$
+DECLARE
+    _day date := (NEW.created AT TIME ZONE 'UTC')::date;
+BEGIN
+    INSERT INTO forum_activity (forum, day, threads)
+    VALUES (NEW.forum, _day, 1)
+    ON CONFLICT (forum, day) DO UPDATE SET threads = forum_activity.threads + 1;
+
+    INSERT INTO forum_active_users (forum, day, nickname)
+    VALUES (NEW.forum, _day, NEW.author)
+    ON CONFLICT DO NOTHING;
+
+    INSERT INTO forum_authors (forum, nickname)
+    VALUES (NEW.forum, NEW.author)
+    ON CONFLICT DO NOTHING;
+
+    INSERT INTO thread_depths (thread_id)
+    VALUES (NEW.thread_id);
+
+    INSERT INTO forum_depths (forum, threads)
+    VALUES (NEW.forum, 1)
+    ON CONFLICT (forum) DO UPDATE SET threads = forum_depths.threads + 1;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER update_stats_threads
+    AFTER INSERT
+    ON threads
+    FOR EACH ROW
+EXECUTE PROCEDURE function_stats_threads();
+
 CREATE OR REPLACE FUNCTION function_update_user_forum()
     RETURNS TRIGGER AS
 
@@ -174,6 +718,7 @@ EXECUTE PROCEDURE function_update_user_forum();
 
 CREATE INDEX IF NOT EXISTS forum_slug_hash ON forums USING hash (slug);
 CREATE INDEX IF NOT EXISTS forum_user_hash ON forums USING hash (users_nickname);
+CREATE INDEX IF NOT EXISTS forum_parent ON forums (parent, slug);
 
 CREATE INDEX IF NOT EXISTS users_to_forums_forum_compare ON user_forums (forum);
 CREATE INDEX IF NOT EXISTS users_to_forums_nickname_compare ON user_forums (nickname);
@@ -186,6 +731,7 @@ CREATE INDEX IF NOT EXISTS post_thread ON posts USING hash (thread_id);
 CREATE INDEX IF NOT EXISTS post_parent ON posts (thread_id, post_id, (path[1]), parent);
 CREATE INDEX IF NOT EXISTS post_path_1_path ON posts ((path[1]), path);
 CREATE INDEX IF NOT EXISTS post_thread_path ON posts (thread_id, path);
+CREATE INDEX IF NOT EXISTS post_author_created ON posts (author, created);
 
 CREATE UNIQUE INDEX IF NOT EXISTS votes_all ON user_votes (nickname, thread_id, voice);
 CREATE UNIQUE INDEX IF NOT EXISTS votes ON user_votes (nickname, thread_id);
@@ -195,5 +741,12 @@ CREATE INDEX IF NOT EXISTS th_user_hash ON threads USING hash (author);
 CREATE INDEX IF NOT EXISTS th_created ON threads (created);
 CREATE INDEX IF NOT EXISTS th_forum ON threads USING hash (forum);
 CREATE INDEX IF NOT EXISTS th_forum_created ON threads (forum, created);
+CREATE INDEX IF NOT EXISTS th_forum_votes ON threads (forum, votes DESC, thread_id);
+CREATE INDEX IF NOT EXISTS th_forum_hot ON threads (forum, hot_score DESC);
+CREATE INDEX IF NOT EXISTS th_forum_active ON threads (forum, last_post_at DESC, thread_id DESC);
+CREATE INDEX IF NOT EXISTS th_hot ON threads (hot_score DESC);
+
+CREATE INDEX IF NOT EXISTS forum_author_posts ON forum_authors (forum, posts DESC, nickname);
+CREATE INDEX IF NOT EXISTS forum_author_votes ON forum_authors (forum, votes DESC, nickname);
 
 VACUUM ANALYSE;
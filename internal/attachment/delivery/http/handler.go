@@ -0,0 +1,87 @@
+package http
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"project/internal/attachment/delivery/models"
+	"project/internal/attachment/usecase"
+	"project/internal/pkg"
+)
+
+type AttachmentHandler struct {
+	attachmentUsecase usecase.AttachmentService
+}
+
+func (h *AttachmentHandler) UploadHandler(w http.ResponseWriter, r *http.Request) {
+	request := models.NewAttachmentUploadRequest()
+
+	err := request.Bind(r)
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	attachment, err := h.attachmentUsecase.Upload(r.Context(), request.GetAttachment(), request.Content)
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	response := models.NewAttachmentResponse(attachment)
+
+	pkg.Response(r.Context(), w, http.StatusCreated, response)
+}
+
+// GetAttachmentHandler serves the content with http.ServeContent, which answers range and conditional requests
+// against the checksum as ETag. Only images are shown inline, anything else is downloaded, and the sniffed type is
+// never second-guessed by the browser.
+func (h *AttachmentHandler) GetAttachmentHandler(w http.ResponseWriter, r *http.Request) {
+	request := models.NewAttachmentGetRequest()
+
+	err := request.Bind(r)
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	attachment, content, err := h.attachmentUsecase.GetAttachment(r.Context(), request.GetAttachment())
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+	defer func() {
+		err = content.Close()
+		if err != nil {
+			logrus.Error(err)
+		}
+	}()
+
+	disposition := "attachment"
+	if strings.HasPrefix(attachment.MimeType, "image/") {
+		disposition = "inline"
+	}
+
+	// A filename that cannot be written in the header is left to the client to choose
+	header := mime.FormatMediaType(disposition, map[string]string{"filename": attachment.Filename})
+	if header == "" {
+		header = disposition
+	}
+
+	w.Header().Set("Content-Type", attachment.MimeType)
+	w.Header().Set("Content-Disposition", header)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private")
+	w.Header().Set("ETag", `"`+attachment.Checksum+`"`)
+
+	http.ServeContent(w, r, attachment.Filename, attachment.Created, content)
+}
+
+func NewAttachmentHandler(attachmentUsecase usecase.AttachmentService, r *mux.Router) *AttachmentHandler {
+	h := &AttachmentHandler{attachmentUsecase: attachmentUsecase}
+	return h
+}
@@ -0,0 +1,143 @@
+package models
+
+import (
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gorilla/mux"
+
+	"project/internal/models"
+	"project/internal/pkg"
+)
+
+//go:generate easyjson -disallow_unknown_fields -omit_empty attachment.go
+
+// maxFilenameLength bounds the kept filename, in runes.
+const maxFilenameLength = 255
+
+// defaultFilename names uploads sent without a filename.
+const defaultFilename = "attachment"
+
+type AttachmentUploadRequest struct {
+	Filename string
+	Content  io.Reader
+}
+
+func NewAttachmentUploadRequest() *AttachmentUploadRequest {
+	return &AttachmentUploadRequest{}
+}
+
+// Bind finds the file part of the multipart body and leaves it to be read as Content, so that uploads are streamed
+// to the storage rather than held in memory. Parts before it are skipped.
+func (req *AttachmentUploadRequest) Bind(r *http.Request) error {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return pkg.ErrAttachmentMissing
+	}
+
+	for {
+		var part *multipart.Part
+
+		part, err = reader.NextPart()
+		if err != nil {
+			return pkg.ErrAttachmentMissing
+		}
+
+		if part.FormName() != pkg.AttachmentFormField {
+			continue
+		}
+
+		req.Filename = part.FileName()
+		if req.Filename == "" {
+			req.Filename = defaultFilename
+		}
+
+		if utf8.RuneCountInString(req.Filename) > maxFilenameLength {
+			req.Filename = string([]rune(req.Filename)[:maxFilenameLength])
+		}
+
+		req.Content = part
+
+		return nil
+	}
+}
+
+func (req *AttachmentUploadRequest) GetAttachment() *models.Attachment {
+	return &models.Attachment{
+		Filename: req.Filename,
+	}
+}
+
+type AttachmentGetRequest struct {
+	ID int64
+}
+
+func NewAttachmentGetRequest() *AttachmentGetRequest {
+	return &AttachmentGetRequest{}
+}
+
+func (req *AttachmentGetRequest) Bind(r *http.Request) error {
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		return pkg.ErrConvertQueryType
+	}
+
+	req.ID = id
+
+	return nil
+}
+
+func (req *AttachmentGetRequest) GetAttachment() *models.Attachment {
+	return &models.Attachment{
+		ID: req.ID,
+	}
+}
+
+//easyjson:json
+type AttachmentResponse struct {
+	ID       int64  `json:"id"`
+	Post     int64  `json:"post,omitempty"`
+	Author   string `json:"author"`
+	Filename string `json:"filename"`
+	MimeType string `json:"mimeType"`
+	Size     int64  `json:"size"`
+	Checksum string `json:"checksum"`
+	Created  string `json:"created"`
+}
+
+//easyjson:json
+type AttachmentsList []AttachmentResponse
+
+func NewAttachmentResponse(attachment *models.Attachment) *AttachmentResponse {
+	return &AttachmentResponse{
+		ID:       attachment.ID,
+		Post:     attachment.Post,
+		Author:   attachment.Author,
+		Filename: attachment.Filename,
+		MimeType: attachment.MimeType,
+		Size:     attachment.Size,
+		Checksum: attachment.Checksum,
+		Created:  attachment.Created.Format(time.RFC3339Nano),
+	}
+}
+
+// NewAttachmentsList lists the attachments of a post, nil when it has none for the field to be left out.
+func NewAttachmentsList(attachments []models.Attachment) AttachmentsList {
+	if len(attachments) == 0 {
+		return nil
+	}
+
+	res := make(AttachmentsList, len(attachments))
+
+	for idx := range attachments {
+		res[idx] = *NewAttachmentResponse(&attachments[idx])
+	}
+
+	return res
+}
@@ -0,0 +1,240 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package models
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson76362c5bDecodeProjectInternalAttachmentDeliveryModels(in *jlexer.Lexer, out *AttachmentsList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(AttachmentsList, 0, 0)
+			} else {
+				*out = AttachmentsList{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v1 AttachmentResponse
+			(v1).UnmarshalEasyJSON(in)
+			*out = append(*out, v1)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson76362c5bEncodeProjectInternalAttachmentDeliveryModels(out *jwriter.Writer, in AttachmentsList) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v2, v3 := range in {
+			if v2 > 0 {
+				out.RawByte(',')
+			}
+			(v3).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AttachmentsList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson76362c5bEncodeProjectInternalAttachmentDeliveryModels(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AttachmentsList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson76362c5bEncodeProjectInternalAttachmentDeliveryModels(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AttachmentsList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson76362c5bDecodeProjectInternalAttachmentDeliveryModels(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AttachmentsList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson76362c5bDecodeProjectInternalAttachmentDeliveryModels(l, v)
+}
+func easyjson76362c5bDecodeProjectInternalAttachmentDeliveryModels1(in *jlexer.Lexer, out *AttachmentResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int64(in.Int64())
+		case "post":
+			out.Post = int64(in.Int64())
+		case "author":
+			out.Author = string(in.String())
+		case "filename":
+			out.Filename = string(in.String())
+		case "mimeType":
+			out.MimeType = string(in.String())
+		case "size":
+			out.Size = int64(in.Int64())
+		case "checksum":
+			out.Checksum = string(in.String())
+		case "created":
+			out.Created = string(in.String())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson76362c5bEncodeProjectInternalAttachmentDeliveryModels1(out *jwriter.Writer, in AttachmentResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.ID != 0 {
+		const prefix string = ",\"id\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.ID))
+	}
+	if in.Post != 0 {
+		const prefix string = ",\"post\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Post))
+	}
+	if in.Author != "" {
+		const prefix string = ",\"author\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Author))
+	}
+	if in.Filename != "" {
+		const prefix string = ",\"filename\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Filename))
+	}
+	if in.MimeType != "" {
+		const prefix string = ",\"mimeType\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.MimeType))
+	}
+	if in.Size != 0 {
+		const prefix string = ",\"size\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Size))
+	}
+	if in.Checksum != "" {
+		const prefix string = ",\"checksum\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Checksum))
+	}
+	if in.Created != "" {
+		const prefix string = ",\"created\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Created))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AttachmentResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson76362c5bEncodeProjectInternalAttachmentDeliveryModels1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AttachmentResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson76362c5bEncodeProjectInternalAttachmentDeliveryModels1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AttachmentResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson76362c5bDecodeProjectInternalAttachmentDeliveryModels1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AttachmentResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson76362c5bDecodeProjectInternalAttachmentDeliveryModels1(l, v)
+}
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	"project/internal/models"
+	"project/internal/pkg"
+	"project/internal/pkg/sqltools"
+)
+
+type AttachmentRepository interface {
+	CreateAttachment(ctx context.Context, attachment *models.Attachment) (*models.Attachment, error)
+	GetAttachment(ctx context.Context, attachment *models.Attachment) (*models.Attachment, error)
+	GetOrphans(ctx context.Context, before time.Time, limit int64) ([]models.Attachment, error)
+	DeleteOrphan(ctx context.Context, attachment *models.Attachment) (bool, error)
+	GetKnownKeys(ctx context.Context, keys []string) (map[string]bool, error)
+}
+
+type attachmentPostgres struct {
+	conn *sqltools.Cluster
+}
+
+func NewAttachmentPostgres(conn *sqltools.Cluster) AttachmentRepository {
+	return &attachmentPostgres{
+		conn,
+	}
+}
+
+// AttachmentColumns are scanned by ScanAttachment, the table being aliased as a.
+const AttachmentColumns = `a.attachment_id, COALESCE(a.post_id, 0), a.author, a.filename, a.mime_type,
+	a.size, a.checksum, a.storage_key, a.created`
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func ScanAttachment(row scanner) (models.Attachment, error) {
+	res := models.Attachment{}
+
+	err := row.Scan(
+		&res.ID,
+		&res.Post,
+		&res.Author,
+		&res.Filename,
+		&res.MimeType,
+		&res.Size,
+		&res.Checksum,
+		&res.Key,
+		&res.Created)
+
+	return res, err
+}
+
+func (a attachmentPostgres) CreateAttachment(ctx context.Context, attachment *models.Attachment) (*models.Attachment, error) {
+	row := a.conn.Write(ctx).QueryRowContext(ctx, `INSERT INTO attachments AS a(author, filename, mime_type, size, checksum, storage_key)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING `+AttachmentColumns+`;`,
+		attachment.Author, attachment.Filename, attachment.MimeType, attachment.Size, attachment.Checksum, attachment.Key)
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	res, err := ScanAttachment(row)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// GetAttachment reads from the primary, an attachment being fetched right after its upload or its post.
+func (a attachmentPostgres) GetAttachment(ctx context.Context, attachment *models.Attachment) (*models.Attachment, error) {
+	row := a.conn.Primary(ctx).QueryRowContext(ctx, `SELECT `+AttachmentColumns+`
+		FROM attachments a
+		WHERE a.attachment_id = $1;`, attachment.ID)
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	res, err := ScanAttachment(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, pkg.ErrSuchAttachmentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// GetOrphans lists the oldest attachments uploaded before the time which no post took.
+func (a attachmentPostgres) GetOrphans(ctx context.Context, before time.Time, limit int64) ([]models.Attachment, error) {
+	rows, err := a.conn.Primary(ctx).QueryContext(ctx, `SELECT `+AttachmentColumns+`
+		FROM attachments a
+		WHERE a.post_id IS NULL AND a.created < $1
+		ORDER BY a.created
+		LIMIT $2;`, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]models.Attachment, 0)
+
+	for rows.Next() {
+		var attachment models.Attachment
+
+		attachment, err = ScanAttachment(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, attachment)
+	}
+
+	return res, rows.Err()
+}
+
+// DeleteOrphan deletes the attachment unless a post took it meanwhile, reporting whether it did.
+func (a attachmentPostgres) DeleteOrphan(ctx context.Context, attachment *models.Attachment) (bool, error) {
+	result, err := a.conn.Write(ctx).ExecContext(ctx, `DELETE FROM attachments
+		WHERE attachment_id = $1 AND post_id IS NULL;`, attachment.ID)
+	if err != nil {
+		return false, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// GetKnownKeys tells which of the storage keys an attachment refers to.
+func (a attachmentPostgres) GetKnownKeys(ctx context.Context, keys []string) (map[string]bool, error) {
+	rows, err := a.conn.Primary(ctx).QueryContext(ctx, `SELECT storage_key
+		FROM attachments
+		WHERE storage_key = ANY ($1::text[]);`, pq.Array(keys))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make(map[string]bool, len(keys))
+
+	for rows.Next() {
+		var key string
+
+		err = rows.Scan(&key)
+		if err != nil {
+			return nil, err
+		}
+
+		res[key] = true
+	}
+
+	return res, rows.Err()
+}
@@ -0,0 +1,212 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"project/internal/pkg"
+)
+
+// sniffLen is as much of the content as http.DetectContentType looks at.
+const sniffLen = 512
+
+const tempPrefix = ".upload-"
+
+// Local stores the content in files under a directory, spread over subdirectories by the first two characters of
+// the keys. Files are written under a temporary name and renamed once complete, so that Open never sees a partial
+// one.
+type Local struct {
+	dir     string
+	maxSize int64
+	types   map[string]bool
+}
+
+// NewLocal stores into dir, creating it if needed. Content over maxSize bytes is refused, as is content whose sniffed
+// type is not one of types; a maxSize of 0 or no types allow anything.
+func NewLocal(dir string, maxSize int64, types []string) (*Local, error) {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Local{
+		dir:     dir,
+		maxSize: maxSize,
+		types:   make(map[string]bool, len(types)),
+	}
+
+	for _, value := range types {
+		res.types[strings.ToLower(strings.TrimSpace(value))] = true
+	}
+
+	return res, nil
+}
+
+func (l *Local) path(key string) (string, error) {
+	if len(key) < 2 || strings.HasPrefix(key, ".") || filepath.Base(key) != key {
+		return "", ErrBadKey
+	}
+
+	return filepath.Join(l.dir, key[:2], key), nil
+}
+
+func (l *Local) Put(ctx context.Context, key string, content io.Reader) (*Blob, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	head := make([]byte, sniffLen)
+
+	read, err := io.ReadFull(content, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+
+	head = head[:read]
+
+	mimeType, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(l.types) > 0 && !l.types[mimeType] {
+		return nil, pkg.ErrAttachmentTypeUnsupported
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), tempPrefix+"*")
+	if err != nil {
+		return nil, err
+	}
+
+	stored := false
+
+	defer func() {
+		if !stored {
+			_ = file.Close()
+			_ = os.Remove(file.Name())
+		}
+	}()
+
+	rest := content
+	if l.maxSize > 0 {
+		// One byte over the limit tells a file of the maximum size from a bigger one
+		rest = io.LimitReader(content, l.maxSize+1-int64(read))
+	}
+
+	hash := sha256.New()
+
+	size, err := io.Copy(io.MultiWriter(file, hash), io.MultiReader(bytes.NewReader(head), rest))
+	if err != nil {
+		return nil, err
+	}
+
+	if l.maxSize > 0 && size > l.maxSize {
+		return nil, pkg.ErrAttachmentTooBig
+	}
+
+	err = ctx.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	err = file.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.Rename(file.Name(), path)
+	if err != nil {
+		return nil, err
+	}
+
+	stored = true
+
+	return &Blob{
+		Size:     size,
+		MimeType: mimeType,
+		Checksum: hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+func (l *Local) Open(ctx context.Context, key string) (io.ReadSeekCloser, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, pkg.ErrSuchAttachmentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// Delete removes the content of the key, a missing one being removed already.
+func (l *Local) Delete(ctx context.Context, key string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// Walk lists the stored files. Temporary files left by a crash in the middle of a Put are removed on the way once
+// they are a day old, no upload taking that long.
+func (l *Local) Walk(ctx context.Context, fn func(key string, stored time.Time) error) error {
+	return filepath.WalkDir(l.dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if entry.IsDir() {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		if strings.HasPrefix(entry.Name(), tempPrefix) {
+			if time.Since(info.ModTime()) > 24*time.Hour {
+				_ = os.Remove(path)
+			}
+
+			return nil
+		}
+
+		return fn(entry.Name(), info.ModTime())
+	})
+}
@@ -0,0 +1,215 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"project/internal/pkg"
+)
+
+func TestLocalPath(t *testing.T) {
+	l := &Local{dir: "/blobs"}
+
+	tests := []struct {
+		name    string
+		key     string
+		want    string
+		wantErr error
+	}{
+		{
+			name: "spread by the first two characters",
+			key:  "ab12cd",
+			want: filepath.Join("/blobs", "ab", "ab12cd"),
+		},
+		{
+			name: "shortest key",
+			key:  "ab",
+			want: filepath.Join("/blobs", "ab", "ab"),
+		},
+		{
+			name:    "empty",
+			key:     "",
+			wantErr: ErrBadKey,
+		},
+		{
+			name:    "too short",
+			key:     "a",
+			wantErr: ErrBadKey,
+		},
+		{
+			name:    "hidden",
+			key:     ".upload-1",
+			wantErr: ErrBadKey,
+		},
+		{
+			name:    "parent directory",
+			key:     "../etc/passwd",
+			wantErr: ErrBadKey,
+		},
+		{
+			name:    "nested",
+			key:     "ab/cd",
+			wantErr: ErrBadKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := l.path(tt.key)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("path = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalPut(t *testing.T) {
+	png := append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 100)...)
+	gif := append([]byte("GIF89a"), bytes.Repeat([]byte{0}, 100)...)
+	text := bytes.Repeat([]byte("a"), 2*sniffLen)
+
+	tests := []struct {
+		name     string
+		maxSize  int64
+		types    []string
+		content  []byte
+		wantType string
+		wantErr  error
+	}{
+		{
+			name:     "allowed type",
+			maxSize:  1 << 10,
+			types:    DefaultTypes,
+			content:  png,
+			wantType: "image/png",
+		},
+		{
+			name:     "parameters of the type are dropped",
+			types:    []string{" Text/Plain "},
+			content:  text,
+			wantType: "text/plain",
+		},
+		{
+			name:    "type not allowed",
+			types:   []string{"image/png"},
+			content: gif,
+			wantErr: pkg.ErrAttachmentTypeUnsupported,
+		},
+		{
+			name:     "no types allow anything",
+			content:  gif,
+			wantType: "image/gif",
+		},
+		{
+			name:     "empty",
+			content:  []byte{},
+			wantType: "text/plain",
+		},
+		{
+			name:     "exactly the maximum size",
+			maxSize:  int64(len(text)),
+			content:  text,
+			wantType: "text/plain",
+		},
+		{
+			name:    "one byte over the maximum size",
+			maxSize: int64(len(text)) - 1,
+			content: text,
+			wantErr: pkg.ErrAttachmentTooBig,
+		},
+		{
+			name:    "over a maximum size below the sniffed head",
+			maxSize: 10,
+			content: png,
+			wantErr: pkg.ErrAttachmentTooBig,
+		},
+		{
+			name:     "no maximum size",
+			content:  text,
+			wantType: "text/plain",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := NewLocal(t.TempDir(), tt.maxSize, tt.types)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := context.Background()
+
+			blob, err := l.Put(ctx, "ab12cd", bytes.NewReader(tt.content))
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			stored := make([]string, 0)
+
+			// Walk skips temporary files, the directory is listed instead for none to be left behind
+			err = filepath.WalkDir(l.dir, func(path string, entry os.DirEntry, err error) error {
+				if err == nil && !entry.IsDir() {
+					stored = append(stored, entry.Name())
+				}
+
+				return err
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.wantErr != nil {
+				if len(stored) != 0 {
+					t.Errorf("refused content left %v", stored)
+				}
+
+				return
+			}
+
+			if len(stored) != 1 || stored[0] != "ab12cd" {
+				t.Fatalf("stored %v, want only ab12cd", stored)
+			}
+
+			sum := sha256.Sum256(tt.content)
+
+			want := Blob{
+				Size:     int64(len(tt.content)),
+				MimeType: tt.wantType,
+				Checksum: hex.EncodeToString(sum[:]),
+			}
+
+			if *blob != want {
+				t.Errorf("blob = %+v, want %+v", *blob, want)
+			}
+
+			file, err := l.Open(ctx, "ab12cd")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+
+			got, err := io.ReadAll(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(got, tt.content) {
+				t.Errorf("read back %d bytes, want %d", len(got), len(tt.content))
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultMaxSize and DefaultTypes allow common images, PDF documents and plain text of up to 10 MiB. SVG is left
+// out, being a script as much as an image.
+const DefaultMaxSize = 10 << 20
+
+var DefaultTypes = []string{"image/png", "image/jpeg", "image/gif", "image/webp", "application/pdf", "text/plain"}
+
+var ErrBadKey = errors.New("bad attachment storage key")
+
+// Blob describes the content stored under a key, its MIME type being sniffed from the content rather than taken from
+// the client.
+type Blob struct {
+	Size     int64
+	MimeType string
+	Checksum string
+}
+
+// Storage keeps the content of the attachments by keys chosen by the caller. Put refuses content that is too big
+// with pkg.ErrAttachmentTooBig and of a type not allowed with pkg.ErrAttachmentTypeUnsupported, storing nothing.
+// Open returns pkg.ErrSuchAttachmentNotFound for an unknown key. Walk lists every stored key with the time it was
+// stored, for the garbage collection to find those no attachment refers to.
+type Storage interface {
+	Put(ctx context.Context, key string, content io.Reader) (*Blob, error)
+	Open(ctx context.Context, key string) (io.ReadSeekCloser, error)
+	Delete(ctx context.Context, key string) error
+	Walk(ctx context.Context, fn func(key string, stored time.Time) error) error
+}
@@ -0,0 +1,276 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"project/internal/attachment/repository"
+	"project/internal/attachment/storage"
+	repoForum "project/internal/forum/repository"
+	"project/internal/models"
+	"project/internal/pkg"
+	repoPost "project/internal/post/repository"
+	repoUser "project/internal/user/repository"
+)
+
+// DefaultOrphanTTL leaves a day to post what was uploaded.
+const DefaultOrphanTTL = 24 * time.Hour
+
+const (
+	// collectInterval is how often Run looks for garbage, the orphans being kept much longer anyway.
+	collectInterval = 10 * time.Minute
+
+	// collectBatchSize bounds the orphans read and the stored keys looked up at once.
+	collectBatchSize = 500
+)
+
+type AttachmentService interface {
+	Upload(ctx context.Context, attachment *models.Attachment, content io.Reader) (*models.Attachment, error)
+	GetAttachment(ctx context.Context, attachment *models.Attachment) (*models.Attachment, io.ReadSeekCloser, error)
+	Run(ctx context.Context) error
+}
+
+type attachmentService struct {
+	attachmentRepo repository.AttachmentRepository
+	postRepo       repoPost.PostRepository
+	forumRepo      repoForum.ForumRepository
+	userRepo       repoUser.UserRepository
+	storage        storage.Storage
+	orphanTTL      time.Duration
+}
+
+// NewAttachmentService keeps uploads no post took for orphanTTL before Run collects them. A zero orphanTTL turns
+// the garbage collection off.
+func NewAttachmentService(r repository.AttachmentRepository, rp repoPost.PostRepository, rf repoForum.ForumRepository, ru repoUser.UserRepository,
+	s storage.Storage, orphanTTL time.Duration) AttachmentService {
+	return &attachmentService{
+		attachmentRepo: r,
+		postRepo:       rp,
+		forumRepo:      rf,
+		userRepo:       ru,
+		storage:        s,
+		orphanTTL:      orphanTTL,
+	}
+}
+
+func newKey() string {
+	value := make([]byte, 16)
+
+	_, _ = rand.Read(value)
+
+	return hex.EncodeToString(value)
+}
+
+// Upload stores the content for the requesting user, who alone may then attach it to posts. The content is stored
+// before the attachment is created, so a crash in between leaves a file the garbage collection removes rather than
+// an attachment without content.
+func (a attachmentService) Upload(ctx context.Context, attachment *models.Attachment, content io.Reader) (*models.Attachment, error) {
+	nickname := pkg.GetNickname(ctx)
+	if nickname == "" {
+		return nil, errors.Wrap(pkg.ErrAuthRequired, "Upload")
+	}
+
+	user, err := a.userRepo.GetUserByNickname(ctx, &models.User{Nickname: nickname})
+	if err != nil {
+		return nil, errors.Wrap(err, "Upload")
+	}
+
+	attachment.Author = user.Nickname
+	attachment.Key = newKey()
+
+	blob, err := a.storage.Put(ctx, attachment.Key, content)
+	if err != nil {
+		return nil, errors.Wrap(err, "Upload")
+	}
+
+	attachment.Size = blob.Size
+	attachment.MimeType = blob.MimeType
+	attachment.Checksum = blob.Checksum
+
+	res, err := a.attachmentRepo.CreateAttachment(ctx, attachment)
+	if err != nil {
+		deleteErr := a.storage.Delete(ctx, attachment.Key)
+		if deleteErr != nil {
+			logrus.Error(errors.Wrap(deleteErr, "Upload"))
+		}
+
+		return nil, errors.Wrap(err, "Upload")
+	}
+
+	return res, nil
+}
+
+// checkAccess shows attachments of posts to those who may read the posts, and orphans to their author only.
+func (a attachmentService) checkAccess(ctx context.Context, attachment *models.Attachment) error {
+	if attachment.Post == 0 {
+		if !pkg.EqualNicknames(attachment.Author, pkg.GetNickname(ctx)) {
+			return pkg.ErrSuchAttachmentNotFound
+		}
+
+		return nil
+	}
+
+	post, err := a.postRepo.GetDetailsPost(ctx, &models.Post{ID: attachment.Post}, &pkg.PostDetailsParams{})
+	if err != nil {
+		return err
+	}
+
+	if post.Post.Visibility == pkg.ForumVisibilityPublic {
+		return nil
+	}
+
+	access, err := a.forumRepo.GetAccessForum(ctx, &models.Forum{Slug: post.Post.Forum}, pkg.GetNickname(ctx))
+	if err != nil {
+		return err
+	}
+
+	if !pkg.CanReadForum(access) {
+		return pkg.ErrSuchAttachmentNotFound
+	}
+
+	return nil
+}
+
+// GetAttachment returns the attachment along with its content, which the caller closes.
+func (a attachmentService) GetAttachment(ctx context.Context, attachment *models.Attachment) (*models.Attachment, io.ReadSeekCloser, error) {
+	res, err := a.attachmentRepo.GetAttachment(ctx, attachment)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "GetAttachment")
+	}
+
+	err = a.checkAccess(ctx, res)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "GetAttachment")
+	}
+
+	content, err := a.storage.Open(ctx, res.Key)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "GetAttachment")
+	}
+
+	return res, content, nil
+}
+
+// collect deletes the orphans older than the TTL, then the stored files of that age no attachment refers to, and
+// returns how many files it deleted. An orphan a post takes meanwhile is left alone.
+func (a attachmentService) collect(ctx context.Context) (int64, error) {
+	var res int64
+
+	before := time.Now().Add(-a.orphanTTL)
+
+	for {
+		orphans, err := a.attachmentRepo.GetOrphans(ctx, before, collectBatchSize)
+		if err != nil {
+			return res, errors.Wrap(err, "collect")
+		}
+
+		for idx := range orphans {
+			deleted, err := a.attachmentRepo.DeleteOrphan(ctx, &orphans[idx])
+			if err != nil {
+				return res, errors.Wrap(err, "collect")
+			}
+
+			if !deleted {
+				continue
+			}
+
+			// A file failing to go is found again by the walk below, having no attachment anymore
+			err = a.storage.Delete(ctx, orphans[idx].Key)
+			if err != nil {
+				logrus.Error(errors.Wrap(err, "collect"))
+				continue
+			}
+
+			res++
+		}
+
+		if len(orphans) < collectBatchSize {
+			break
+		}
+	}
+
+	keys := make([]string, 0, collectBatchSize)
+
+	flush := func() error {
+		known, err := a.attachmentRepo.GetKnownKeys(ctx, keys)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			if known[key] {
+				continue
+			}
+
+			err = a.storage.Delete(ctx, key)
+			if err != nil {
+				return err
+			}
+
+			res++
+		}
+
+		keys = keys[:0]
+
+		return nil
+	}
+
+	err := a.storage.Walk(ctx, func(key string, stored time.Time) error {
+		if !stored.Before(before) {
+			return nil
+		}
+
+		keys = append(keys, key)
+
+		if len(keys) < collectBatchSize {
+			return nil
+		}
+
+		return flush()
+	})
+	if err != nil {
+		return res, errors.Wrap(err, "collect")
+	}
+
+	if len(keys) > 0 {
+		err = flush()
+		if err != nil {
+			return res, errors.Wrap(err, "collect")
+		}
+	}
+
+	return res, nil
+}
+
+// Run collects the garbage every collectInterval until ctx is done.
+func (a attachmentService) Run(ctx context.Context) error {
+	if a.orphanTTL <= 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(collectInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		deleted, err := a.collect(ctx)
+		if err != nil && ctx.Err() == nil {
+			logrus.Error(errors.Wrap(err, "Run"))
+		}
+
+		if deleted > 0 {
+			logrus.Infof("attachments: collected %d files", deleted)
+		}
+	}
+}
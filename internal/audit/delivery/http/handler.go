@@ -0,0 +1,40 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"project/internal/audit/delivery/models"
+	"project/internal/audit/usecase"
+	"project/internal/pkg"
+)
+
+type AuditHandler struct {
+	auditUsecase usecase.AuditService
+}
+
+func (h *AuditHandler) GetAuditHandler(w http.ResponseWriter, r *http.Request) {
+	request := models.NewAuditRequest()
+
+	err := request.Bind(r)
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	entries, err := h.auditUsecase.GetEntries(r.Context(), request.GetParams())
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	response := models.NewAuditResponse(entries)
+
+	pkg.Response(r.Context(), w, http.StatusOK, response)
+}
+
+func NewAuditHandler(auditUsecase usecase.AuditService, r *mux.Router) *AuditHandler {
+	h := &AuditHandler{auditUsecase: auditUsecase}
+	return h
+}
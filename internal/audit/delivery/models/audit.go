@@ -0,0 +1,132 @@
+package models
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mailru/easyjson"
+
+	"project/internal/models"
+	"project/internal/pkg"
+)
+
+//go:generate easyjson -disallow_unknown_fields -omit_empty audit.go
+
+type AuditRequest struct {
+	Entity   string
+	EntityID string
+	Actor    string
+	From     time.Time
+	To       time.Time
+	Limit    int64
+	Since    int64
+}
+
+func NewAuditRequest() *AuditRequest {
+	return &AuditRequest{}
+}
+
+func (req *AuditRequest) Bind(r *http.Request) error {
+	req.Entity = r.FormValue("entity")
+	req.EntityID = r.FormValue("id")
+	req.Actor = r.FormValue("actor")
+
+	var err error
+
+	param := r.FormValue("from")
+	if param != "" {
+		req.From, err = time.Parse(time.RFC3339, param)
+		if err != nil {
+			return pkg.ErrConvertQueryType
+		}
+	}
+
+	param = r.FormValue("to")
+	if param != "" {
+		req.To, err = time.Parse(time.RFC3339, param)
+		if err != nil {
+			return pkg.ErrConvertQueryType
+		}
+	}
+
+	req.Limit = 100
+
+	param = r.FormValue("limit")
+	if param != "" {
+		req.Limit, err = strconv.ParseInt(param, 10, 64)
+		if err != nil {
+			return pkg.ErrConvertQueryType
+		}
+	}
+
+	param = r.FormValue("since")
+	if param != "" {
+		req.Since, err = strconv.ParseInt(param, 10, 64)
+		if err != nil {
+			return pkg.ErrConvertQueryType
+		}
+	}
+
+	return nil
+}
+
+func (req *AuditRequest) GetParams() *pkg.GetAuditParams {
+	return &pkg.GetAuditParams{
+		Entity:   req.Entity,
+		EntityID: req.EntityID,
+		Actor:    req.Actor,
+		From:     req.From,
+		To:       req.To,
+		Limit:    req.Limit,
+		Since:    req.Since,
+	}
+}
+
+//easyjson:json
+type AuditEntryResponse struct {
+	ID        int64               `json:"id"`
+	Actor     string              `json:"actor,omitempty"`
+	RequestID string              `json:"requestId,omitempty"`
+	Entity    string              `json:"entity"`
+	EntityID  string              `json:"entityId"`
+	Action    string              `json:"action"`
+	Before    easyjson.RawMessage `json:"before,omitempty"`
+	After     easyjson.RawMessage `json:"after,omitempty"`
+	Created   string              `json:"created"`
+}
+
+//easyjson:json
+type AuditList []AuditEntryResponse
+
+func NewAuditEntryResponse(entry *models.AuditEntry) *AuditEntryResponse {
+	res := &AuditEntryResponse{
+		ID:        entry.ID,
+		Actor:     entry.Actor,
+		RequestID: entry.RequestID,
+		Entity:    entry.Entity,
+		EntityID:  entry.EntityID,
+		Action:    entry.Action,
+		Created:   entry.Created,
+	}
+
+	if entry.Before != "" {
+		res.Before = easyjson.RawMessage(entry.Before)
+	}
+
+	if entry.After != "" {
+		res.After = easyjson.RawMessage(entry.After)
+	}
+
+	return res
+}
+
+func NewAuditResponse(entries []*models.AuditEntry) AuditList {
+	res := make([]AuditEntryResponse, len(entries))
+
+	for idx, value := range entries {
+		res[idx] = *NewAuditEntryResponse(value)
+	}
+
+	return res
+}
@@ -0,0 +1,252 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package models
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonF2c44427DecodeProjectInternalAuditDeliveryModels(in *jlexer.Lexer, out *AuditList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(AuditList, 0, 0)
+			} else {
+				*out = AuditList{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v1 AuditEntryResponse
+			(v1).UnmarshalEasyJSON(in)
+			*out = append(*out, v1)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF2c44427EncodeProjectInternalAuditDeliveryModels(out *jwriter.Writer, in AuditList) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v2, v3 := range in {
+			if v2 > 0 {
+				out.RawByte(',')
+			}
+			(v3).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AuditList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF2c44427EncodeProjectInternalAuditDeliveryModels(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AuditList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF2c44427EncodeProjectInternalAuditDeliveryModels(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AuditList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF2c44427DecodeProjectInternalAuditDeliveryModels(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AuditList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF2c44427DecodeProjectInternalAuditDeliveryModels(l, v)
+}
+func easyjsonF2c44427DecodeProjectInternalAuditDeliveryModels1(in *jlexer.Lexer, out *AuditEntryResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int64(in.Int64())
+		case "actor":
+			out.Actor = string(in.String())
+		case "requestId":
+			out.RequestID = string(in.String())
+		case "entity":
+			out.Entity = string(in.String())
+		case "entityId":
+			out.EntityID = string(in.String())
+		case "action":
+			out.Action = string(in.String())
+		case "before":
+			(out.Before).UnmarshalEasyJSON(in)
+		case "after":
+			(out.After).UnmarshalEasyJSON(in)
+		case "created":
+			out.Created = string(in.String())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF2c44427EncodeProjectInternalAuditDeliveryModels1(out *jwriter.Writer, in AuditEntryResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.ID != 0 {
+		const prefix string = ",\"id\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.ID))
+	}
+	if in.Actor != "" {
+		const prefix string = ",\"actor\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Actor))
+	}
+	if in.RequestID != "" {
+		const prefix string = ",\"requestId\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.RequestID))
+	}
+	if in.Entity != "" {
+		const prefix string = ",\"entity\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Entity))
+	}
+	if in.EntityID != "" {
+		const prefix string = ",\"entityId\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.EntityID))
+	}
+	if in.Action != "" {
+		const prefix string = ",\"action\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Action))
+	}
+	if (in.Before).IsDefined() {
+		const prefix string = ",\"before\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		(in.Before).MarshalEasyJSON(out)
+	}
+	if (in.After).IsDefined() {
+		const prefix string = ",\"after\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		(in.After).MarshalEasyJSON(out)
+	}
+	if in.Created != "" {
+		const prefix string = ",\"created\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Created))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AuditEntryResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF2c44427EncodeProjectInternalAuditDeliveryModels1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AuditEntryResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF2c44427EncodeProjectInternalAuditDeliveryModels1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AuditEntryResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF2c44427DecodeProjectInternalAuditDeliveryModels1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AuditEntryResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF2c44427DecodeProjectInternalAuditDeliveryModels1(l, v)
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"project/internal/models"
+	"project/internal/pkg"
+	"project/internal/pkg/sqltools"
+)
+
+// AuditRepository reads the audit log. Entries are appended by the repositories making the changes, see
+// sqltools.Audit.
+type AuditRepository interface {
+	GetEntries(ctx context.Context, params *pkg.GetAuditParams) ([]*models.AuditEntry, error)
+}
+
+type auditPostgres struct {
+	conn *sqltools.Cluster
+}
+
+func NewAuditPostgres(conn *sqltools.Cluster) AuditRepository {
+	return &auditPostgres{
+		conn,
+	}
+}
+
+// GetEntries returns the newest entries first, Since being the ID to continue after.
+func (a auditPostgres) GetEntries(ctx context.Context, params *pkg.GetAuditParams) ([]*models.AuditEntry, error) {
+	res := make([]*models.AuditEntry, 0)
+
+	rows, err := a.conn.Replica(ctx).QueryContext(ctx, `SELECT audit_id, COALESCE(actor, ''), COALESCE(request_id, ''),
+			entity, entity_id, action, COALESCE(before::text, ''), COALESCE(after::text, ''), created
+		FROM audit_log
+		WHERE ($1 = '' OR entity = $1)
+		  AND ($2 = '' OR entity_id = $2)
+		  AND ($3 = '' OR actor = $3)
+		  AND ($4::timestamptz IS NULL OR created >= $4)
+		  AND ($5::timestamptz IS NULL OR created < $5)
+		  AND ($6 = 0 OR audit_id < $6)
+		ORDER BY audit_id DESC
+		LIMIT $7;`,
+		params.Entity, params.EntityID, params.Actor, nullTime(params.From), nullTime(params.To), params.Since, params.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		entry := &models.AuditEntry{}
+
+		var created time.Time
+
+		err = rows.Scan(
+			&entry.ID,
+			&entry.Actor,
+			&entry.RequestID,
+			&entry.Entity,
+			&entry.EntityID,
+			&entry.Action,
+			&entry.Before,
+			&entry.After,
+			&created)
+		if err != nil {
+			return nil, err
+		}
+
+		entry.Created = created.Format(time.RFC3339Nano)
+
+		res = append(res, entry)
+	}
+
+	return res, nil
+}
+
+func nullTime(value time.Time) sql.NullTime {
+	return sql.NullTime{Time: value, Valid: !value.IsZero()}
+}
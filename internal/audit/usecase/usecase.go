@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	repoAudit "project/internal/audit/repository"
+	"project/internal/models"
+	"project/internal/pkg"
+)
+
+type AuditService interface {
+	GetEntries(ctx context.Context, params *pkg.GetAuditParams) ([]*models.AuditEntry, error)
+}
+
+type auditService struct {
+	auditRepo repoAudit.AuditRepository
+}
+
+func NewAuditService(ra repoAudit.AuditRepository) AuditService {
+	return &auditService{
+		auditRepo: ra,
+	}
+}
+
+func (a auditService) GetEntries(ctx context.Context, params *pkg.GetAuditParams) ([]*models.AuditEntry, error) {
+	switch params.Entity {
+	case "", pkg.AuditEntityUser, pkg.AuditEntityForum, pkg.AuditEntityThread, pkg.AuditEntityPost,
+		pkg.AuditEntityVote, pkg.AuditEntityService:
+	default:
+		return nil, errors.Wrap(pkg.ErrBadRequestParams, "GetEntries")
+	}
+
+	if params.Limit <= 0 {
+		return nil, errors.Wrap(pkg.ErrBadRequestParams, "GetEntries")
+	}
+
+	res, err := a.auditRepo.GetEntries(ctx, params)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetEntries")
+	}
+
+	return res, nil
+}
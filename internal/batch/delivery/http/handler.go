@@ -0,0 +1,187 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/mux"
+	"github.com/mailru/easyjson"
+	"github.com/mailru/easyjson/jwriter"
+
+	"project/internal/batch/delivery/models"
+	"project/internal/pkg"
+)
+
+// maxConcurrentReads bounds how many sub-requests of one batch run at the same time
+const maxConcurrentReads = 8
+
+// forwardedHeaders are the headers of the batch passed on to its sub-requests
+var forwardedHeaders = []string{pkg.HeaderNickname, pkg.HeaderNicknameSignature, pkg.HeaderAdminToken, pkg.HeaderConsistency}
+
+// returnedHeaders are the headers of sub-responses returned with them
+var returnedHeaders = []string{"Content-Type", "ETag", "Last-Modified", "Location", "Retry-After"}
+
+type BatchHandler struct {
+	router *mux.Router
+}
+
+// BatchHandler runs the sub-requests through the router in their order. Reads between two writes do not depend
+// on each other and run concurrently; a write waits for the reads before it and blocks the ones after it.
+func (h *BatchHandler) BatchHandler(w http.ResponseWriter, r *http.Request) {
+	request := models.NewBatchRequest()
+
+	err := request.Bind(r)
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	response := &models.BatchResponse{
+		Responses: make([]models.BatchItemResponse, len(request.Requests)),
+	}
+
+	var wg sync.WaitGroup
+
+	reads := make(chan struct{}, maxConcurrentReads)
+
+	for idx := range request.Requests {
+		item := &request.Requests[idx]
+
+		item.Method = strings.ToUpper(item.Method)
+		if item.Method == "" {
+			item.Method = http.MethodGet
+		}
+
+		if item.Method != http.MethodGet && item.Method != http.MethodHead {
+			wg.Wait()
+
+			response.Responses[idx] = h.dispatch(r, item)
+
+			continue
+		}
+
+		wg.Add(1)
+		reads <- struct{}{}
+
+		go func(idx int) {
+			defer func() {
+				<-reads
+				wg.Done()
+			}()
+
+			response.Responses[idx] = h.dispatch(r, item)
+		}(idx)
+	}
+
+	wg.Wait()
+
+	pkg.Response(r.Context(), w, http.StatusOK, response)
+}
+
+func newItemError(item *models.BatchItemRequest, err error) models.BatchItemResponse {
+	code, _ := pkg.GetErrorCodeHTTP(err)
+
+	body, _ := easyjson.Marshal(&pkg.ErrResponse{ErrMassage: err.Error()})
+
+	return models.BatchItemResponse{
+		ID:     item.ID,
+		Status: code,
+		Body:   body,
+	}
+}
+
+func (h *BatchHandler) dispatch(parent *http.Request, item *models.BatchItemRequest) models.BatchItemResponse {
+	target, err := url.Parse(item.Path)
+	if err != nil || target.IsAbs() || !strings.HasPrefix(target.Path, "/api/") {
+		return newItemError(item, pkg.ErrBadRequestParams)
+	}
+
+	query := target.Query()
+	for key, value := range item.Query {
+		query.Set(key, value)
+	}
+
+	target.RawQuery = query.Encode()
+
+	var body io.Reader = http.NoBody
+	if len(item.Body) > 0 {
+		body = bytes.NewReader(item.Body)
+	}
+
+	req, err := http.NewRequestWithContext(parent.Context(), item.Method, target.String(), body)
+	if err != nil {
+		return newItemError(item, pkg.ErrBadRequestParams)
+	}
+
+	req.Host = parent.Host
+	req.RemoteAddr = parent.RemoteAddr
+
+	if len(item.Body) > 0 {
+		req.Header.Set("Content-Type", pkg.ContentTypeJSON)
+	}
+
+	for _, header := range forwardedHeaders {
+		value := parent.Header.Get(header)
+		if value != "" {
+			req.Header.Set(header, value)
+		}
+	}
+
+	// Batches do not nest, and streams never end
+	match := &mux.RouteMatch{}
+	if h.router.Match(req, match) && match.Route != nil {
+		name := match.Route.GetName()
+
+		if name == pkg.BatchRouteName || strings.HasPrefix(name, pkg.StreamRoutePrefix) {
+			return newItemError(item, pkg.ErrBatchRouteUnsupported)
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+
+	h.router.ServeHTTP(recorder, req)
+
+	res := models.BatchItemResponse{
+		ID:     item.ID,
+		Status: recorder.Code,
+	}
+
+	for _, header := range returnedHeaders {
+		value := recorder.Header().Get(header)
+		if value == "" {
+			continue
+		}
+
+		if res.Headers == nil {
+			res.Headers = make(map[string]string)
+		}
+
+		res.Headers[header] = value
+	}
+
+	out := recorder.Body.Bytes()
+
+	switch {
+	case len(out) == 0:
+	case json.Valid(out):
+		res.Body = out
+	default:
+		// Feeds and other non-JSON bodies are returned as strings
+		writer := jwriter.Writer{}
+		writer.String(string(out))
+		res.Body = writer.Buffer.BuildBytes()
+	}
+
+	return res
+}
+
+func NewBatchHandler(r *mux.Router) *BatchHandler {
+	h := &BatchHandler{router: r}
+	return h
+}
@@ -0,0 +1,313 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/mailru/easyjson"
+
+	"project/internal/batch/delivery/models"
+	"project/internal/pkg"
+)
+
+// journal records the order sub-requests finish in and how many reads run at once.
+type journal struct {
+	mu       sync.Mutex
+	done     []string
+	inflight int
+	peak     int
+}
+
+func (j *journal) enter() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.inflight++
+	if j.inflight > j.peak {
+		j.peak = j.inflight
+	}
+}
+
+func (j *journal) leave(name string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.inflight--
+	j.done = append(j.done, name)
+}
+
+func (j *journal) running() int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.inflight
+}
+
+func (j *journal) reached() int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.peak
+}
+
+// newTestRouter serves reads which wait until "together" reads have run at once, which only happens when the batch
+// runs them concurrently, and writes which must not overlap anything.
+func newTestRouter(j *journal) *mux.Router {
+	router := mux.NewRouter()
+
+	router.HandleFunc("/api/read/{n}", func(w http.ResponseWriter, r *http.Request) {
+		j.enter()
+
+		together, _ := strconv.Atoi(r.FormValue("together"))
+
+		deadline := time.Now().Add(time.Second)
+		for j.reached() < together && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+
+		j.leave("r" + mux.Vars(r)["n"])
+
+		w.Header().Set("ETag", `"`+mux.Vars(r)["n"]+`"`)
+		_, _ = fmt.Fprintf(w, `{"n":%s}`, mux.Vars(r)["n"])
+	}).Methods(http.MethodGet)
+
+	router.HandleFunc("/api/write/{n}", func(w http.ResponseWriter, r *http.Request) {
+		j.enter()
+		running := j.running()
+		j.leave("w" + mux.Vars(r)["n"])
+
+		if running != 1 {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+	}).Methods(http.MethodPost)
+
+	router.HandleFunc("/api/whoami", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, "%q", r.Header.Get(pkg.HeaderNickname))
+	}).Methods(http.MethodGet)
+
+	router.HandleFunc("/api/feed", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<feed/>"))
+	}).Methods(http.MethodGet)
+
+	router.HandleFunc("/api/stream", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}).Methods(http.MethodGet).Name(pkg.StreamRoutePrefix + "-test")
+
+	handler := NewBatchHandler(router)
+	router.HandleFunc("/api/batch", handler.BatchHandler).Methods(http.MethodPost).Name(pkg.BatchRouteName)
+
+	return router
+}
+
+func runBatch(t *testing.T, router *mux.Router, nickname string, items []models.BatchItemRequest) *models.BatchResponse {
+	t.Helper()
+
+	body, err := easyjson.Marshal(&models.BatchRequest{Requests: items})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/api/batch", bytes.NewReader(body))
+	if nickname != "" {
+		r.Header.Set(pkg.HeaderNickname, nickname)
+	}
+
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("batch status = %d, body %s", w.Code, w.Body.String())
+	}
+
+	res := &models.BatchResponse{}
+
+	err = easyjson.Unmarshal(w.Body.Bytes(), res)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(res.Responses) != len(items) {
+		t.Fatalf("got %d responses for %d requests", len(res.Responses), len(items))
+	}
+
+	return res
+}
+
+func read(n int, together int) models.BatchItemRequest {
+	return models.BatchItemRequest{
+		ID:    "r" + strconv.Itoa(n),
+		Path:  "/api/read/" + strconv.Itoa(n),
+		Query: map[string]string{"together": strconv.Itoa(together)},
+	}
+}
+
+func write(n int) models.BatchItemRequest {
+	return models.BatchItemRequest{
+		ID:     "w" + strconv.Itoa(n),
+		Method: "post",
+		Path:   "/api/write/" + strconv.Itoa(n),
+	}
+}
+
+func TestBatchOrdering(t *testing.T) {
+	tests := []struct {
+		name     string
+		items    []models.BatchItemRequest
+		wantPeak int
+	}{
+		{
+			name:     "reads run together",
+			items:    []models.BatchItemRequest{read(0, 3), read(1, 3), read(2, 3)},
+			wantPeak: 3,
+		},
+		{
+			name:     "reads are bounded",
+			items:    []models.BatchItemRequest{read(0, 8), read(1, 8), read(2, 8), read(3, 8), read(4, 8), read(5, 8), read(6, 8), read(7, 8), read(8, 1), read(9, 1)},
+			wantPeak: maxConcurrentReads,
+		},
+		{
+			name:     "writes split reads",
+			items:    []models.BatchItemRequest{read(0, 2), read(1, 2), write(2), read(3, 2), read(4, 2), write(5), read(6, 1)},
+			wantPeak: 2,
+		},
+		{
+			name:     "writes in a row",
+			items:    []models.BatchItemRequest{write(0), write(1), write(2)},
+			wantPeak: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &journal{}
+
+			res := runBatch(t, newTestRouter(j), "", tt.items)
+
+			position := make(map[string]int, len(j.done))
+			for idx, name := range j.done {
+				position[name] = idx
+			}
+
+			for idx, item := range tt.items {
+				got := res.Responses[idx]
+
+				if got.ID != item.ID {
+					t.Errorf("response %d is for %q, want %q", idx, got.ID, item.ID)
+				}
+
+				if got.Status != http.StatusOK && got.Status != http.StatusCreated {
+					t.Errorf("%s status = %d", item.ID, got.Status)
+				}
+
+				if item.Method == "" {
+					continue
+				}
+
+				// Everything before a write finishes before it, everything after it starts after it
+				for other := range tt.items {
+					name := tt.items[other].ID
+
+					if other < idx && position[name] > position[item.ID] {
+						t.Errorf("%s finished after %s", name, item.ID)
+					}
+
+					if other > idx && position[name] < position[item.ID] {
+						t.Errorf("%s finished before %s", name, item.ID)
+					}
+				}
+			}
+
+			if j.peak != tt.wantPeak {
+				t.Errorf("peak concurrency = %d, want %d", j.peak, tt.wantPeak)
+			}
+		})
+	}
+}
+
+func TestBatchItems(t *testing.T) {
+	tests := []struct {
+		name        string
+		item        models.BatchItemRequest
+		wantStatus  int
+		wantBody    string
+		wantHeaders map[string]string
+	}{
+		{
+			name:        "read with query in the path",
+			item:        models.BatchItemRequest{Path: "/api/read/7?together=1"},
+			wantStatus:  http.StatusOK,
+			wantBody:    `{"n":7}`,
+			wantHeaders: map[string]string{"ETag": `"7"`},
+		},
+		{
+			name:       "headers are forwarded",
+			item:       models.BatchItemRequest{Path: "/api/whoami"},
+			wantStatus: http.StatusOK,
+			wantBody:   `"alice"`,
+		},
+		{
+			name:       "non-JSON body is a string",
+			item:       models.BatchItemRequest{Path: "/api/feed"},
+			wantStatus: http.StatusOK,
+			wantBody:   `"\u003cfeed/\u003e"`,
+		},
+		{
+			name:       "outside the API",
+			item:       models.BatchItemRequest{Path: "/metrics"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "absolute URL",
+			item:       models.BatchItemRequest{Path: "http://example.com/api/feed"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "nested batch",
+			item:       models.BatchItemRequest{Method: http.MethodPost, Path: "/api/batch"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "stream",
+			item:       models.BatchItemRequest{Path: "/api/stream"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown route",
+			item:       models.BatchItemRequest{Path: "/api/missing"},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := runBatch(t, newTestRouter(&journal{}), "alice", []models.BatchItemRequest{tt.item})
+
+			got := res.Responses[0]
+
+			if got.Status != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body %s", got.Status, tt.wantStatus, got.Body)
+			}
+
+			if tt.wantBody != "" && string(got.Body) != tt.wantBody {
+				t.Errorf("body = %s, want %s", got.Body, tt.wantBody)
+			}
+
+			for header, value := range tt.wantHeaders {
+				if got.Headers[header] != value {
+					t.Errorf("header %s = %q, want %q", header, got.Headers[header], value)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/mailru/easyjson"
+
+	"project/internal/pkg"
+)
+
+//go:generate easyjson -disallow_unknown_fields -omit_empty batch.go
+
+const MaxBatchRequests = 50
+
+//easyjson:json
+type BatchItemRequest struct {
+	ID     string              `json:"id"`
+	Method string              `json:"method"`
+	Path   string              `json:"path"`
+	Query  map[string]string   `json:"query"`
+	Body   easyjson.RawMessage `json:"body"`
+}
+
+//easyjson:json
+type BatchRequest struct {
+	Requests []BatchItemRequest `json:"requests"`
+}
+
+func NewBatchRequest() *BatchRequest {
+	return &BatchRequest{}
+}
+
+func (req *BatchRequest) Bind(r *http.Request) error {
+	body, _ := io.ReadAll(r.Body)
+
+	err := easyjson.Unmarshal(body, req)
+	if err != nil {
+		return pkg.ErrBadBodyRequest
+	}
+
+	if len(req.Requests) == 0 {
+		return pkg.ErrEmptyBody
+	}
+
+	if len(req.Requests) > MaxBatchRequests {
+		return pkg.ErrBigRequest
+	}
+
+	return nil
+}
+
+//easyjson:json
+type BatchItemResponse struct {
+	ID      string              `json:"id,omitempty"`
+	Status  int                 `json:"status"`
+	Headers map[string]string   `json:"headers,omitempty"`
+	Body    easyjson.RawMessage `json:"body,omitempty"`
+}
+
+//easyjson:json
+type BatchResponse struct {
+	Responses []BatchItemResponse `json:"responses"`
+}
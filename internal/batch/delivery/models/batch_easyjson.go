@@ -0,0 +1,511 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package models
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson917759c2DecodeProjectInternalBatchDeliveryModels(in *jlexer.Lexer, out *BatchResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "responses":
+			if in.IsNull() {
+				in.Skip()
+				out.Responses = nil
+			} else {
+				in.Delim('[')
+				if out.Responses == nil {
+					if !in.IsDelim(']') {
+						out.Responses = make([]BatchItemResponse, 0, 1)
+					} else {
+						out.Responses = []BatchItemResponse{}
+					}
+				} else {
+					out.Responses = (out.Responses)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 BatchItemResponse
+					(v1).UnmarshalEasyJSON(in)
+					out.Responses = append(out.Responses, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson917759c2EncodeProjectInternalBatchDeliveryModels(out *jwriter.Writer, in BatchResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if len(in.Responses) != 0 {
+		const prefix string = ",\"responses\":"
+		first = false
+		out.RawString(prefix[1:])
+		{
+			out.RawByte('[')
+			for v2, v3 := range in.Responses {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BatchResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson917759c2EncodeProjectInternalBatchDeliveryModels(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BatchResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson917759c2EncodeProjectInternalBatchDeliveryModels(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BatchResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson917759c2DecodeProjectInternalBatchDeliveryModels(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BatchResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson917759c2DecodeProjectInternalBatchDeliveryModels(l, v)
+}
+func easyjson917759c2DecodeProjectInternalBatchDeliveryModels1(in *jlexer.Lexer, out *BatchRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "requests":
+			if in.IsNull() {
+				in.Skip()
+				out.Requests = nil
+			} else {
+				in.Delim('[')
+				if out.Requests == nil {
+					if !in.IsDelim(']') {
+						out.Requests = make([]BatchItemRequest, 0, 0)
+					} else {
+						out.Requests = []BatchItemRequest{}
+					}
+				} else {
+					out.Requests = (out.Requests)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 BatchItemRequest
+					(v4).UnmarshalEasyJSON(in)
+					out.Requests = append(out.Requests, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson917759c2EncodeProjectInternalBatchDeliveryModels1(out *jwriter.Writer, in BatchRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if len(in.Requests) != 0 {
+		const prefix string = ",\"requests\":"
+		first = false
+		out.RawString(prefix[1:])
+		{
+			out.RawByte('[')
+			for v5, v6 := range in.Requests {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				(v6).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BatchRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson917759c2EncodeProjectInternalBatchDeliveryModels1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BatchRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson917759c2EncodeProjectInternalBatchDeliveryModels1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BatchRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson917759c2DecodeProjectInternalBatchDeliveryModels1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BatchRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson917759c2DecodeProjectInternalBatchDeliveryModels1(l, v)
+}
+func easyjson917759c2DecodeProjectInternalBatchDeliveryModels2(in *jlexer.Lexer, out *BatchItemResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "status":
+			out.Status = int(in.Int())
+		case "headers":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Headers = make(map[string]string)
+				} else {
+					out.Headers = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v7 string
+					v7 = string(in.String())
+					(out.Headers)[key] = v7
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "body":
+			(out.Body).UnmarshalEasyJSON(in)
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson917759c2EncodeProjectInternalBatchDeliveryModels2(out *jwriter.Writer, in BatchItemResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.ID != "" {
+		const prefix string = ",\"id\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	if in.Status != 0 {
+		const prefix string = ",\"status\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Status))
+	}
+	if len(in.Headers) != 0 {
+		const prefix string = ",\"headers\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('{')
+			v8First := true
+			for v8Name, v8Value := range in.Headers {
+				if v8First {
+					v8First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v8Name))
+				out.RawByte(':')
+				out.String(string(v8Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	if (in.Body).IsDefined() {
+		const prefix string = ",\"body\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		(in.Body).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BatchItemResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson917759c2EncodeProjectInternalBatchDeliveryModels2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BatchItemResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson917759c2EncodeProjectInternalBatchDeliveryModels2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BatchItemResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson917759c2DecodeProjectInternalBatchDeliveryModels2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BatchItemResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson917759c2DecodeProjectInternalBatchDeliveryModels2(l, v)
+}
+func easyjson917759c2DecodeProjectInternalBatchDeliveryModels3(in *jlexer.Lexer, out *BatchItemRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "method":
+			out.Method = string(in.String())
+		case "path":
+			out.Path = string(in.String())
+		case "query":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Query = make(map[string]string)
+				} else {
+					out.Query = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v9 string
+					v9 = string(in.String())
+					(out.Query)[key] = v9
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "body":
+			(out.Body).UnmarshalEasyJSON(in)
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson917759c2EncodeProjectInternalBatchDeliveryModels3(out *jwriter.Writer, in BatchItemRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.ID != "" {
+		const prefix string = ",\"id\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	if in.Method != "" {
+		const prefix string = ",\"method\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Method))
+	}
+	if in.Path != "" {
+		const prefix string = ",\"path\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Path))
+	}
+	if len(in.Query) != 0 {
+		const prefix string = ",\"query\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('{')
+			v10First := true
+			for v10Name, v10Value := range in.Query {
+				if v10First {
+					v10First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v10Name))
+				out.RawByte(':')
+				out.String(string(v10Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	if (in.Body).IsDefined() {
+		const prefix string = ",\"body\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		(in.Body).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BatchItemRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson917759c2EncodeProjectInternalBatchDeliveryModels3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BatchItemRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson917759c2EncodeProjectInternalBatchDeliveryModels3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BatchItemRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson917759c2DecodeProjectInternalBatchDeliveryModels3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BatchItemRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson917759c2DecodeProjectInternalBatchDeliveryModels3(l, v)
+}
@@ -0,0 +1,417 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	"project/internal/models"
+	"project/internal/pkg"
+	"project/internal/pkg/sqltools"
+)
+
+// checkLock is the key of the advisory lock held by the process running the checker, so that the replicas of the
+// server take turns.
+const checkLock = 4707
+
+// keptRuns is how many reports of past runs are kept.
+const keptRuns = 100
+
+type ConsistencyRepository interface {
+	Find(ctx context.Context, check string, since string, limit int) ([]models.ConsistencyIssue, error)
+	Repair(ctx context.Context, check string, keys []string) (int64, error)
+	TryLock(ctx context.Context) (func(), bool, error)
+	SaveReport(ctx context.Context, report *models.ConsistencyReport) error
+	GetLastReport(ctx context.Context) (*models.ConsistencyReport, error)
+}
+
+type consistencyPostgres struct {
+	conn *sqltools.Cluster
+}
+
+func NewConsistencyPostgres(conn *sqltools.Cluster) ConsistencyRepository {
+	return &consistencyPostgres{
+		conn,
+	}
+}
+
+// Each check selects the discrepancies as (position, key, field, stored, expected), position being unique and
+// ordered the way the checker goes through them.
+var checks = map[string]string{
+	// Forum counters include those of the sub-forums rolled up into them, as in function_count_posts
+	pkg.ConsistencyForumCounters: `WITH RECURSIVE own AS (
+		SELECT forum AS slug, count(*) AS posts, 0 AS threads FROM posts GROUP BY forum
+		UNION ALL
+		SELECT forum, 0, count(*) FROM threads GROUP BY forum
+	), chain(source, target, parent, roll_up) AS (
+		SELECT slug, slug, parent, roll_up FROM forums
+		UNION ALL
+		SELECT c.source, f.slug, f.parent, f.roll_up FROM chain c JOIN forums f ON f.slug = c.parent WHERE c.roll_up
+	), totals AS (
+		SELECT c.target AS slug, coalesce(sum(o.posts), 0) AS posts, coalesce(sum(o.threads), 0) AS threads
+		FROM chain c LEFT JOIN own o ON o.slug = c.source
+		GROUP BY c.target
+	)
+	SELECT f.slug::text || '/' || c.field, f.slug::text, c.field, c.stored::text, c.expected::text
+	FROM forums f
+		JOIN totals t ON t.slug = f.slug
+		CROSS JOIN LATERAL (VALUES ('posts', f.posts::bigint, t.posts::bigint),
+								   ('threads', f.threads::bigint, t.threads::bigint)) AS c(field, stored, expected)
+	WHERE c.stored IS DISTINCT FROM c.expected`,
+	pkg.ConsistencyThreadCounters: `SELECT lpad(t.thread_id::text, 19, '0') || '/' || c.field, t.thread_id::text, c.field,
+		   c.stored::text, c.expected::text
+	FROM threads t
+		LEFT JOIN (SELECT thread_id, sum(voice) AS votes FROM user_votes GROUP BY thread_id) v ON v.thread_id = t.thread_id
+		LEFT JOIN (SELECT thread_id, count(*) AS replies FROM posts GROUP BY thread_id) p ON p.thread_id = t.thread_id
+		CROSS JOIN LATERAL (VALUES ('votes', t.votes::bigint, coalesce(v.votes, 0)::bigint),
+								   ('replies', t.replies::bigint, coalesce(p.replies, 0)::bigint)) AS c(field, stored, expected)
+	WHERE c.stored IS DISTINCT FROM c.expected`,
+	// The path of a post is the path of its parent, in the same thread, followed by its ID. A parent missing or in
+	// another thread is reported as such, as there is no path to expect.
+	pkg.ConsistencyPostPaths: `SELECT lpad(p.post_id::text, 19, '0'), p.post_id::text,
+		   CASE WHEN broken THEN 'parent_thread' ELSE 'path' END,
+		   CASE WHEN broken THEN coalesce(parent.thread_id::text, 'none') ELSE p.path::text END,
+		   CASE WHEN broken THEN p.thread_id::text
+				WHEN coalesce(p.parent, 0) = 0 THEN ARRAY [p.post_id]::text
+				ELSE (parent.path || p.post_id)::text END
+	FROM posts p
+		LEFT JOIN posts parent ON parent.post_id = p.parent AND coalesce(p.parent, 0) <> 0
+		CROSS JOIN LATERAL (SELECT coalesce(p.parent, 0) <> 0
+								   AND parent.thread_id IS DISTINCT FROM p.thread_id AS broken) b
+	WHERE broken
+	   OR p.path IS DISTINCT FROM CASE WHEN coalesce(p.parent, 0) = 0 THEN ARRAY [p.post_id]
+									   ELSE parent.path || p.post_id END`,
+	pkg.ConsistencyUserForums: `SELECT a.forum::text || '/' || a.author::text, a.forum::text || '/' || a.author::text,
+		   'membership', 'missing', 'present'
+	FROM (SELECT author, forum FROM threads UNION SELECT author, forum FROM posts WHERE forum IS NOT NULL) a
+	WHERE NOT EXISTS(SELECT 1 FROM user_forums uf WHERE uf.forum = a.forum AND uf.nickname = a.author)
+	UNION ALL
+	SELECT uf.forum::text || '/' || uf.nickname::text, uf.forum::text || '/' || uf.nickname::text,
+		   'membership', 'present', 'missing'
+	FROM user_forums uf
+	WHERE NOT EXISTS(SELECT 1 FROM threads t WHERE t.forum = uf.forum AND t.author = uf.nickname)
+	  AND NOT EXISTS(SELECT 1 FROM posts p WHERE p.forum = uf.forum AND p.author = uf.nickname)`,
+}
+
+// repair recomputes the derived values of the entities of a batch, $1 being their keys. The rows are locked before
+// they are recomputed, so that the triggers cannot change them in between.
+type repair struct {
+	lock    string
+	updates []string
+}
+
+var repairs = map[string]repair{
+	// Posts are counted by their own forum, as the check and function_count_posts do
+	pkg.ConsistencyForumCounters: {
+		lock: `SELECT slug FROM forums WHERE slug = ANY ($1::text[]::citext[]) ORDER BY slug FOR UPDATE;`,
+		updates: []string{`WITH RECURSIVE sources(target, source) AS (
+			SELECT slug, slug FROM forums WHERE slug = ANY ($1::text[]::citext[])
+			UNION ALL
+			SELECT s.target, f.slug FROM sources s JOIN forums f ON f.parent = s.source AND f.roll_up
+		), totals AS (
+			SELECT s.target AS slug,
+				   sum((SELECT count(*) FROM posts p WHERE p.forum = s.source)) AS posts,
+				   sum((SELECT count(*) FROM threads t WHERE t.forum = s.source)) AS threads
+			FROM sources s
+			GROUP BY s.target
+		)
+		UPDATE forums f
+		SET posts = t.posts, threads = t.threads
+		FROM totals t
+		WHERE f.slug = t.slug
+		  AND (f.posts, f.threads) IS DISTINCT FROM (t.posts::int, t.threads::int);`},
+	},
+	pkg.ConsistencyThreadCounters: {
+		lock: `SELECT thread_id FROM threads WHERE thread_id = ANY ($1::text[]::int[]) ORDER BY thread_id FOR UPDATE;`,
+		updates: []string{`UPDATE threads t
+		SET votes = r.votes, replies = r.replies
+		FROM (SELECT x.thread_id, coalesce(v.votes, 0)::int AS votes, coalesce(p.replies, 0)::int AS replies
+			  FROM threads x
+				  LEFT JOIN (SELECT thread_id, sum(voice) AS votes
+							 FROM user_votes
+							 WHERE thread_id = ANY ($1::text[]::int[])
+							 GROUP BY thread_id) v ON v.thread_id = x.thread_id
+				  LEFT JOIN (SELECT thread_id, count(*) AS replies
+							 FROM posts
+							 WHERE thread_id = ANY ($1::text[]::int[])
+							 GROUP BY thread_id) p ON p.thread_id = x.thread_id
+			  WHERE x.thread_id = ANY ($1::text[]::int[])) r
+		WHERE t.thread_id = r.thread_id
+		  AND (t.votes, t.replies) IS DISTINCT FROM (r.votes, r.replies);`},
+	},
+	// The paths are rebuilt from the parents up to the root, so that a post is right even when its parent is fixed
+	// in the same batch. Posts without a root in their thread are left alone.
+	pkg.ConsistencyPostPaths: {
+		lock: `SELECT post_id FROM posts WHERE post_id = ANY ($1::text[]::bigint[]) ORDER BY post_id FOR UPDATE;`,
+		updates: []string{`WITH RECURSIVE up(post_id, ancestor, parent, thread_id, depth) AS (
+			SELECT post_id, post_id, coalesce(parent, 0), thread_id, 0
+			FROM posts
+			WHERE post_id = ANY ($1::text[]::bigint[])
+			UNION ALL
+			SELECT u.post_id, p.post_id, coalesce(p.parent, 0), p.thread_id, u.depth + 1
+			FROM up u
+				JOIN posts p ON p.post_id = u.parent AND p.thread_id = u.thread_id
+			WHERE u.parent <> 0 AND u.depth < 10000
+		), rebuilt AS (
+			SELECT post_id, array_agg(ancestor ORDER BY depth DESC) AS path
+			FROM up
+			GROUP BY post_id
+			HAVING bool_or(parent = 0)
+		)
+		UPDATE posts p
+		SET path = r.path
+		FROM rebuilt r
+		WHERE p.post_id = r.post_id
+		  AND p.path IS DISTINCT FROM r.path;`},
+	},
+	// Keys are forum/nickname; a user is added to or dropped from a forum by whether they still wrote in it
+	pkg.ConsistencyUserForums: {
+		updates: []string{`DELETE FROM user_forums uf
+		USING (SELECT split_part(key, '/', 1)::citext AS forum, substr(key, strpos(key, '/') + 1)::citext AS nickname
+			   FROM unnest($1::text[]) AS key) k
+		WHERE uf.forum = k.forum AND uf.nickname = k.nickname
+		  AND NOT EXISTS(SELECT 1 FROM threads t WHERE t.forum = uf.forum AND t.author = uf.nickname)
+		  AND NOT EXISTS(SELECT 1 FROM posts p WHERE p.forum = uf.forum AND p.author = uf.nickname);`,
+			`INSERT INTO user_forums (nickname, fullname, about, email, forum)
+		SELECT u.nickname, u.fullname, u.about, u.email, k.forum
+		FROM (SELECT split_part(key, '/', 1)::citext AS forum, substr(key, strpos(key, '/') + 1)::citext AS nickname
+			  FROM unnest($1::text[]) AS key) k
+			JOIN users u ON u.nickname = k.nickname
+		WHERE EXISTS(SELECT 1 FROM threads t WHERE t.forum = k.forum AND t.author = k.nickname)
+		   OR EXISTS(SELECT 1 FROM posts p WHERE p.forum = k.forum AND p.author = k.nickname)
+		ON CONFLICT DO NOTHING;`},
+	},
+}
+
+// Find returns the discrepancies of a check positioned after since. The whole check is evaluated for each batch,
+// on the primary as the replicas may not have caught up with a repair yet.
+func (c consistencyPostgres) Find(ctx context.Context, check string, since string, limit int) ([]models.ConsistencyIssue, error) {
+	query, ok := checks[check]
+	if !ok {
+		return nil, errors.Errorf("unknown check %s", check)
+	}
+
+	rows, err := c.conn.Primary(ctx).QueryContext(ctx, fmt.Sprintf(`SELECT pos, key, field, stored, expected
+		FROM (%s) AS issue(pos, key, field, stored, expected)
+		WHERE pos COLLATE "C" > $1
+		ORDER BY pos COLLATE "C"
+		LIMIT $2;`, query), since, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]models.ConsistencyIssue, 0)
+
+	for rows.Next() {
+		issue := models.ConsistencyIssue{}
+
+		var stored, expected sql.NullString
+
+		err = rows.Scan(
+			&issue.Position,
+			&issue.Key,
+			&issue.Field,
+			&stored,
+			&expected)
+		if err != nil {
+			return nil, err
+		}
+
+		issue.Stored = stored.String
+		issue.Expected = expected.String
+
+		res = append(res, issue)
+	}
+
+	return res, rows.Err()
+}
+
+// Repair recomputes the derived values of the entities with the given keys in a transaction of its own, and records
+// the keys repaired in the audit log. It returns the number of rows changed.
+func (c consistencyPostgres) Repair(ctx context.Context, check string, keys []string) (int64, error) {
+	repair, ok := repairs[check]
+	if !ok {
+		return 0, errors.Errorf("unknown check %s", check)
+	}
+
+	var res int64
+
+	err := sqltools.RunTxOnConn(ctx, pkg.TxInsertOptions, c.conn.Primary(ctx), func(ctx context.Context, tx *sql.Tx) error {
+		res = 0
+
+		if repair.lock != "" {
+			_, err := tx.ExecContext(ctx, repair.lock, pq.Array(keys))
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, query := range repair.updates {
+			result, err := tx.ExecContext(ctx, query, pq.Array(keys))
+			if err != nil {
+				return err
+			}
+
+			count, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+
+			res += count
+		}
+
+		if res == 0 {
+			return nil
+		}
+
+		after, err := json.Marshal(keys)
+		if err != nil {
+			return err
+		}
+
+		return sqltools.Audit(ctx, tx, pkg.AuditEntityService, pkg.AuditActionRepair, nil, map[string][]byte{check: after})
+	})
+
+	return res, err
+}
+
+// TryLock takes the lock of the checker on a connection of its own, which the returned func releases.
+func (c consistencyPostgres) TryLock(ctx context.Context) (func(), bool, error) {
+	conn, err := c.conn.Primary(ctx).Conn(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var locked bool
+
+	err = conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1);`, checkLock).Scan(&locked)
+	if err != nil || !locked {
+		conn.Close()
+
+		return nil, false, err
+	}
+
+	unlock := func() {
+		_, _ = conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1);`, checkLock)
+		conn.Close()
+	}
+
+	return unlock, true, nil
+}
+
+type checkRecord struct {
+	Name     string        `json:"name"`
+	Found    int64         `json:"found"`
+	Repaired int64         `json:"repaired"`
+	Samples  []issueRecord `json:"samples"`
+}
+
+type issueRecord struct {
+	Key      string `json:"key"`
+	Field    string `json:"field"`
+	Stored   string `json:"stored"`
+	Expected string `json:"expected"`
+}
+
+func (c consistencyPostgres) SaveReport(ctx context.Context, report *models.ConsistencyReport) error {
+	records := make([]checkRecord, 0, len(report.Checks))
+
+	for _, check := range report.Checks {
+		record := checkRecord{
+			Name:     check.Name,
+			Found:    check.Found,
+			Repaired: check.Repaired,
+			Samples:  make([]issueRecord, 0, len(check.Samples)),
+		}
+
+		for _, issue := range check.Samples {
+			record.Samples = append(record.Samples, issueRecord{
+				Key:      issue.Key,
+				Field:    issue.Field,
+				Stored:   issue.Stored,
+				Expected: issue.Expected,
+			})
+		}
+
+		records = append(records, record)
+	}
+
+	checks, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+
+	return sqltools.RunTxOnConn(ctx, pkg.TxInsertOptions, c.conn.Primary(ctx), func(ctx context.Context, tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `INSERT INTO consistency_runs (started, finished, repair, checks)
+			VALUES ($1, $2, $3, $4);`, report.Started, report.Finished, report.Repair, string(checks))
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `DELETE FROM consistency_runs
+			WHERE run_id <= (SELECT max(run_id) - $1 FROM consistency_runs);`, keptRuns)
+
+		return err
+	})
+}
+
+// GetLastReport returns the report of the last run, or nil when the checker has not run yet.
+func (c consistencyPostgres) GetLastReport(ctx context.Context) (*models.ConsistencyReport, error) {
+	res := &models.ConsistencyReport{}
+
+	var started, finished sql.NullTime
+
+	var checks []byte
+
+	err := c.conn.Replica(ctx).QueryRowContext(ctx, `SELECT started, finished, repair, checks
+		FROM consistency_runs
+		ORDER BY run_id DESC
+		LIMIT 1;`).Scan(&started, &finished, &res.Repair, &checks)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	res.Started = started.Time.Format(time.RFC3339Nano)
+	res.Finished = finished.Time.Format(time.RFC3339Nano)
+
+	records := make([]checkRecord, 0)
+
+	err = json.Unmarshal(checks, &records)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Checks = make([]models.ConsistencyCheck, 0, len(records))
+
+	for _, record := range records {
+		check := models.ConsistencyCheck{
+			Name:     record.Name,
+			Found:    record.Found,
+			Repaired: record.Repaired,
+			Samples:  make([]models.ConsistencyIssue, 0, len(record.Samples)),
+		}
+
+		for _, issue := range record.Samples {
+			check.Samples = append(check.Samples, models.ConsistencyIssue{
+				Key:      issue.Key,
+				Field:    issue.Field,
+				Stored:   issue.Stored,
+				Expected: issue.Expected,
+			})
+		}
+
+		res.Checks = append(res.Checks, check)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"project/internal/consistency/repository"
+	"project/internal/models"
+	"project/internal/pkg"
+	"project/internal/pkg/cache"
+)
+
+const (
+	// checkBatchSize bounds the discrepancies read at once, and so the rows locked by each repair.
+	checkBatchSize = 1000
+
+	// checkSamples is how many discrepancies of each check are kept in the report.
+	checkSamples = 20
+)
+
+// checkOrder runs the checks of the posts before those of the counters, which do not depend on them.
+var checkOrder = []string{
+	pkg.ConsistencyPostPaths,
+	pkg.ConsistencyThreadCounters,
+	pkg.ConsistencyForumCounters,
+	pkg.ConsistencyUserForums,
+}
+
+type ConsistencyService interface {
+	Check(ctx context.Context, repair bool) (*models.ConsistencyReport, error)
+	GetLastReport(ctx context.Context) (*models.ConsistencyReport, error)
+	Run(ctx context.Context) error
+}
+
+type consistencyService struct {
+	consistencyRepo repository.ConsistencyRepository
+	interval        time.Duration
+	repair          bool
+	caches          *cache.Set
+}
+
+// NewConsistencyService makes Run check every interval, repairing what it finds when repair is set. A zero
+// interval leaves the checks to the admin command. Repairs purge caches, which may hold the counters repaired.
+func NewConsistencyService(r repository.ConsistencyRepository, interval time.Duration, repair bool, caches *cache.Set) ConsistencyService {
+	return &consistencyService{
+		consistencyRepo: r,
+		interval:        interval,
+		repair:          repair,
+		caches:          caches,
+	}
+}
+
+// Check goes through the discrepancies of each check in batches, repairing each batch in a short transaction of its
+// own when repair is set, so that writers are never held up for long. The report is saved for the service status.
+func (c consistencyService) Check(ctx context.Context, repair bool) (*models.ConsistencyReport, error) {
+	res := &models.ConsistencyReport{
+		Started: time.Now().Format(time.RFC3339Nano),
+		Repair:  repair,
+		Checks:  make([]models.ConsistencyCheck, 0, len(checkOrder)),
+	}
+
+	var repaired int64
+
+	for _, name := range checkOrder {
+		check, err := c.check(ctx, name, repair)
+		if err != nil {
+			return nil, errors.Wrap(err, "Check")
+		}
+
+		res.Checks = append(res.Checks, *check)
+		repaired += check.Repaired
+	}
+
+	if repaired != 0 {
+		c.caches.Purge()
+	}
+
+	res.Finished = time.Now().Format(time.RFC3339Nano)
+
+	err := c.consistencyRepo.SaveReport(ctx, res)
+	if err != nil {
+		return nil, errors.Wrap(err, "Check")
+	}
+
+	return res, nil
+}
+
+func (c consistencyService) check(ctx context.Context, name string, repair bool) (*models.ConsistencyCheck, error) {
+	res := &models.ConsistencyCheck{
+		Name:    name,
+		Samples: make([]models.ConsistencyIssue, 0),
+	}
+
+	since := ""
+
+	for {
+		issues, err := c.consistencyRepo.Find(ctx, name, since, checkBatchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(issues) == 0 {
+			return res, nil
+		}
+
+		res.Found += int64(len(issues))
+
+		for _, issue := range issues {
+			if len(res.Samples) == checkSamples {
+				break
+			}
+
+			res.Samples = append(res.Samples, issue)
+		}
+
+		if repair {
+			repaired, err := c.consistencyRepo.Repair(ctx, name, keys(issues))
+			if err != nil {
+				return nil, err
+			}
+
+			res.Repaired += repaired
+		}
+
+		if len(issues) < checkBatchSize {
+			return res, nil
+		}
+
+		since = issues[len(issues)-1].Position
+	}
+}
+
+// keys lists the entities of the issues once each, as a check may report several fields of an entity.
+func keys(issues []models.ConsistencyIssue) []string {
+	res := make([]string, 0, len(issues))
+	seen := make(map[string]struct{}, len(issues))
+
+	for _, issue := range issues {
+		if _, ok := seen[issue.Key]; ok {
+			continue
+		}
+
+		seen[issue.Key] = struct{}{}
+		res = append(res, issue.Key)
+	}
+
+	return res
+}
+
+func (c consistencyService) GetLastReport(ctx context.Context) (*models.ConsistencyReport, error) {
+	res, err := c.consistencyRepo.GetLastReport(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetLastReport")
+	}
+
+	return res, nil
+}
+
+// Run checks every interval until ctx is done. Only one of the replicas of the server checks at a time, the others
+// skip their turn.
+func (c consistencyService) Run(ctx context.Context) error {
+	if c.interval <= 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		err := c.runOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			logrus.Error(errors.Wrap(err, "Run"))
+		}
+	}
+}
+
+func (c consistencyService) runOnce(ctx context.Context) error {
+	unlock, locked, err := c.consistencyRepo.TryLock(ctx)
+	if err != nil || !locked {
+		return err
+	}
+	defer unlock()
+
+	report, err := c.Check(ctx, c.repair)
+	if err != nil {
+		return err
+	}
+
+	for _, check := range report.Checks {
+		if check.Found > 0 {
+			logrus.Warnf("consistency: %s found %d, repaired %d", check.Name, check.Found, check.Repaired)
+		}
+	}
+
+	return nil
+}
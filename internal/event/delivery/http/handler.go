@@ -0,0 +1,86 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/mailru/easyjson"
+	"github.com/sirupsen/logrus"
+
+	"project/internal/event/delivery/models"
+	"project/internal/event/usecase"
+	"project/internal/pkg"
+)
+
+const heartbeatInterval = 15 * time.Second
+
+type EventHandler struct {
+	eventUsecase usecase.EventService
+}
+
+func (h *EventHandler) ThreadStreamHandler(w http.ResponseWriter, r *http.Request) {
+	request := models.NewThreadStreamRequest()
+
+	err := request.Bind(r)
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, pkg.ErrStreamUnsupported)
+		return
+	}
+
+	events, err := h.eventUsecase.SubscribeThread(r.Context(), request.GetThread(), request.LastEventID)
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", pkg.ContentTypeEventStream)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			data, err := easyjson.Marshal(models.NewThreadEventResponse(&event))
+			if err != nil {
+				logrus.Error(err)
+				return
+			}
+
+			_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Kind, data)
+			if err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			_, err = fmt.Fprint(w, ": ping\n\n")
+			if err != nil {
+				return
+			}
+		}
+
+		flusher.Flush()
+	}
+}
+
+func NewEventHandler(eventUsecase usecase.EventService, r *mux.Router) *EventHandler {
+	h := &EventHandler{eventUsecase: eventUsecase}
+	return h
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"github.com/mailru/easyjson"
+
+	"project/internal/models"
+	"project/internal/pkg"
+	threadModels "project/internal/thread/delivery/models"
+	userModels "project/internal/user/delivery/models"
+)
+
+//go:generate easyjson -disallow_unknown_fields -omit_empty gateway.go
+
+const (
+	GatewayActionSubscribe   = "subscribe"
+	GatewayActionUnsubscribe = "unsubscribe"
+
+	GatewayEventSubscribed   = "subscribed"
+	GatewayEventUnsubscribed = "unsubscribed"
+	GatewayEventDropped      = "dropped"
+	GatewayEventError        = "error"
+)
+
+//easyjson:json
+type GatewayRequest struct {
+	Action string   `json:"action"`
+	Topics []string `json:"topics"`
+}
+
+//easyjson:json
+type GatewayMessage struct {
+	Event  string              `json:"event"`
+	Topics []string            `json:"topics,omitempty"`
+	ID     int64               `json:"id,omitempty"`
+	Data   easyjson.RawMessage `json:"data,omitempty"`
+}
+
+//easyjson:json
+type GatewayErrorResponse struct {
+	Topic   string `json:"topic,omitempty"`
+	Message string `json:"message"`
+}
+
+//easyjson:json
+type GatewayDroppedResponse struct {
+	Count int64 `json:"count"`
+}
+
+// NewGatewayEventData returns the data of an event in the shape of the response model of the entity it is about.
+func NewGatewayEventData(event *models.Event) easyjson.Marshaler {
+	switch event.Kind {
+	case pkg.EventThreadCreated:
+		return threadModels.NewThreadGetDetailsResponse(&event.ThreadData)
+	case pkg.EventUserUpdated:
+		return userModels.NewProfileGetResponse(&event.User)
+	default:
+		return NewThreadEventResponse(event)
+	}
+}
+
+func NewGatewayEventMessage(event *models.Event, topics []string) (*GatewayMessage, error) {
+	data, err := easyjson.Marshal(NewGatewayEventData(event))
+	if err != nil {
+		return nil, err
+	}
+
+	return &GatewayMessage{
+		Event:  event.Kind,
+		Topics: topics,
+		ID:     event.ID,
+		Data:   data,
+	}, nil
+}
+
+func NewGatewayMessage(event string, topics []string, data easyjson.Marshaler) *GatewayMessage {
+	res := &GatewayMessage{
+		Event:  event,
+		Topics: topics,
+	}
+
+	if data != nil {
+		raw, err := easyjson.Marshal(data)
+		if err == nil {
+			res.Data = raw
+		}
+	}
+
+	return res
+}
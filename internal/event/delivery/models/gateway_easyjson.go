@@ -0,0 +1,423 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package models
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonAa2664a0DecodeProjectInternalEventDeliveryModels(in *jlexer.Lexer, out *GatewayRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "action":
+			out.Action = string(in.String())
+		case "topics":
+			if in.IsNull() {
+				in.Skip()
+				out.Topics = nil
+			} else {
+				in.Delim('[')
+				if out.Topics == nil {
+					if !in.IsDelim(']') {
+						out.Topics = make([]string, 0, 4)
+					} else {
+						out.Topics = []string{}
+					}
+				} else {
+					out.Topics = (out.Topics)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 string
+					v1 = string(in.String())
+					out.Topics = append(out.Topics, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonAa2664a0EncodeProjectInternalEventDeliveryModels(out *jwriter.Writer, in GatewayRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Action != "" {
+		const prefix string = ",\"action\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Action))
+	}
+	if len(in.Topics) != 0 {
+		const prefix string = ",\"topics\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v2, v3 := range in.Topics {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v3))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v GatewayRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonAa2664a0EncodeProjectInternalEventDeliveryModels(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v GatewayRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonAa2664a0EncodeProjectInternalEventDeliveryModels(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *GatewayRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonAa2664a0DecodeProjectInternalEventDeliveryModels(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *GatewayRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonAa2664a0DecodeProjectInternalEventDeliveryModels(l, v)
+}
+func easyjsonAa2664a0DecodeProjectInternalEventDeliveryModels1(in *jlexer.Lexer, out *GatewayMessage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "event":
+			out.Event = string(in.String())
+		case "topics":
+			if in.IsNull() {
+				in.Skip()
+				out.Topics = nil
+			} else {
+				in.Delim('[')
+				if out.Topics == nil {
+					if !in.IsDelim(']') {
+						out.Topics = make([]string, 0, 4)
+					} else {
+						out.Topics = []string{}
+					}
+				} else {
+					out.Topics = (out.Topics)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 string
+					v4 = string(in.String())
+					out.Topics = append(out.Topics, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "id":
+			out.ID = int64(in.Int64())
+		case "data":
+			(out.Data).UnmarshalEasyJSON(in)
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonAa2664a0EncodeProjectInternalEventDeliveryModels1(out *jwriter.Writer, in GatewayMessage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Event != "" {
+		const prefix string = ",\"event\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Event))
+	}
+	if len(in.Topics) != 0 {
+		const prefix string = ",\"topics\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v5, v6 := range in.Topics {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v6))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.ID != 0 {
+		const prefix string = ",\"id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.ID))
+	}
+	if (in.Data).IsDefined() {
+		const prefix string = ",\"data\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		(in.Data).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v GatewayMessage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonAa2664a0EncodeProjectInternalEventDeliveryModels1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v GatewayMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonAa2664a0EncodeProjectInternalEventDeliveryModels1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *GatewayMessage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonAa2664a0DecodeProjectInternalEventDeliveryModels1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *GatewayMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonAa2664a0DecodeProjectInternalEventDeliveryModels1(l, v)
+}
+func easyjsonAa2664a0DecodeProjectInternalEventDeliveryModels2(in *jlexer.Lexer, out *GatewayErrorResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "topic":
+			out.Topic = string(in.String())
+		case "message":
+			out.Message = string(in.String())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonAa2664a0EncodeProjectInternalEventDeliveryModels2(out *jwriter.Writer, in GatewayErrorResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Topic != "" {
+		const prefix string = ",\"topic\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Topic))
+	}
+	if in.Message != "" {
+		const prefix string = ",\"message\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Message))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v GatewayErrorResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonAa2664a0EncodeProjectInternalEventDeliveryModels2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v GatewayErrorResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonAa2664a0EncodeProjectInternalEventDeliveryModels2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *GatewayErrorResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonAa2664a0DecodeProjectInternalEventDeliveryModels2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *GatewayErrorResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonAa2664a0DecodeProjectInternalEventDeliveryModels2(l, v)
+}
+func easyjsonAa2664a0DecodeProjectInternalEventDeliveryModels3(in *jlexer.Lexer, out *GatewayDroppedResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "count":
+			out.Count = int64(in.Int64())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonAa2664a0EncodeProjectInternalEventDeliveryModels3(out *jwriter.Writer, in GatewayDroppedResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Count != 0 {
+		const prefix string = ",\"count\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.Count))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v GatewayDroppedResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonAa2664a0EncodeProjectInternalEventDeliveryModels3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v GatewayDroppedResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonAa2664a0EncodeProjectInternalEventDeliveryModels3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *GatewayDroppedResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonAa2664a0DecodeProjectInternalEventDeliveryModels3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *GatewayDroppedResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonAa2664a0DecodeProjectInternalEventDeliveryModels3(l, v)
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/mailru/easyjson"
+
+	"project/internal/models"
+	"project/internal/pkg"
+)
+
+//go:generate easyjson -disallow_unknown_fields -omit_empty threadstream.go
+
+type ThreadStreamRequest struct {
+	SlugOrID    string
+	LastEventID int64
+}
+
+func NewThreadStreamRequest() *ThreadStreamRequest {
+	return &ThreadStreamRequest{}
+}
+
+func (req *ThreadStreamRequest) Bind(r *http.Request) error {
+	vars := mux.Vars(r)
+
+	req.SlugOrID = vars["slug_or_id"]
+
+	// EventSource sends the header on reconnect, the query parameter is for clients that cannot set headers
+	param := r.Header.Get("Last-Event-ID")
+	if param == "" {
+		param = r.FormValue("lastEventId")
+	}
+
+	req.LastEventID = -1
+
+	if param != "" {
+		value, err := strconv.ParseInt(param, 10, 64)
+		if err != nil {
+			return pkg.ErrConvertQueryType
+		}
+
+		req.LastEventID = value
+	}
+
+	return nil
+}
+
+func (req *ThreadStreamRequest) GetThread() *models.Thread {
+	id, err := strconv.Atoi(req.SlugOrID)
+	if err == nil {
+		return &models.Thread{
+			ID: int64(id),
+		}
+	}
+
+	return &models.Thread{
+		Slug: req.SlugOrID,
+	}
+}
+
+//easyjson:json
+type ThreadEventPostResponse struct {
+	ID       int64  `json:"id"`
+	Parent   int64  `json:"parent"`
+	Author   string `json:"author"`
+	Message  string `json:"message"`
+	IsEdited bool   `json:"isEdited"`
+	Forum    string `json:"forum"`
+	Thread   int64  `json:"thread"`
+	Created  string `json:"created"`
+}
+
+//easyjson:json
+type ThreadEventVotesResponse struct {
+	ID    int64 `json:"id"`
+	Votes int64 `json:"votes"`
+}
+
+// NewThreadEventResponse returns the data of a stream message: the post for post events, the thread rating for votes.
+func NewThreadEventResponse(event *models.Event) easyjson.Marshaler {
+	if event.Kind == pkg.EventVoteChanged {
+		return &ThreadEventVotesResponse{
+			ID:    event.Thread,
+			Votes: event.Votes,
+		}
+	}
+
+	return &ThreadEventPostResponse{
+		ID:       event.Post.ID,
+		Parent:   event.Post.Parent,
+		Author:   event.Post.Author.Nickname,
+		Message:  event.Post.Message,
+		IsEdited: event.Post.IsEdited,
+		Forum:    event.Post.Forum,
+		Thread:   event.Post.Thread,
+		Created:  event.Post.Created,
+	}
+}
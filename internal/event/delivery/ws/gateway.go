@@ -0,0 +1,305 @@
+package ws
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	"github.com/mailru/easyjson"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"project/internal/event/delivery/models"
+	"project/internal/event/usecase"
+	coreModels "project/internal/models"
+	"project/internal/pkg"
+)
+
+const (
+	writeWait      = 10 * time.Second
+	pongWait       = 60 * time.Second
+	pingPeriod     = pongWait * 9 / 10
+	maxMessageSize = 4096
+
+	// queueSize is how many events a client may lag behind before new ones are dropped
+	queueSize = 256
+)
+
+type Gateway struct {
+	eventUsecase usecase.EventService
+	upgrader     websocket.Upgrader
+	origins      map[string]bool
+}
+
+// NewGateway accepts connections from pages of the same origin as the server and of origins, given as
+// scheme://host[:port], so that a foreign page cannot subscribe with the cookies of its visitor.
+func NewGateway(eventUsecase usecase.EventService, r *mux.Router, origins []string) *Gateway {
+	g := &Gateway{
+		eventUsecase: eventUsecase,
+		origins:      make(map[string]bool, len(origins)),
+	}
+
+	for _, origin := range origins {
+		origin = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(origin), "/"))
+		if origin != "" {
+			g.origins[origin] = true
+		}
+	}
+
+	g.upgrader = websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin:     g.checkOrigin,
+	}
+
+	return g
+}
+
+// checkOrigin lets through clients which send no Origin, as only browsers do, and those the gateway accepts.
+func (g *Gateway) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+
+	if strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+
+	return g.origins[strings.ToLower(u.Scheme+"://"+u.Host)]
+}
+
+func (g *Gateway) GatewayHandler(w http.ResponseWriter, r *http.Request) {
+	conn, err := g.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade has already replied to the client
+		logrus.Error(errors.Wrap(err, "GatewayHandler"))
+		return
+	}
+
+	c := newClient(conn)
+	defer g.eventUsecase.UnsubscribeAll(c)
+
+	go c.writeLoop()
+	defer c.close()
+
+	conn.SetReadLimit(maxMessageSize)
+	_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		request := &models.GatewayRequest{}
+
+		err = easyjson.Unmarshal(data, request)
+		if err != nil {
+			c.control(models.NewGatewayMessage(models.GatewayEventError, nil, &models.GatewayErrorResponse{
+				Message: pkg.ErrBadRequestParams.Error(),
+			}))
+
+			continue
+		}
+
+		switch request.Action {
+		case models.GatewayActionSubscribe:
+			topics := make([]string, 0, len(request.Topics))
+
+			for _, topic := range request.Topics {
+				key, err := g.eventUsecase.SubscribeTopic(r.Context(), c, topic)
+				if err != nil {
+					c.control(models.NewGatewayMessage(models.GatewayEventError, nil, &models.GatewayErrorResponse{
+						Topic:   topic,
+						Message: errors.Cause(err).Error(),
+					}))
+
+					continue
+				}
+
+				topics = append(topics, key)
+			}
+
+			c.control(models.NewGatewayMessage(models.GatewayEventSubscribed, topics, nil))
+		case models.GatewayActionUnsubscribe:
+			for _, topic := range request.Topics {
+				g.eventUsecase.UnsubscribeTopic(c, topic)
+			}
+
+			c.control(models.NewGatewayMessage(models.GatewayEventUnsubscribed, request.Topics, nil))
+		default:
+			c.control(models.NewGatewayMessage(models.GatewayEventError, nil, &models.GatewayErrorResponse{
+				Message: pkg.ErrBadRequestParams.Error(),
+			}))
+		}
+	}
+}
+
+// client is a gateway connection. Events wait in a bounded queue until the write loop sends them. Events which only
+// carry the latest state of something (votes, profiles, edited posts) replace the queued ones about the same thing,
+// the others are dropped once the queue is full and the client is told how many it missed.
+type client struct {
+	conn *websocket.Conn
+
+	mu       sync.Mutex
+	controls []*models.GatewayMessage
+	events   []*models.GatewayMessage
+	pending  map[string]int
+	dropped  int64
+	closed   bool
+	signal   chan struct{}
+	done     chan struct{}
+}
+
+func newClient(conn *websocket.Conn) *client {
+	return &client{
+		conn:    conn,
+		pending: make(map[string]int),
+		signal:  make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+}
+
+func coalesceKey(event *coreModels.Event) string {
+	switch event.Kind {
+	case pkg.EventVoteChanged:
+		return event.Kind + ":" + strconv.FormatInt(event.Thread, 10)
+	case pkg.EventPostUpdated:
+		return event.Kind + ":" + strconv.FormatInt(event.Entity, 10)
+	case pkg.EventUserUpdated:
+		return event.Kind + ":" + event.Nickname
+	default:
+		return ""
+	}
+}
+
+func (c *client) Deliver(event *coreModels.Event, topics []string) {
+	message, err := models.NewGatewayEventMessage(event, topics)
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+
+	key := coalesceKey(event)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return
+	}
+
+	if i, ok := c.pending[key]; ok && key != "" {
+		c.events[i] = message
+		return
+	}
+
+	if len(c.events) >= queueSize {
+		c.dropped++
+		return
+	}
+
+	if key != "" {
+		c.pending[key] = len(c.events)
+	}
+
+	c.events = append(c.events, message)
+	c.wakeUp()
+}
+
+// control queues a reply to the client; replies are never dropped.
+func (c *client) control(message *models.GatewayMessage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.controls = append(c.controls, message)
+	c.wakeUp()
+}
+
+func (c *client) wakeUp() {
+	select {
+	case c.signal <- struct{}{}:
+	default:
+	}
+}
+
+func (c *client) take() []*models.GatewayMessage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	res := c.controls
+
+	if c.dropped > 0 {
+		res = append(res, models.NewGatewayMessage(models.GatewayEventDropped, nil, &models.GatewayDroppedResponse{
+			Count: c.dropped,
+		}))
+	}
+
+	res = append(res, c.events...)
+
+	c.controls = nil
+	c.events = nil
+	c.pending = make(map[string]int)
+	c.dropped = 0
+
+	return res
+}
+
+func (c *client) close() {
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+
+	close(c.done)
+}
+
+func (c *client) writeLoop() {
+	ticker := time.NewTicker(pingPeriod)
+
+	defer func() {
+		ticker.Stop()
+		_ = c.conn.Close()
+	}()
+
+	for {
+		select {
+		case <-c.done:
+			_ = c.conn.WriteControl(websocket.CloseMessage, []byte{}, time.Now().Add(writeWait))
+			return
+		case <-ticker.C:
+			err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait))
+			if err != nil {
+				return
+			}
+		case <-c.signal:
+			for _, message := range c.take() {
+				data, err := easyjson.Marshal(message)
+				if err != nil {
+					logrus.Error(err)
+					continue
+				}
+
+				_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+
+				err = c.conn.WriteMessage(websocket.TextMessage, data)
+				if err != nil {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package ws
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCheckOrigin(t *testing.T) {
+	g := NewGateway(nil, nil, []string{" https://App.example.com/ ", ""})
+
+	tests := []struct {
+		name   string
+		origin string
+		want   bool
+	}{
+		{name: "no origin", origin: "", want: true},
+		{name: "same origin", origin: "http://forum.example.com", want: true},
+		{name: "same origin other case", origin: "http://FORUM.example.com", want: true},
+		{name: "allowed origin", origin: "https://app.example.com", want: true},
+		{name: "allowed host other scheme", origin: "http://app.example.com", want: false},
+		{name: "foreign origin", origin: "https://evil.example.com", want: false},
+		{name: "malformed", origin: "://", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "http://forum.example.com/api/ws", nil)
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+
+			if got := g.checkOrigin(r); got != tt.want {
+				t.Errorf("checkOrigin(%q) = %v, want %v", tt.origin, got, tt.want)
+			}
+		})
+	}
+}
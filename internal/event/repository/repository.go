@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"project/internal/models"
+	"project/internal/pkg"
+	"project/internal/pkg/sqltools"
+)
+
+type EventRepository interface {
+	Listen(ctx context.Context, events chan<- models.Event) error
+	GetThreadEvents(ctx context.Context, thread *models.Thread, since int64, limit int64) ([]models.Event, error)
+	GetLastEventID(ctx context.Context, thread *models.Thread) (int64, error)
+	GetEventsHorizon(ctx context.Context, thread *models.Thread) (int64, error)
+	DeleteEventsBefore(ctx context.Context, before time.Time) error
+}
+
+type eventPostgres struct {
+	conn *sql.DB
+	dsn  string
+}
+
+// NewEventPostgres needs the connection string besides the pool: LISTEN holds a dedicated connection.
+func NewEventPostgres(conn *sql.DB, dsn string) EventRepository {
+	return &eventPostgres{
+		conn,
+		dsn,
+	}
+}
+
+type notification struct {
+	Kind     string `json:"kind"`
+	Thread   int64  `json:"thread"`
+	Seq      int64  `json:"seq"`
+	Entity   int64  `json:"entity"`
+	Forum    string `json:"forum"`
+	Nickname string `json:"nickname"`
+}
+
+// Listen forwards notifications of the events channel until ctx is done. Notifications carry the routing data
+// only, the content of an event is read separately. After a reconnect an event without a kind is sent,
+// since notifications could be lost meanwhile.
+func (e eventPostgres) Listen(ctx context.Context, events chan<- models.Event) error {
+	listener := pq.NewListener(e.dsn, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			logrus.Error(err)
+		}
+	})
+	defer listener.Close()
+
+	err := listener.Listen(pkg.EventsChannel)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case n := <-listener.Notify:
+			event := models.Event{}
+
+			if n != nil {
+				value := notification{}
+
+				err = json.Unmarshal([]byte(n.Extra), &value)
+				if err != nil {
+					logrus.Error(err)
+					continue
+				}
+
+				event.ID = value.Seq
+				event.Kind = value.Kind
+				event.Thread = value.Thread
+				event.Entity = value.Entity
+				event.Forum = value.Forum
+				event.Nickname = value.Nickname
+			}
+
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return nil
+			}
+		case <-time.After(time.Minute):
+			go func() {
+				err := listener.Ping()
+				if err != nil {
+					logrus.Error(err)
+				}
+			}()
+		}
+	}
+}
+
+func (e eventPostgres) GetThreadEvents(ctx context.Context, thread *models.Thread, since int64, limit int64) ([]models.Event, error) {
+	rows, err := e.conn.QueryContext(ctx, `SELECT e.seq, e.kind, e.entity_id, e.created,
+			COALESCE(p.parent, 0), COALESCE(p.author, ''), COALESCE(p.message, ''), COALESCE(p.is_edited, false),
+			COALESCE(p.forum, ''), COALESCE(p.created, e.created),
+			COALESCE(t.votes, 0)
+		FROM events e
+			LEFT JOIN posts p ON e.kind IN ($4, $5) AND p.post_id = e.entity_id
+			LEFT JOIN threads t ON e.kind = $6 AND t.thread_id = e.thread_id
+		WHERE e.thread_id = $1
+		  AND e.seq > $2
+		  AND e.kind IN ($4, $5, $6)
+		ORDER BY e.seq
+		LIMIT $3;`, thread.ID, since, limit, pkg.EventPostCreated, pkg.EventPostUpdated, pkg.EventVoteChanged)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]models.Event, 0)
+
+	for rows.Next() {
+		event := models.Event{}
+
+		eventTime := time.Time{}
+		postTime := time.Time{}
+
+		err = rows.Scan(
+			&event.ID,
+			&event.Kind,
+			&event.Entity,
+			&eventTime,
+			&event.Post.Parent,
+			&event.Post.Author.Nickname,
+			&event.Post.Message,
+			&event.Post.IsEdited,
+			&event.Post.Forum,
+			&postTime,
+			&event.Votes)
+		if err != nil {
+			return nil, err
+		}
+
+		event.Thread = thread.ID
+		event.Created = eventTime.Format(time.RFC3339)
+
+		if event.Kind != pkg.EventVoteChanged {
+			event.Post.ID = event.Entity
+			event.Post.Thread = thread.ID
+			event.Post.Created = postTime.Format(time.RFC3339)
+		}
+
+		res = append(res, event)
+	}
+
+	return res, nil
+}
+
+// GetLastEventID returns the number of the latest event of the thread, everything after it is yet to come.
+func (e eventPostgres) GetLastEventID(ctx context.Context, thread *models.Thread) (int64, error) {
+	var res int64
+
+	row := e.conn.QueryRowContext(ctx, `SELECT last_event FROM threads WHERE thread_id = $1;`, thread.ID)
+	if row.Err() != nil {
+		return 0, row.Err()
+	}
+
+	err := row.Scan(&res)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, pkg.ErrSuchThreadNotFound
+		}
+
+		return 0, err
+	}
+
+	return res, nil
+}
+
+// GetEventsHorizon returns the highest event number of the thread deleted by retention.
+func (e eventPostgres) GetEventsHorizon(ctx context.Context, thread *models.Thread) (int64, error) {
+	var res int64
+
+	row := e.conn.QueryRowContext(ctx, `SELECT COALESCE(max(seq), 0) FROM events_horizon WHERE thread_id = $1;`, thread.ID)
+	if row.Err() != nil {
+		return 0, row.Err()
+	}
+
+	err := row.Scan(&res)
+	if err != nil {
+		return 0, err
+	}
+
+	return res, nil
+}
+
+// DeleteEventsBefore deletes events older than before and moves the horizons of their threads past them.
+func (e eventPostgres) DeleteEventsBefore(ctx context.Context, before time.Time) error {
+	err := sqltools.RunTxOnConn(ctx, pkg.TxInsertOptions, e.conn, func(ctx context.Context, tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `WITH deleted AS (DELETE FROM events WHERE created < $1 RETURNING thread_id, seq)
+			INSERT INTO events_horizon (thread_id, seq)
+			SELECT thread_id, max(seq) FROM deleted GROUP BY thread_id
+			ON CONFLICT (thread_id) DO UPDATE SET seq = GREATEST(events_horizon.seq, EXCLUDED.seq);`, before)
+
+		return err
+	})
+
+	return err
+}
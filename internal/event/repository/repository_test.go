@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	_ "github.com/jackc/pgx/stdlib"
+
+	"project/internal/models"
+)
+
+// envTestDSN names the database with db/db.sql loaded which the tests run against, they are skipped without it.
+const envTestDSN = "TEST_POSTGRES_DSN"
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	dsn := os.Getenv(envTestDSN)
+	if dsn == "" {
+		t.Skip(envTestDSN + " is not set")
+	}
+
+	conn, err := sql.Open("pgx", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	err = conn.Ping()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return conn
+}
+
+// newTestThread creates a thread with its author and forum, and voters, all named after the test run.
+func newTestThread(t *testing.T, conn *sql.DB, voters ...string) *models.Thread {
+	t.Helper()
+
+	suffix := fmt.Sprint(time.Now().UnixNano())
+	author := "author" + suffix
+
+	for _, nickname := range append([]string{author}, voters...) {
+		_, err := conn.Exec(`INSERT INTO users (nickname, fullname, email) VALUES ($1, $1, $1 || '@test');`, nickname)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, err := conn.Exec(`INSERT INTO forums (users_nickname, slug, title) VALUES ($1, $2, $2);`, author, "forum"+suffix)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	thread := &models.Thread{}
+
+	err = conn.QueryRow(`INSERT INTO threads (author, forum, title, message) VALUES ($1, $2, 'title', 'message')
+		RETURNING thread_id;`, author, "forum"+suffix).Scan(&thread.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return thread
+}
+
+func seqs(t *testing.T, repo EventRepository, thread *models.Thread, since int64) []int64 {
+	t.Helper()
+
+	events, err := repo.GetThreadEvents(context.Background(), thread, since, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := make([]int64, 0, len(events))
+	for _, event := range events {
+		res = append(res, event.ID)
+	}
+
+	return res
+}
+
+// TestEventsCommitOrder has two voters of a thread in transactions which emit in one order and would commit in the
+// other. The one emitting second waits for the first to commit, so a reader resuming from what it has seen never
+// misses an event.
+func TestEventsCommitOrder(t *testing.T) {
+	conn := openTestDB(t)
+	repo := NewEventPostgres(conn, "")
+	ctx := context.Background()
+
+	suffix := fmt.Sprint(time.Now().UnixNano())
+	first, second := "first"+suffix, "second"+suffix
+
+	thread := newTestThread(t, conn, first, second)
+
+	last, err := repo.GetLastEventID(ctx, thread)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	txFirst, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = txFirst.Rollback()
+	}()
+
+	_, err = txFirst.Exec(`INSERT INTO user_votes (nickname, thread_id, voice) VALUES ($1, $2, 1);`, first, thread.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	txSecond, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = txSecond.Rollback()
+	}()
+
+	voted := make(chan error, 1)
+
+	go func() {
+		_, err := txSecond.Exec(`INSERT INTO user_votes (nickname, thread_id, voice) VALUES ($1, $2, -1);`, second, thread.ID)
+		voted <- err
+	}()
+
+	select {
+	case err = <-voted:
+		t.Fatalf("second vote did not wait for the first to commit, err %v", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	if got := seqs(t, repo, thread, last); len(got) != 0 {
+		t.Fatalf("events %v visible before any commit", got)
+	}
+
+	err = txFirst.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = <-voted
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := seqs(t, repo, thread, last)
+	if len(got) != 1 || got[0] != last+1 {
+		t.Fatalf("events after the first commit = %v, want [%d]", got, last+1)
+	}
+
+	err = txSecond.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A reader which saw the first event resumes from it and gets the second
+	got = seqs(t, repo, thread, last+1)
+	if len(got) != 1 || got[0] != last+2 {
+		t.Fatalf("events after resuming = %v, want [%d]", got, last+2)
+	}
+}
@@ -0,0 +1,455 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	repoEvent "project/internal/event/repository"
+	repoForum "project/internal/forum/repository"
+	"project/internal/models"
+	"project/internal/pkg"
+	repoThread "project/internal/thread/repository"
+	repoUser "project/internal/user/repository"
+)
+
+const (
+	eventsBatchSize = 100
+	eventsRetention = 24 * time.Hour
+)
+
+// Subscriber receives events of the topics it subscribed to. Deliver is called from the dispatching goroutine
+// and must not block: slow subscribers are expected to queue, coalesce or drop events on their own.
+type Subscriber interface {
+	Deliver(event *models.Event, topics []string)
+}
+
+type EventService interface {
+	Run(ctx context.Context) error
+	SubscribeThread(ctx context.Context, thread *models.Thread, lastEventID int64) (<-chan models.Event, error)
+	SubscribeTopic(ctx context.Context, subscriber Subscriber, topic string) (string, error)
+	UnsubscribeTopic(subscriber Subscriber, topic string)
+	UnsubscribeAll(subscriber Subscriber)
+	LoadEvent(ctx context.Context, event *models.Event) error
+}
+
+type eventService struct {
+	eventRepo  repoEvent.EventRepository
+	threadRepo repoThread.ThreadRepository
+	forumRepo  repoForum.ForumRepository
+	userRepo   repoUser.UserRepository
+
+	mu          sync.Mutex
+	subscribers map[int64]map[chan struct{}]struct{}
+	topics      map[string]map[Subscriber]struct{}
+}
+
+func NewEventService(re repoEvent.EventRepository, rt repoThread.ThreadRepository, rf repoForum.ForumRepository, ru repoUser.UserRepository) EventService {
+	return &eventService{
+		eventRepo:   re,
+		threadRepo:  rt,
+		forumRepo:   rf,
+		userRepo:    ru,
+		subscribers: make(map[int64]map[chan struct{}]struct{}),
+		topics:      make(map[string]map[Subscriber]struct{}),
+	}
+}
+
+// Run listens for database notifications and dispatches them to thread streams and topic subscribers until
+// ctx is done.
+func (e *eventService) Run(ctx context.Context) error {
+	notifications := make(chan models.Event, eventsBatchSize)
+	published := make(chan models.Event, eventsBatchSize*10)
+
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				err := e.eventRepo.DeleteEventsBefore(ctx, time.Now().Add(-eventsRetention))
+				if err != nil {
+					logrus.Error(err)
+				}
+			}
+		}
+	}()
+
+	// Topic events need their content read from the database, which must not delay thread streams
+	go func() {
+		for event := range published {
+			e.publish(ctx, &event)
+		}
+	}()
+
+	go func() {
+		defer close(published)
+
+		for event := range notifications {
+			if event.Kind == "" || event.Thread != 0 {
+				e.wakeUp(event.Thread)
+			}
+
+			if event.Kind == "" {
+				continue
+			}
+
+			select {
+			case published <- event:
+			default:
+				logrus.Warn("event dropped: topic dispatching is behind")
+			}
+		}
+	}()
+
+	err := e.eventRepo.Listen(ctx, notifications)
+	close(notifications)
+	if err != nil {
+		return errors.Wrap(err, "Run")
+	}
+
+	return nil
+}
+
+// wakeUp signals subscribers of the thread, or of every thread if it is zero. Signals coalesce, so a slow
+// subscriber is never blocked on, it just reads several events at once.
+func (e *eventService) wakeUp(thread int64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if thread != 0 {
+		notify(e.subscribers[thread])
+		return
+	}
+
+	for _, subscribers := range e.subscribers {
+		notify(subscribers)
+	}
+}
+
+func notify(subscribers map[chan struct{}]struct{}) {
+	for signal := range subscribers {
+		select {
+		case signal <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (e *eventService) subscribe(thread int64) chan struct{} {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	signal := make(chan struct{}, 1)
+
+	if e.subscribers[thread] == nil {
+		e.subscribers[thread] = make(map[chan struct{}]struct{})
+	}
+
+	e.subscribers[thread][signal] = struct{}{}
+
+	return signal
+}
+
+func (e *eventService) unsubscribe(thread int64, signal chan struct{}) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	delete(e.subscribers[thread], signal)
+
+	if len(e.subscribers[thread]) == 0 {
+		delete(e.subscribers, thread)
+	}
+}
+
+// SubscribeThread streams events of the thread following lastEventID until ctx is done. A negative lastEventID
+// starts from the current moment. The backlog is read before live events, and both come from the events table,
+// so a resumed stream has no gaps. Resuming from behind the retention horizon fails with ErrEventsExpired
+// rather than skipping what was deleted, the client has to reload the thread.
+func (e *eventService) SubscribeThread(ctx context.Context, thread *models.Thread, lastEventID int64) (<-chan models.Event, error) {
+	resThread, err := e.getReadableThread(ctx, thread)
+	if err != nil {
+		return nil, errors.Wrap(err, "SubscribeThread")
+	}
+
+	if lastEventID >= 0 {
+		horizon, err := e.eventRepo.GetEventsHorizon(ctx, &resThread)
+		if err != nil {
+			return nil, errors.Wrap(err, "SubscribeThread")
+		}
+
+		if lastEventID < horizon {
+			return nil, errors.Wrap(pkg.ErrEventsExpired, "SubscribeThread")
+		}
+	}
+
+	signal := e.subscribe(resThread.ID)
+
+	if lastEventID < 0 {
+		lastEventID, err = e.eventRepo.GetLastEventID(ctx, &resThread)
+		if err != nil {
+			e.unsubscribe(resThread.ID, signal)
+
+			return nil, errors.Wrap(err, "SubscribeThread")
+		}
+	}
+
+	res := make(chan models.Event)
+
+	go func() {
+		defer close(res)
+		defer e.unsubscribe(resThread.ID, signal)
+
+		last := lastEventID
+
+		for {
+			events, err := e.eventRepo.GetThreadEvents(ctx, &resThread, last, eventsBatchSize)
+			if err != nil {
+				if ctx.Err() == nil {
+					logrus.Error(err)
+				}
+
+				return
+			}
+
+			for _, event := range events {
+				select {
+				case res <- event:
+					last = event.ID
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			if len(events) == eventsBatchSize {
+				continue
+			}
+
+			select {
+			case <-signal:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return res, nil
+}
+
+func (e *eventService) getReadableThread(ctx context.Context, thread *models.Thread) (models.Thread, error) {
+	var err error
+
+	var resThread models.Thread
+
+	// CheckAndGetThread
+	if thread.Slug != "" {
+		resThread, err = e.threadRepo.GetDetailsThreadBySlug(ctx, thread)
+	} else {
+		resThread, err = e.threadRepo.GetDetailsThreadByID(ctx, thread)
+	}
+	if err != nil {
+		return models.Thread{}, err
+	}
+
+	// CheckAccess, public forums being open to everybody
+	if resThread.Visibility == pkg.ForumVisibilityPublic {
+		return resThread, nil
+	}
+
+	access, err := e.forumRepo.GetAccessForum(ctx, &models.Forum{Slug: resThread.Forum}, pkg.GetNickname(ctx))
+	if err != nil {
+		return models.Thread{}, err
+	}
+
+	if !pkg.CanReadForum(access) {
+		return models.Thread{}, pkg.ErrSuchThreadNotFound
+	}
+
+	return resThread, nil
+}
+
+func topicKey(kind string, name string) string {
+	return kind + ":" + strings.ToLower(name)
+}
+
+// SubscribeTopic subscribes to forum:{slug}, thread:{id} or user:{nickname} and returns the topic in its
+// canonical form. Access to private forums is checked once, at subscription.
+func (e *eventService) SubscribeTopic(ctx context.Context, subscriber Subscriber, topic string) (string, error) {
+	kind, name, found := strings.Cut(topic, ":")
+	if !found || name == "" {
+		return "", errors.Wrap(pkg.ErrSuchTopicNotFound, "SubscribeTopic")
+	}
+
+	switch kind {
+	case pkg.TopicForum:
+		access, err := e.forumRepo.GetAccessForum(ctx, &models.Forum{Slug: name}, pkg.GetNickname(ctx))
+		if err != nil {
+			return "", errors.Wrap(err, "SubscribeTopic")
+		}
+
+		if !pkg.CanReadForum(access) {
+			return "", errors.Wrap(pkg.ErrSuchForumNotFound, "SubscribeTopic")
+		}
+	case pkg.TopicThread:
+		id, err := strconv.ParseInt(name, 10, 64)
+		if err != nil {
+			return "", errors.Wrap(pkg.ErrSuchTopicNotFound, "SubscribeTopic")
+		}
+
+		_, err = e.getReadableThread(ctx, &models.Thread{ID: id})
+		if err != nil {
+			return "", errors.Wrap(err, "SubscribeTopic")
+		}
+	case pkg.TopicUser:
+		_, err := e.userRepo.GetUserByNickname(ctx, &models.User{Nickname: name})
+		if err != nil {
+			return "", errors.Wrap(err, "SubscribeTopic")
+		}
+	default:
+		return "", errors.Wrap(pkg.ErrSuchTopicNotFound, "SubscribeTopic")
+	}
+
+	key := topicKey(kind, name)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.topics[key] == nil {
+		e.topics[key] = make(map[Subscriber]struct{})
+	}
+
+	e.topics[key][subscriber] = struct{}{}
+
+	return key, nil
+}
+
+func (e *eventService) UnsubscribeTopic(subscriber Subscriber, topic string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	kind, name, _ := strings.Cut(topic, ":")
+
+	key := topicKey(kind, name)
+
+	delete(e.topics[key], subscriber)
+
+	if len(e.topics[key]) == 0 {
+		delete(e.topics, key)
+	}
+}
+
+func (e *eventService) UnsubscribeAll(subscriber Subscriber) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for key, subscribers := range e.topics {
+		delete(subscribers, subscriber)
+
+		if len(subscribers) == 0 {
+			delete(e.topics, key)
+		}
+	}
+}
+
+// recipients groups the subscribers of the event topics, listing for each one the topics it matched.
+func (e *eventService) recipients(event *models.Event, public bool) map[Subscriber][]string {
+	keys := make([]string, 0, 3)
+
+	if event.Forum != "" {
+		keys = append(keys, topicKey(pkg.TopicForum, event.Forum))
+	}
+
+	if event.Thread != 0 {
+		keys = append(keys, topicKey(pkg.TopicThread, strconv.FormatInt(event.Thread, 10)))
+	}
+
+	// Subscribers of a user were not checked against forums, so they get public activity only
+	if event.Nickname != "" && public {
+		keys = append(keys, topicKey(pkg.TopicUser, event.Nickname))
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	res := make(map[Subscriber][]string)
+
+	for _, key := range keys {
+		for subscriber := range e.topics[key] {
+			res[subscriber] = append(res[subscriber], key)
+		}
+	}
+
+	return res
+}
+
+func (e *eventService) publish(ctx context.Context, event *models.Event) {
+	public := true
+
+	if event.Forum != "" {
+		access, err := e.forumRepo.GetAccessForum(ctx, &models.Forum{Slug: event.Forum}, "")
+		if err != nil {
+			logrus.Error(err)
+			return
+		}
+
+		public = pkg.CanReadForum(access)
+	}
+
+	recipients := e.recipients(event, public)
+	if len(recipients) == 0 {
+		return
+	}
+
+	err := e.LoadEvent(ctx, event)
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+
+	for subscriber, topics := range recipients {
+		subscriber.Deliver(event, topics)
+	}
+}
+
+// LoadEvent reads what the event is about: the post, the thread or the user profile.
+func (e *eventService) LoadEvent(ctx context.Context, event *models.Event) error {
+	switch event.Kind {
+	case pkg.EventPostCreated, pkg.EventPostUpdated:
+		events, err := e.eventRepo.GetThreadEvents(ctx, &models.Thread{ID: event.Thread}, event.ID-1, 1)
+		if err != nil {
+			return err
+		}
+
+		if len(events) == 0 {
+			return pkg.ErrSuchPostNotFound
+		}
+
+		event.Post = events[0].Post
+		event.Created = events[0].Created
+	case pkg.EventThreadCreated, pkg.EventVoteChanged:
+		thread, err := e.threadRepo.GetDetailsThreadByID(ctx, &models.Thread{ID: event.Thread})
+		if err != nil {
+			return err
+		}
+
+		event.ThreadData = thread
+		event.Votes = thread.Votes
+	case pkg.EventUserUpdated:
+		user, err := e.userRepo.GetUserByNickname(ctx, &models.User{Nickname: event.Nickname})
+		if err != nil {
+			return err
+		}
+
+		event.User = user
+	}
+
+	return nil
+}
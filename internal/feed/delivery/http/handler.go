@@ -0,0 +1,87 @@
+package http
+
+import (
+	"encoding/xml"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"project/internal/feed/delivery/models"
+	"project/internal/feed/usecase"
+	coreModels "project/internal/models"
+	"project/internal/pkg"
+)
+
+type FeedHandler struct {
+	feedUsecase usecase.FeedService
+}
+
+// writeFeed answers 304 to clients whose copy is fresh, so that polling readers cost a single cheap query.
+func writeFeed(w http.ResponseWriter, r *http.Request, feed *coreModels.Feed, contentType string, body interface{}) {
+	pkg.SetValidators(w, feed.ETag, feed.Updated)
+
+	if feed.NotModified {
+		pkg.NoBody(w, http.StatusNotModified)
+		return
+	}
+
+	out, err := xml.Marshal(body)
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType+"; charset=utf-8")
+
+	w.WriteHeader(http.StatusOK)
+
+	_, _ = w.Write([]byte(xml.Header))
+	_, _ = w.Write(out)
+}
+
+func (h *FeedHandler) ForumFeedHandler(w http.ResponseWriter, r *http.Request) {
+	request := models.NewFeedRequest()
+
+	request.Bind(r)
+
+	feed, err := h.feedUsecase.GetForumFeed(r.Context(), request.GetForum(), request.Conditional)
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	writeFeed(w, r, feed, pkg.ContentTypeAtom, models.NewAtomFeed(feed, request.BaseURL, r.URL.Path))
+}
+
+func (h *FeedHandler) ThreadFeedHandler(w http.ResponseWriter, r *http.Request) {
+	request := models.NewFeedRequest()
+
+	request.Bind(r)
+
+	feed, err := h.feedUsecase.GetThreadFeed(r.Context(), request.GetThread(), request.Conditional)
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	writeFeed(w, r, feed, pkg.ContentTypeRSS, models.NewRSSFeed(feed, request.BaseURL, r.URL.Path))
+}
+
+func (h *FeedHandler) UserFeedHandler(w http.ResponseWriter, r *http.Request) {
+	request := models.NewFeedRequest()
+
+	request.Bind(r)
+
+	feed, err := h.feedUsecase.GetUserFeed(r.Context(), request.GetUser(), request.Conditional)
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	writeFeed(w, r, feed, pkg.ContentTypeAtom, models.NewAtomFeed(feed, request.BaseURL, r.URL.Path))
+}
+
+func NewFeedHandler(feedUsecase usecase.FeedService, r *mux.Router) *FeedHandler {
+	h := &FeedHandler{feedUsecase: feedUsecase}
+	return h
+}
@@ -0,0 +1,193 @@
+package models
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"project/internal/models"
+	"project/internal/pkg"
+)
+
+type FeedRequest struct {
+	Slug        string
+	SlugOrID    string
+	Nickname    string
+	BaseURL     string
+	Conditional *pkg.ConditionalParams
+}
+
+func NewFeedRequest() *FeedRequest {
+	return &FeedRequest{}
+}
+
+func (req *FeedRequest) Bind(r *http.Request) error {
+	vars := mux.Vars(r)
+
+	req.Slug = vars["slug"]
+	req.SlugOrID = vars["slug_or_id"]
+	req.Nickname = vars["nickname"]
+
+	// Feed readers need absolute links
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+
+	req.BaseURL = scheme + "://" + r.Host
+
+	req.Conditional = pkg.NewConditionalParams(r)
+
+	return nil
+}
+
+func (req *FeedRequest) GetForum() *models.Forum {
+	return &models.Forum{
+		Slug: req.Slug,
+	}
+}
+
+func (req *FeedRequest) GetThread() *models.Thread {
+	id, err := strconv.Atoi(req.SlugOrID)
+	if err == nil {
+		return &models.Thread{
+			ID: int64(id),
+		}
+	}
+
+	return &models.Thread{
+		Slug: req.SlugOrID,
+	}
+}
+
+func (req *FeedRequest) GetUser() *models.User {
+	return &models.User{
+		Nickname: req.Nickname,
+	}
+}
+
+type AtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type AtomPerson struct {
+	Name string `xml:"name"`
+}
+
+type AtomContent struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+type AtomEntry struct {
+	ID        string      `xml:"id"`
+	Title     string      `xml:"title"`
+	Updated   string      `xml:"updated"`
+	Published string      `xml:"published"`
+	Link      AtomLink    `xml:"link"`
+	Author    AtomPerson  `xml:"author"`
+	Content   AtomContent `xml:"content"`
+}
+
+type AtomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Links   []AtomLink  `xml:"link"`
+	Author  AtomPerson  `xml:"author"`
+	Entries []AtomEntry `xml:"entry"`
+}
+
+func NewAtomFeed(feed *models.Feed, baseURL string, self string) *AtomFeed {
+	res := &AtomFeed{
+		ID:      feed.ID,
+		Title:   feed.Title,
+		Updated: feed.Updated.UTC().Format(time.RFC3339),
+		Links: []AtomLink{
+			{Href: baseURL + feed.Link, Rel: "alternate", Type: pkg.ContentTypeJSON},
+			{Href: baseURL + self, Rel: "self", Type: pkg.ContentTypeAtom},
+		},
+		Author:  AtomPerson{Name: feed.Author},
+		Entries: make([]AtomEntry, len(feed.Entries)),
+	}
+
+	for idx, entry := range feed.Entries {
+		res.Entries[idx] = AtomEntry{
+			ID:        entry.ID,
+			Title:     entry.Title,
+			Updated:   entry.Updated.UTC().Format(time.RFC3339),
+			Published: entry.Published.UTC().Format(time.RFC3339),
+			Link:      AtomLink{Href: baseURL + entry.Link, Rel: "alternate", Type: pkg.ContentTypeJSON},
+			Author:    AtomPerson{Name: entry.Author},
+			Content:   AtomContent{Type: "text", Body: entry.Content},
+		}
+	}
+
+	return res
+}
+
+type RSSGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type RSSItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	GUID        RSSGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+	Creator     string  `xml:"dc:creator"`
+	Description string  `xml:"description"`
+}
+
+type RSSChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Self          AtomLink  `xml:"atom:link"`
+	Items         []RSSItem `xml:"item"`
+}
+
+type RSSFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Atom    string     `xml:"xmlns:atom,attr"`
+	DC      string     `xml:"xmlns:dc,attr"`
+	Channel RSSChannel `xml:"channel"`
+}
+
+func NewRSSFeed(feed *models.Feed, baseURL string, self string) *RSSFeed {
+	res := &RSSFeed{
+		Version: "2.0",
+		Atom:    "http://www.w3.org/2005/Atom",
+		DC:      "http://purl.org/dc/elements/1.1/",
+		Channel: RSSChannel{
+			Title:         feed.Title,
+			Link:          baseURL + feed.Link,
+			Description:   feed.Title,
+			LastBuildDate: feed.Updated.UTC().Format(time.RFC1123Z),
+			Self:          AtomLink{Href: baseURL + self, Rel: "self", Type: pkg.ContentTypeRSS},
+			Items:         make([]RSSItem, len(feed.Entries)),
+		},
+	}
+
+	for idx, entry := range feed.Entries {
+		res.Channel.Items[idx] = RSSItem{
+			Title:       entry.Title,
+			Link:        baseURL + entry.Link,
+			GUID:        RSSGUID{Value: entry.ID},
+			PubDate:     entry.Published.UTC().Format(time.RFC1123Z),
+			Creator:     entry.Author,
+			Description: entry.Content,
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"project/internal/models"
+)
+
+type FeedRepository interface {
+	GetForumFeedState(ctx context.Context, forum *models.Forum) (*models.FeedState, error)
+	GetThreadFeedState(ctx context.Context, thread *models.Thread) (*models.FeedState, error)
+	GetUserFeedState(ctx context.Context, user *models.User) (*models.FeedState, error)
+}
+
+type feedPostgres struct {
+	conn *sql.DB
+}
+
+func NewFeedPostgres(conn *sql.DB) FeedRepository {
+	return &feedPostgres{
+		conn,
+	}
+}
+
+func (f feedPostgres) getState(ctx context.Context, query string, args ...interface{}) (*models.FeedState, error) {
+	var count int64
+
+	var updated time.Time
+
+	row := f.conn.QueryRowContext(ctx, query, args...)
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	err := row.Scan(&count, &updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.FeedState{
+		Version: fmt.Sprintf("%d-%d", count, updated.UnixNano()),
+		Updated: updated,
+	}, nil
+}
+
+// GetForumFeedState changes with every new thread of the forum.
+func (f feedPostgres) GetForumFeedState(ctx context.Context, forum *models.Forum) (*models.FeedState, error) {
+	return f.getState(ctx, `SELECT count(*), COALESCE(max(created), to_timestamp(0))
+		FROM threads
+		WHERE forum = $1;`, forum.Slug)
+}
+
+// GetThreadFeedState changes with every event of the thread, which covers new and edited posts. Events older
+// than their retention are gone, so the latest post is a fallback for the update time.
+func (f feedPostgres) GetThreadFeedState(ctx context.Context, thread *models.Thread) (*models.FeedState, error) {
+	return f.getState(ctx, `SELECT COALESCE(e.seq, 0),
+			GREATEST(t.created, (SELECT max(created) FROM posts WHERE thread_id = t.thread_id), e.created)
+		FROM threads t
+			LEFT JOIN LATERAL (SELECT seq, created FROM events WHERE thread_id = t.thread_id ORDER BY seq DESC LIMIT 1) e
+				ON true
+		WHERE t.thread_id = $1;`, thread.ID)
+}
+
+// GetUserFeedState changes with every new post of the user and every first edit of one.
+func (f feedPostgres) GetUserFeedState(ctx context.Context, user *models.User) (*models.FeedState, error) {
+	return f.getState(ctx, `SELECT count(*) + count(*) FILTER (WHERE is_edited), COALESCE(max(created), to_timestamp(0))
+		FROM posts
+		WHERE author = $1;`, user.Nickname)
+}
@@ -0,0 +1,239 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	repoFeed "project/internal/feed/repository"
+	repoForum "project/internal/forum/repository"
+	"project/internal/models"
+	"project/internal/pkg"
+	repoPost "project/internal/post/repository"
+	repoThread "project/internal/thread/repository"
+	repoUser "project/internal/user/repository"
+)
+
+const feedSize = 50
+
+type FeedService interface {
+	GetForumFeed(ctx context.Context, forum *models.Forum, cond *pkg.ConditionalParams) (*models.Feed, error)
+	GetThreadFeed(ctx context.Context, thread *models.Thread, cond *pkg.ConditionalParams) (*models.Feed, error)
+	GetUserFeed(ctx context.Context, user *models.User, cond *pkg.ConditionalParams) (*models.Feed, error)
+}
+
+type feedService struct {
+	feedRepo   repoFeed.FeedRepository
+	forumRepo  repoForum.ForumRepository
+	threadRepo repoThread.ThreadRepository
+	postRepo   repoPost.PostRepository
+	userRepo   repoUser.UserRepository
+}
+
+func NewFeedService(rfd repoFeed.FeedRepository, rf repoForum.ForumRepository, rt repoThread.ThreadRepository, rp repoPost.PostRepository, ru repoUser.UserRepository) FeedService {
+	return &feedService{
+		feedRepo:   rfd,
+		forumRepo:  rf,
+		threadRepo: rt,
+		postRepo:   rp,
+		userRepo:   ru,
+	}
+}
+
+func (f feedService) checkReadAccess(ctx context.Context, forum string) error {
+	access, err := f.forumRepo.GetAccessForum(ctx, &models.Forum{Slug: forum}, pkg.GetNickname(ctx))
+	if err != nil {
+		return err
+	}
+
+	if !pkg.CanReadForum(access) {
+		return pkg.ErrSuchForumNotFound
+	}
+
+	return nil
+}
+
+// newFeed fills the validators of the feed and reports whether the client copy is still fresh, in which case
+// the feed is left without entries.
+func newFeed(state *models.FeedState, tag string, cond *pkg.ConditionalParams) *models.Feed {
+	res := &models.Feed{
+		ETag:    fmt.Sprintf(`W/"%s-%s"`, tag, state.Version),
+		Updated: state.Updated,
+	}
+
+	res.NotModified = cond.NotModified(res.ETag, res.Updated)
+
+	return res
+}
+
+func parseTime(value string) time.Time {
+	res, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return res
+}
+
+func newPostEntry(post *models.Post, title string) models.FeedEntry {
+	created := parseTime(post.Created)
+
+	return models.FeedEntry{
+		ID:        "urn:forum:post:" + strconv.FormatInt(post.ID, 10),
+		Title:     title,
+		Link:      "/api/post/" + strconv.FormatInt(post.ID, 10) + "/details",
+		Author:    post.Author.Nickname,
+		Content:   post.Message,
+		Published: created,
+		Updated:   created,
+	}
+}
+
+func (f feedService) GetForumFeed(ctx context.Context, forum *models.Forum, cond *pkg.ConditionalParams) (*models.Feed, error) {
+	resForum, err := f.forumRepo.GetDetailsForumBySlug(ctx, forum)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetForumFeed")
+	}
+
+	err = f.checkReadAccess(ctx, resForum.Slug)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetForumFeed")
+	}
+
+	state, err := f.feedRepo.GetForumFeedState(ctx, resForum)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetForumFeed")
+	}
+
+	res := newFeed(state, "forum-"+resForum.Slug, cond)
+	if res.NotModified {
+		return res, nil
+	}
+
+	threads, err := f.forumRepo.GetThreads(ctx, resForum, &pkg.GetThreadsParams{Limit: feedSize, Desc: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "GetForumFeed")
+	}
+
+	res.ID = "urn:forum:forum:" + resForum.Slug
+	res.Title = resForum.Title
+	res.Link = "/api/forum/" + resForum.Slug + "/details"
+	res.Author = resForum.User
+	res.Entries = make([]models.FeedEntry, 0, len(threads))
+
+	for _, thread := range threads {
+		created := parseTime(thread.Created)
+
+		res.Entries = append(res.Entries, models.FeedEntry{
+			ID:        "urn:forum:thread:" + strconv.FormatInt(thread.ID, 10),
+			Title:     thread.Title,
+			Link:      "/api/thread/" + strconv.FormatInt(thread.ID, 10) + "/details",
+			Author:    thread.Author,
+			Content:   thread.Message,
+			Published: created,
+			Updated:   created,
+		})
+	}
+
+	return res, nil
+}
+
+func (f feedService) GetThreadFeed(ctx context.Context, thread *models.Thread, cond *pkg.ConditionalParams) (*models.Feed, error) {
+	var resThread models.Thread
+	var err error
+
+	// CheckAndGetThread
+	if thread.Slug != "" {
+		resThread, err = f.threadRepo.GetDetailsThreadBySlug(ctx, thread)
+	} else {
+		resThread, err = f.threadRepo.GetDetailsThreadByID(ctx, thread)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "GetThreadFeed")
+	}
+
+	err = f.checkReadAccess(ctx, resThread.Forum)
+	if errors.Is(err, pkg.ErrSuchForumNotFound) {
+		return nil, errors.Wrap(pkg.ErrSuchThreadNotFound, "GetThreadFeed")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "GetThreadFeed")
+	}
+
+	state, err := f.feedRepo.GetThreadFeedState(ctx, &resThread)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetThreadFeed")
+	}
+
+	res := newFeed(state, "thread-"+strconv.FormatInt(resThread.ID, 10), cond)
+	if res.NotModified {
+		return res, nil
+	}
+
+	posts, err := f.threadRepo.GetPostsByIDFlat(ctx, &resThread, &pkg.GetPostsParams{
+		Limit: feedSize,
+		Since: -1,
+		Desc:  true,
+		Sort:  pkg.TypeSortFlat,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "GetThreadFeed")
+	}
+
+	res.ID = "urn:forum:thread:" + strconv.FormatInt(resThread.ID, 10)
+	res.Title = resThread.Title
+	res.Link = "/api/thread/" + strconv.FormatInt(resThread.ID, 10) + "/details"
+	res.Author = resThread.Author
+	res.Entries = make([]models.FeedEntry, 0, len(posts))
+
+	for idx := range posts {
+		res.Entries = append(res.Entries, newPostEntry(&posts[idx], posts[idx].Author.Nickname+" in "+resThread.Title))
+	}
+
+	return res, nil
+}
+
+func (f feedService) GetUserFeed(ctx context.Context, user *models.User, cond *pkg.ConditionalParams) (*models.Feed, error) {
+	resUser, err := f.userRepo.GetUserByNickname(ctx, user)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetUserFeed")
+	}
+
+	state, err := f.feedRepo.GetUserFeedState(ctx, &resUser)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetUserFeed")
+	}
+
+	// Private forums make the feed depend on who reads it
+	viewer := pkg.GetNickname(ctx)
+
+	tag := "user-" + resUser.Nickname
+	if viewer != "" {
+		tag += "-" + viewer
+	}
+
+	res := newFeed(state, tag, cond)
+	if res.NotModified {
+		return res, nil
+	}
+
+	posts, err := f.postRepo.GetPostsByAuthor(ctx, &resUser, viewer, feedSize)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetUserFeed")
+	}
+
+	res.ID = "urn:forum:user:" + resUser.Nickname
+	res.Title = resUser.FullName
+	res.Link = "/api/user/" + resUser.Nickname + "/profile"
+	res.Author = resUser.Nickname
+	res.Entries = make([]models.FeedEntry, 0, len(posts))
+
+	for idx := range posts {
+		res.Entries = append(res.Entries, newPostEntry(&posts[idx], resUser.Nickname+" in "+posts[idx].Forum))
+	}
+
+	return res, nil
+}
@@ -0,0 +1,95 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"project/internal/forum/usecase"
+	"project/internal/models"
+	"project/internal/pb"
+	"project/internal/pkg"
+	"project/internal/pkg/grpctools"
+)
+
+const defaultLimit = 100
+
+type ForumServer struct {
+	pb.UnimplementedForumServiceServer
+
+	forumUsecase usecase.ForumService
+}
+
+func (s *ForumServer) CreateForum(ctx context.Context, req *pb.CreateForumRequest) (*pb.Forum, error) {
+	forum, err := s.forumUsecase.CreateForum(ctx, req.GetForum().GetModel())
+	if err != nil {
+		if errors.Is(errors.Cause(err), pkg.ErrSuchForumExist) {
+			return nil, grpctools.Error(err, pb.NewForum(forum))
+		}
+
+		return nil, grpctools.Error(err)
+	}
+
+	return pb.NewForum(forum), nil
+}
+
+func (s *ForumServer) GetForum(ctx context.Context, req *pb.GetForumRequest) (*pb.Forum, error) {
+	forum, err := s.forumUsecase.GetDetailsForum(ctx, &models.Forum{Slug: req.GetSlug()})
+	if err != nil {
+		return nil, grpctools.Error(err)
+	}
+
+	return pb.NewForum(forum), nil
+}
+
+func (s *ForumServer) ListThreads(ctx context.Context, req *pb.ListThreadsRequest) (*pb.ListThreadsResponse, error) {
+	params := &pkg.GetThreadsParams{
+		Limit:  req.GetLimit(),
+		Since:  req.GetSince(),
+		Desc:   req.GetDesc(),
+		Sort:   req.GetSort(),
+		Window: req.GetWindow(),
+	}
+	if params.Limit <= 0 {
+		params.Limit = defaultLimit
+	}
+
+	threads, err := s.forumUsecase.GetThreads(ctx, &models.Forum{Slug: req.GetSlug()}, params)
+	if err != nil {
+		return nil, grpctools.Error(err)
+	}
+
+	res := &pb.ListThreadsResponse{Threads: make([]*pb.Thread, len(threads))}
+	for idx, thread := range threads {
+		res.Threads[idx] = pb.NewThread(thread)
+	}
+
+	return res, nil
+}
+
+func (s *ForumServer) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	params := &pkg.GetUsersParams{
+		Limit: req.GetLimit(),
+		Since: req.GetSince(),
+		Desc:  req.GetDesc(),
+	}
+	if params.Limit <= 0 {
+		params.Limit = defaultLimit
+	}
+
+	users, err := s.forumUsecase.GetUsers(ctx, &models.Forum{Slug: req.GetSlug()}, params)
+	if err != nil {
+		return nil, grpctools.Error(err)
+	}
+
+	res := &pb.ListUsersResponse{Users: make([]*pb.User, len(users))}
+	for idx, user := range users {
+		res.Users[idx] = pb.NewUser(user)
+	}
+
+	return res, nil
+}
+
+func NewForumServer(forumUsecase usecase.ForumService) *ForumServer {
+	return &ForumServer{forumUsecase: forumUsecase}
+}
@@ -86,6 +86,88 @@ func (h *ForumHandler) GetForumUsersHandler(w http.ResponseWriter, r *http.Reque
 	pkg.Response(r.Context(), w, http.StatusOK, response)
 }
 
+func (h *ForumHandler) GetForumStatsHandler(w http.ResponseWriter, r *http.Request) {
+	request := models.NewForumGetStatsRequest()
+
+	err := request.Bind(r)
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	stats, err := h.forumUsecase.GetStats(r.Context(), request.GetForum(), request.GetParams())
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	response := models.NewForumGetStatsResponse(stats)
+
+	pkg.Response(r.Context(), w, http.StatusOK, response)
+}
+
+func (h *ForumHandler) GetForumChildrenHandler(w http.ResponseWriter, r *http.Request) {
+	request := models.NewForumGetChildrenRequest()
+
+	request.Bind(r)
+
+	forums, err := h.forumUsecase.GetChildren(r.Context(), request.GetForum())
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	response := models.NewForumGetChildrenResponse(forums)
+
+	pkg.Response(r.Context(), w, http.StatusOK, response)
+}
+
+func (h *ForumHandler) InviteMemberHandler(w http.ResponseWriter, r *http.Request) {
+	request := models.NewForumMemberRequest()
+
+	request.Bind(r)
+
+	member, err := h.forumUsecase.InviteMember(r.Context(), request.GetMember())
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	response := models.NewForumMemberResponse(member)
+
+	pkg.Response(r.Context(), w, http.StatusCreated, response)
+}
+
+func (h *ForumHandler) AcceptInviteHandler(w http.ResponseWriter, r *http.Request) {
+	request := models.NewForumMemberRequest()
+
+	request.Bind(r)
+
+	member, err := h.forumUsecase.AcceptInvite(r.Context(), request.GetMember())
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	response := models.NewForumMemberResponse(member)
+
+	pkg.Response(r.Context(), w, http.StatusOK, response)
+}
+
+func (h *ForumHandler) LeaveForumHandler(w http.ResponseWriter, r *http.Request) {
+	request := models.NewForumMemberRequest()
+
+	request.Bind(r)
+
+	err := h.forumUsecase.LeaveForum(r.Context(), request.GetMember())
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	pkg.NoBody(w, http.StatusOK)
+}
+
 func NewForumHandler(forumUsecase usecase.ForumService, r *mux.Router) *ForumHandler {
 	h := &ForumHandler{forumUsecase: forumUsecase}
 	return h
@@ -12,9 +12,12 @@ import (
 //go:generate easyjson -all -disallow_unknown_fields -omit_empty createforum.go
 
 type ForumCreateRequest struct {
-	Title string `json:"title"`
-	User  string `json:"user"`
-	Slug  string `json:"slug"`
+	Title      string `json:"title"`
+	User       string `json:"user"`
+	Slug       string `json:"slug"`
+	Parent     string `json:"parent"`
+	RollUp     bool   `json:"rollup"`
+	Visibility string `json:"visibility"`
 }
 
 func NewForumCreateRequest() *ForumCreateRequest {
@@ -56,26 +59,35 @@ func (req *ForumCreateRequest) Bind(r *http.Request) error {
 
 func (req *ForumCreateRequest) GetForum() *models.Forum {
 	return &models.Forum{
-		Title: req.Title,
-		User:  req.User,
-		Slug:  req.Slug,
+		Title:      req.Title,
+		User:       req.User,
+		Slug:       req.Slug,
+		Parent:     req.Parent,
+		RollUp:     req.RollUp,
+		Visibility: req.Visibility,
 	}
 }
 
 type ForumCreateResponse struct {
-	Title   string `json:"title"`
-	User    string `json:"user"`
-	Slug    string `json:"slug"`
-	Posts   int64  `json:"posts,omitempty"`
-	Threads int64  `json:"threads,omitempty"`
+	Title      string `json:"title"`
+	User       string `json:"user"`
+	Slug       string `json:"slug"`
+	Posts      int64  `json:"posts,omitempty"`
+	Threads    int64  `json:"threads,omitempty"`
+	Parent     string `json:"parent,omitempty"`
+	RollUp     bool   `json:"rollup,omitempty"`
+	Visibility string `json:"visibility,omitempty"`
 }
 
 func NewForumCreateResponse(forum *models.Forum) *ForumCreateResponse {
 	return &ForumCreateResponse{
-		Title:   forum.Title,
-		User:    forum.User,
-		Slug:    forum.Slug,
-		Posts:   forum.Posts,
-		Threads: forum.Threads,
+		Title:      forum.Title,
+		User:       forum.User,
+		Slug:       forum.Slug,
+		Posts:      forum.Posts,
+		Threads:    forum.Threads,
+		Parent:     forum.Parent,
+		RollUp:     forum.RollUp,
+		Visibility: forum.Visibility,
 	}
 }
@@ -17,7 +17,7 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjson369c8e19DecodeDbPerformanceProjectInternalForumDeliveryModels(in *jlexer.Lexer, out *ForumCreateResponse) {
+func easyjson369c8e19DecodeProjectInternalForumDeliveryModels(in *jlexer.Lexer, out *ForumCreateResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -46,6 +46,12 @@ func easyjson369c8e19DecodeDbPerformanceProjectInternalForumDeliveryModels(in *j
 			out.Posts = int64(in.Int64())
 		case "threads":
 			out.Threads = int64(in.Int64())
+		case "parent":
+			out.Parent = string(in.String())
+		case "rollup":
+			out.RollUp = bool(in.Bool())
+		case "visibility":
+			out.Visibility = string(in.String())
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -60,7 +66,7 @@ func easyjson369c8e19DecodeDbPerformanceProjectInternalForumDeliveryModels(in *j
 		in.Consumed()
 	}
 }
-func easyjson369c8e19EncodeDbPerformanceProjectInternalForumDeliveryModels(out *jwriter.Writer, in ForumCreateResponse) {
+func easyjson369c8e19EncodeProjectInternalForumDeliveryModels(out *jwriter.Writer, in ForumCreateResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -110,33 +116,63 @@ func easyjson369c8e19EncodeDbPerformanceProjectInternalForumDeliveryModels(out *
 		}
 		out.Int64(int64(in.Threads))
 	}
+	if in.Parent != "" {
+		const prefix string = ",\"parent\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Parent))
+	}
+	if in.RollUp {
+		const prefix string = ",\"rollup\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.RollUp))
+	}
+	if in.Visibility != "" {
+		const prefix string = ",\"visibility\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Visibility))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v ForumCreateResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson369c8e19EncodeDbPerformanceProjectInternalForumDeliveryModels(&w, v)
+	easyjson369c8e19EncodeProjectInternalForumDeliveryModels(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ForumCreateResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson369c8e19EncodeDbPerformanceProjectInternalForumDeliveryModels(w, v)
+	easyjson369c8e19EncodeProjectInternalForumDeliveryModels(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ForumCreateResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson369c8e19DecodeDbPerformanceProjectInternalForumDeliveryModels(&r, v)
+	easyjson369c8e19DecodeProjectInternalForumDeliveryModels(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ForumCreateResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson369c8e19DecodeDbPerformanceProjectInternalForumDeliveryModels(l, v)
+	easyjson369c8e19DecodeProjectInternalForumDeliveryModels(l, v)
 }
-func easyjson369c8e19DecodeDbPerformanceProjectInternalForumDeliveryModels1(in *jlexer.Lexer, out *ForumCreateRequest) {
+func easyjson369c8e19DecodeProjectInternalForumDeliveryModels1(in *jlexer.Lexer, out *ForumCreateRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -161,6 +197,12 @@ func easyjson369c8e19DecodeDbPerformanceProjectInternalForumDeliveryModels1(in *
 			out.User = string(in.String())
 		case "slug":
 			out.Slug = string(in.String())
+		case "parent":
+			out.Parent = string(in.String())
+		case "rollup":
+			out.RollUp = bool(in.Bool())
+		case "visibility":
+			out.Visibility = string(in.String())
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -175,7 +217,7 @@ func easyjson369c8e19DecodeDbPerformanceProjectInternalForumDeliveryModels1(in *
 		in.Consumed()
 	}
 }
-func easyjson369c8e19EncodeDbPerformanceProjectInternalForumDeliveryModels1(out *jwriter.Writer, in ForumCreateRequest) {
+func easyjson369c8e19EncodeProjectInternalForumDeliveryModels1(out *jwriter.Writer, in ForumCreateRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -205,29 +247,59 @@ func easyjson369c8e19EncodeDbPerformanceProjectInternalForumDeliveryModels1(out
 		}
 		out.String(string(in.Slug))
 	}
+	if in.Parent != "" {
+		const prefix string = ",\"parent\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Parent))
+	}
+	if in.RollUp {
+		const prefix string = ",\"rollup\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.RollUp))
+	}
+	if in.Visibility != "" {
+		const prefix string = ",\"visibility\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Visibility))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v ForumCreateRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson369c8e19EncodeDbPerformanceProjectInternalForumDeliveryModels1(&w, v)
+	easyjson369c8e19EncodeProjectInternalForumDeliveryModels1(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ForumCreateRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson369c8e19EncodeDbPerformanceProjectInternalForumDeliveryModels1(w, v)
+	easyjson369c8e19EncodeProjectInternalForumDeliveryModels1(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ForumCreateRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson369c8e19DecodeDbPerformanceProjectInternalForumDeliveryModels1(&r, v)
+	easyjson369c8e19DecodeProjectInternalForumDeliveryModels1(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ForumCreateRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson369c8e19DecodeDbPerformanceProjectInternalForumDeliveryModels1(l, v)
+	easyjson369c8e19DecodeProjectInternalForumDeliveryModels1(l, v)
 }
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"project/internal/models"
+)
+
+//go:generate easyjson -disallow_unknown_fields -omit_empty getchildren.go
+
+type ForumGetChildrenRequest struct {
+	Slug string
+}
+
+func NewForumGetChildrenRequest() *ForumGetChildrenRequest {
+	return &ForumGetChildrenRequest{}
+}
+
+func (req *ForumGetChildrenRequest) Bind(r *http.Request) error {
+	vars := mux.Vars(r)
+
+	req.Slug = vars["slug"]
+
+	return nil
+}
+
+func (req *ForumGetChildrenRequest) GetForum() *models.Forum {
+	return &models.Forum{
+		Slug: req.Slug,
+	}
+}
+
+//easyjson:json
+type ForumGetChildrenResponse struct {
+	Title      string `json:"title"`
+	User       string `json:"user"`
+	Slug       string `json:"slug"`
+	Posts      int64  `json:"posts,omitempty"`
+	Threads    int64  `json:"threads,omitempty"`
+	Parent     string `json:"parent,omitempty"`
+	RollUp     bool   `json:"rollup,omitempty"`
+	Visibility string `json:"visibility,omitempty"`
+}
+
+//easyjson:json
+type ForumsList []ForumGetChildrenResponse
+
+func NewForumGetChildrenResponse(forums []*models.Forum) ForumsList {
+	res := make([]ForumGetChildrenResponse, len(forums))
+
+	for idx, value := range forums {
+		res[idx] = ForumGetChildrenResponse{
+			Title:      value.Title,
+			User:       value.User,
+			Slug:       value.Slug,
+			Posts:      value.Posts,
+			Threads:    value.Threads,
+			Parent:     value.Parent,
+			RollUp:     value.RollUp,
+			Visibility: value.Visibility,
+		}
+	}
+
+	return res
+}
@@ -36,21 +36,49 @@ func (req *ForumGetDetailsRequest) GetForum() *models.Forum {
 	}
 }
 
+//easyjson:json
+type ForumBreadcrumbResponse struct {
+	Slug  string `json:"slug"`
+	Title string `json:"title"`
+}
+
 //easyjson:json
 type ForumGetDetailsResponse struct {
-	Title   string `json:"title"`
-	User    string `json:"user"`
-	Slug    string `json:"slug"`
-	Posts   int64  `json:"posts,omitempty"`
-	Threads int64  `json:"threads,omitempty"`
+	Title      string                    `json:"title"`
+	User       string                    `json:"user"`
+	Slug       string                    `json:"slug"`
+	Posts      int64                     `json:"posts,omitempty"`
+	Threads    int64                     `json:"threads,omitempty"`
+	Parent     string                    `json:"parent,omitempty"`
+	RollUp     bool                      `json:"rollup,omitempty"`
+	Visibility string                    `json:"visibility,omitempty"`
+	Version    int64                     `json:"version,omitempty"`
+	Breadcrumb []ForumBreadcrumbResponse `json:"breadcrumb,omitempty"`
 }
 
 func NewForumGetDetailsResponse(forum *models.Forum) *ForumGetDetailsResponse {
-	return &ForumGetDetailsResponse{
-		Title:   forum.Title,
-		User:    forum.User,
-		Slug:    forum.Slug,
-		Posts:   forum.Posts,
-		Threads: forum.Threads,
+	res := &ForumGetDetailsResponse{
+		Title:      forum.Title,
+		User:       forum.User,
+		Slug:       forum.Slug,
+		Posts:      forum.Posts,
+		Threads:    forum.Threads,
+		Parent:     forum.Parent,
+		RollUp:     forum.RollUp,
+		Visibility: forum.Visibility,
+		Version:    forum.Version,
 	}
+
+	if len(forum.Breadcrumb) > 0 {
+		res.Breadcrumb = make([]ForumBreadcrumbResponse, len(forum.Breadcrumb))
+
+		for idx, value := range forum.Breadcrumb {
+			res.Breadcrumb[idx] = ForumBreadcrumbResponse{
+				Slug:  value.Slug,
+				Title: value.Title,
+			}
+		}
+	}
+
+	return res
 }
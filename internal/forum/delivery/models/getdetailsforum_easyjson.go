@@ -17,7 +17,7 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjsonE96e2c6bDecodeDbPerformanceProjectInternalForumDeliveryModels(in *jlexer.Lexer, out *ForumGetDetailsResponse) {
+func easyjsonE96e2c6bDecodeProjectInternalForumDeliveryModels(in *jlexer.Lexer, out *ForumGetDetailsResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -46,6 +46,37 @@ func easyjsonE96e2c6bDecodeDbPerformanceProjectInternalForumDeliveryModels(in *j
 			out.Posts = int64(in.Int64())
 		case "threads":
 			out.Threads = int64(in.Int64())
+		case "parent":
+			out.Parent = string(in.String())
+		case "rollup":
+			out.RollUp = bool(in.Bool())
+		case "visibility":
+			out.Visibility = string(in.String())
+		case "version":
+			out.Version = int64(in.Int64())
+		case "breadcrumb":
+			if in.IsNull() {
+				in.Skip()
+				out.Breadcrumb = nil
+			} else {
+				in.Delim('[')
+				if out.Breadcrumb == nil {
+					if !in.IsDelim(']') {
+						out.Breadcrumb = make([]ForumBreadcrumbResponse, 0, 2)
+					} else {
+						out.Breadcrumb = []ForumBreadcrumbResponse{}
+					}
+				} else {
+					out.Breadcrumb = (out.Breadcrumb)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 ForumBreadcrumbResponse
+					(v1).UnmarshalEasyJSON(in)
+					out.Breadcrumb = append(out.Breadcrumb, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -60,7 +91,7 @@ func easyjsonE96e2c6bDecodeDbPerformanceProjectInternalForumDeliveryModels(in *j
 		in.Consumed()
 	}
 }
-func easyjsonE96e2c6bEncodeDbPerformanceProjectInternalForumDeliveryModels(out *jwriter.Writer, in ForumGetDetailsResponse) {
+func easyjsonE96e2c6bEncodeProjectInternalForumDeliveryModels(out *jwriter.Writer, in ForumGetDetailsResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -110,29 +141,171 @@ func easyjsonE96e2c6bEncodeDbPerformanceProjectInternalForumDeliveryModels(out *
 		}
 		out.Int64(int64(in.Threads))
 	}
+	if in.Parent != "" {
+		const prefix string = ",\"parent\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Parent))
+	}
+	if in.RollUp {
+		const prefix string = ",\"rollup\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.RollUp))
+	}
+	if in.Visibility != "" {
+		const prefix string = ",\"visibility\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Visibility))
+	}
+	if in.Version != 0 {
+		const prefix string = ",\"version\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Version))
+	}
+	if len(in.Breadcrumb) != 0 {
+		const prefix string = ",\"breadcrumb\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v2, v3 := range in.Breadcrumb {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v ForumGetDetailsResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE96e2c6bEncodeDbPerformanceProjectInternalForumDeliveryModels(&w, v)
+	easyjsonE96e2c6bEncodeProjectInternalForumDeliveryModels(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ForumGetDetailsResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE96e2c6bEncodeDbPerformanceProjectInternalForumDeliveryModels(w, v)
+	easyjsonE96e2c6bEncodeProjectInternalForumDeliveryModels(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ForumGetDetailsResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE96e2c6bDecodeDbPerformanceProjectInternalForumDeliveryModels(&r, v)
+	easyjsonE96e2c6bDecodeProjectInternalForumDeliveryModels(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ForumGetDetailsResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE96e2c6bDecodeDbPerformanceProjectInternalForumDeliveryModels(l, v)
+	easyjsonE96e2c6bDecodeProjectInternalForumDeliveryModels(l, v)
+}
+func easyjsonE96e2c6bDecodeProjectInternalForumDeliveryModels1(in *jlexer.Lexer, out *ForumBreadcrumbResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "slug":
+			out.Slug = string(in.String())
+		case "title":
+			out.Title = string(in.String())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE96e2c6bEncodeProjectInternalForumDeliveryModels1(out *jwriter.Writer, in ForumBreadcrumbResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Slug != "" {
+		const prefix string = ",\"slug\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Slug))
+	}
+	if in.Title != "" {
+		const prefix string = ",\"title\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Title))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ForumBreadcrumbResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE96e2c6bEncodeProjectInternalForumDeliveryModels1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ForumBreadcrumbResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE96e2c6bEncodeProjectInternalForumDeliveryModels1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ForumBreadcrumbResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE96e2c6bDecodeProjectInternalForumDeliveryModels1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ForumBreadcrumbResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE96e2c6bDecodeProjectInternalForumDeliveryModels1(l, v)
 }
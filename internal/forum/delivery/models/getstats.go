@@ -0,0 +1,165 @@
+package models
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"project/internal/models"
+	"project/internal/pkg"
+)
+
+//go:generate easyjson -disallow_unknown_fields -omit_empty getstats.go
+
+type ForumGetStatsRequest struct {
+	Slug   string
+	Period string
+	From   time.Time
+	To     time.Time
+	Limit  int64
+}
+
+func NewForumGetStatsRequest() *ForumGetStatsRequest {
+	return &ForumGetStatsRequest{}
+}
+
+// Bind takes from and to as days, 2006-01-02.
+func (req *ForumGetStatsRequest) Bind(r *http.Request) error {
+	vars := mux.Vars(r)
+
+	req.Slug = vars["slug"]
+	req.Period = r.FormValue("period")
+
+	var err error
+
+	param := r.FormValue("from")
+	if param != "" {
+		req.From, err = time.Parse("2006-01-02", param)
+		if err != nil {
+			return pkg.ErrConvertQueryType
+		}
+	}
+
+	param = r.FormValue("to")
+	if param != "" {
+		req.To, err = time.Parse("2006-01-02", param)
+		if err != nil {
+			return pkg.ErrConvertQueryType
+		}
+	}
+
+	req.Limit = 10
+
+	param = r.FormValue("limit")
+	if param != "" {
+		req.Limit, err = strconv.ParseInt(param, 10, 64)
+		if err != nil {
+			return pkg.ErrConvertQueryType
+		}
+	}
+
+	return nil
+}
+
+func (req *ForumGetStatsRequest) GetForum() *models.Forum {
+	return &models.Forum{
+		Slug: req.Slug,
+	}
+}
+
+func (req *ForumGetStatsRequest) GetParams() *pkg.GetForumStatsParams {
+	return &pkg.GetForumStatsParams{
+		Period: req.Period,
+		From:   req.From,
+		To:     req.To,
+		Limit:  req.Limit,
+	}
+}
+
+//easyjson:json
+type ForumActivityResponse struct {
+	Start   string `json:"start"`
+	Posts   int64  `json:"posts"`
+	Threads int64  `json:"threads"`
+	Users   int64  `json:"users"`
+}
+
+//easyjson:json
+type ForumAuthorResponse struct {
+	Nickname string `json:"nickname"`
+	Posts    int64  `json:"posts"`
+	Votes    int64  `json:"votes"`
+}
+
+//easyjson:json
+type ForumTopThreadResponse struct {
+	ID      int64  `json:"id"`
+	Title   string `json:"title"`
+	Author  string `json:"author"`
+	Slug    string `json:"slug,omitempty"`
+	Votes   int64  `json:"votes"`
+	Created string `json:"created"`
+}
+
+//easyjson:json
+type ForumGetStatsResponse struct {
+	Forum        string                   `json:"forum"`
+	Period       string                   `json:"period"`
+	Activity     []ForumActivityResponse  `json:"activity"`
+	ActiveUsers  int64                    `json:"activeUsers"`
+	TopPosters   []ForumAuthorResponse    `json:"topPosters"`
+	TopVoted     []ForumAuthorResponse    `json:"topVoted"`
+	TopThreads   []ForumTopThreadResponse `json:"topThreads"`
+	AverageDepth float64                  `json:"averageDepth"`
+}
+
+func NewForumGetStatsResponse(stats *models.ForumStats) *ForumGetStatsResponse {
+	res := &ForumGetStatsResponse{
+		Forum:        stats.Forum,
+		Period:       stats.Period,
+		Activity:     make([]ForumActivityResponse, len(stats.Activity)),
+		ActiveUsers:  stats.ActiveUsers,
+		TopPosters:   newForumAuthorsResponse(stats.TopPosters),
+		TopVoted:     newForumAuthorsResponse(stats.TopVoted),
+		TopThreads:   make([]ForumTopThreadResponse, len(stats.TopThreads)),
+		AverageDepth: stats.AverageDepth,
+	}
+
+	for idx, value := range stats.Activity {
+		res.Activity[idx] = ForumActivityResponse{
+			Start:   value.Start,
+			Posts:   value.Posts,
+			Threads: value.Threads,
+			Users:   value.Users,
+		}
+	}
+
+	for idx, value := range stats.TopThreads {
+		res.TopThreads[idx] = ForumTopThreadResponse{
+			ID:      value.ID,
+			Title:   value.Title,
+			Author:  value.Author,
+			Slug:    value.Slug,
+			Votes:   value.Votes,
+			Created: value.Created,
+		}
+	}
+
+	return res
+}
+
+func newForumAuthorsResponse(authors []models.ForumAuthor) []ForumAuthorResponse {
+	res := make([]ForumAuthorResponse, len(authors))
+
+	for idx, value := range authors {
+		res[idx] = ForumAuthorResponse{
+			Nickname: value.Nickname,
+			Posts:    value.Posts,
+			Votes:    value.Votes,
+		}
+	}
+
+	return res
+}
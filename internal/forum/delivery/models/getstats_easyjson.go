@@ -0,0 +1,627 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package models
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson6d8024d9DecodeProjectInternalForumDeliveryModels(in *jlexer.Lexer, out *ForumTopThreadResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int64(in.Int64())
+		case "title":
+			out.Title = string(in.String())
+		case "author":
+			out.Author = string(in.String())
+		case "slug":
+			out.Slug = string(in.String())
+		case "votes":
+			out.Votes = int64(in.Int64())
+		case "created":
+			out.Created = string(in.String())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6d8024d9EncodeProjectInternalForumDeliveryModels(out *jwriter.Writer, in ForumTopThreadResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.ID != 0 {
+		const prefix string = ",\"id\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.ID))
+	}
+	if in.Title != "" {
+		const prefix string = ",\"title\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Title))
+	}
+	if in.Author != "" {
+		const prefix string = ",\"author\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Author))
+	}
+	if in.Slug != "" {
+		const prefix string = ",\"slug\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Slug))
+	}
+	if in.Votes != 0 {
+		const prefix string = ",\"votes\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Votes))
+	}
+	if in.Created != "" {
+		const prefix string = ",\"created\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Created))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ForumTopThreadResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6d8024d9EncodeProjectInternalForumDeliveryModels(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ForumTopThreadResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6d8024d9EncodeProjectInternalForumDeliveryModels(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ForumTopThreadResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6d8024d9DecodeProjectInternalForumDeliveryModels(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ForumTopThreadResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6d8024d9DecodeProjectInternalForumDeliveryModels(l, v)
+}
+func easyjson6d8024d9DecodeProjectInternalForumDeliveryModels1(in *jlexer.Lexer, out *ForumGetStatsResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "forum":
+			out.Forum = string(in.String())
+		case "period":
+			out.Period = string(in.String())
+		case "activity":
+			if in.IsNull() {
+				in.Skip()
+				out.Activity = nil
+			} else {
+				in.Delim('[')
+				if out.Activity == nil {
+					if !in.IsDelim(']') {
+						out.Activity = make([]ForumActivityResponse, 0, 1)
+					} else {
+						out.Activity = []ForumActivityResponse{}
+					}
+				} else {
+					out.Activity = (out.Activity)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 ForumActivityResponse
+					(v1).UnmarshalEasyJSON(in)
+					out.Activity = append(out.Activity, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "activeUsers":
+			out.ActiveUsers = int64(in.Int64())
+		case "topPosters":
+			if in.IsNull() {
+				in.Skip()
+				out.TopPosters = nil
+			} else {
+				in.Delim('[')
+				if out.TopPosters == nil {
+					if !in.IsDelim(']') {
+						out.TopPosters = make([]ForumAuthorResponse, 0, 2)
+					} else {
+						out.TopPosters = []ForumAuthorResponse{}
+					}
+				} else {
+					out.TopPosters = (out.TopPosters)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v2 ForumAuthorResponse
+					(v2).UnmarshalEasyJSON(in)
+					out.TopPosters = append(out.TopPosters, v2)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "topVoted":
+			if in.IsNull() {
+				in.Skip()
+				out.TopVoted = nil
+			} else {
+				in.Delim('[')
+				if out.TopVoted == nil {
+					if !in.IsDelim(']') {
+						out.TopVoted = make([]ForumAuthorResponse, 0, 2)
+					} else {
+						out.TopVoted = []ForumAuthorResponse{}
+					}
+				} else {
+					out.TopVoted = (out.TopVoted)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v3 ForumAuthorResponse
+					(v3).UnmarshalEasyJSON(in)
+					out.TopVoted = append(out.TopVoted, v3)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "topThreads":
+			if in.IsNull() {
+				in.Skip()
+				out.TopThreads = nil
+			} else {
+				in.Delim('[')
+				if out.TopThreads == nil {
+					if !in.IsDelim(']') {
+						out.TopThreads = make([]ForumTopThreadResponse, 0, 0)
+					} else {
+						out.TopThreads = []ForumTopThreadResponse{}
+					}
+				} else {
+					out.TopThreads = (out.TopThreads)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 ForumTopThreadResponse
+					(v4).UnmarshalEasyJSON(in)
+					out.TopThreads = append(out.TopThreads, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "averageDepth":
+			out.AverageDepth = float64(in.Float64())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6d8024d9EncodeProjectInternalForumDeliveryModels1(out *jwriter.Writer, in ForumGetStatsResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Forum != "" {
+		const prefix string = ",\"forum\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Forum))
+	}
+	if in.Period != "" {
+		const prefix string = ",\"period\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Period))
+	}
+	if len(in.Activity) != 0 {
+		const prefix string = ",\"activity\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v5, v6 := range in.Activity {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				(v6).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.ActiveUsers != 0 {
+		const prefix string = ",\"activeUsers\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.ActiveUsers))
+	}
+	if len(in.TopPosters) != 0 {
+		const prefix string = ",\"topPosters\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v7, v8 := range in.TopPosters {
+				if v7 > 0 {
+					out.RawByte(',')
+				}
+				(v8).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.TopVoted) != 0 {
+		const prefix string = ",\"topVoted\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v9, v10 := range in.TopVoted {
+				if v9 > 0 {
+					out.RawByte(',')
+				}
+				(v10).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.TopThreads) != 0 {
+		const prefix string = ",\"topThreads\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v11, v12 := range in.TopThreads {
+				if v11 > 0 {
+					out.RawByte(',')
+				}
+				(v12).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.AverageDepth != 0 {
+		const prefix string = ",\"averageDepth\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Float64(float64(in.AverageDepth))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ForumGetStatsResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6d8024d9EncodeProjectInternalForumDeliveryModels1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ForumGetStatsResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6d8024d9EncodeProjectInternalForumDeliveryModels1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ForumGetStatsResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6d8024d9DecodeProjectInternalForumDeliveryModels1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ForumGetStatsResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6d8024d9DecodeProjectInternalForumDeliveryModels1(l, v)
+}
+func easyjson6d8024d9DecodeProjectInternalForumDeliveryModels2(in *jlexer.Lexer, out *ForumAuthorResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "nickname":
+			out.Nickname = string(in.String())
+		case "posts":
+			out.Posts = int64(in.Int64())
+		case "votes":
+			out.Votes = int64(in.Int64())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6d8024d9EncodeProjectInternalForumDeliveryModels2(out *jwriter.Writer, in ForumAuthorResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Nickname != "" {
+		const prefix string = ",\"nickname\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Nickname))
+	}
+	if in.Posts != 0 {
+		const prefix string = ",\"posts\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Posts))
+	}
+	if in.Votes != 0 {
+		const prefix string = ",\"votes\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Votes))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ForumAuthorResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6d8024d9EncodeProjectInternalForumDeliveryModels2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ForumAuthorResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6d8024d9EncodeProjectInternalForumDeliveryModels2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ForumAuthorResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6d8024d9DecodeProjectInternalForumDeliveryModels2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ForumAuthorResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6d8024d9DecodeProjectInternalForumDeliveryModels2(l, v)
+}
+func easyjson6d8024d9DecodeProjectInternalForumDeliveryModels3(in *jlexer.Lexer, out *ForumActivityResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "start":
+			out.Start = string(in.String())
+		case "posts":
+			out.Posts = int64(in.Int64())
+		case "threads":
+			out.Threads = int64(in.Int64())
+		case "users":
+			out.Users = int64(in.Int64())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6d8024d9EncodeProjectInternalForumDeliveryModels3(out *jwriter.Writer, in ForumActivityResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Start != "" {
+		const prefix string = ",\"start\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Start))
+	}
+	if in.Posts != 0 {
+		const prefix string = ",\"posts\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Posts))
+	}
+	if in.Threads != 0 {
+		const prefix string = ",\"threads\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Threads))
+	}
+	if in.Users != 0 {
+		const prefix string = ",\"users\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Users))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ForumActivityResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6d8024d9EncodeProjectInternalForumDeliveryModels3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ForumActivityResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6d8024d9EncodeProjectInternalForumDeliveryModels3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ForumActivityResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6d8024d9DecodeProjectInternalForumDeliveryModels3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ForumActivityResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6d8024d9DecodeProjectInternalForumDeliveryModels3(l, v)
+}
@@ -13,10 +13,12 @@ import (
 //go:generate easyjson -disallow_unknown_fields -omit_empty getthreads.go
 
 type ForumGetThreadsRequest struct {
-	Slug  string
-	Limit int64
-	Since string
-	Desc  bool
+	Slug   string
+	Limit  int64
+	Since  string
+	Desc   bool
+	Sort   string
+	Window string
 }
 
 func NewForumGetThreadsRequest() *ForumGetThreadsRequest {
@@ -71,6 +73,9 @@ func (req *ForumGetThreadsRequest) Bind(r *http.Request) error {
 		req.Desc = true
 	}
 
+	req.Sort = r.FormValue("sort")
+	req.Window = r.FormValue("window")
+
 	return nil
 }
 
@@ -82,9 +87,11 @@ func (req *ForumGetThreadsRequest) GetForum() *models.Forum {
 
 func (req *ForumGetThreadsRequest) GetParams() *pkg.GetThreadsParams {
 	return &pkg.GetThreadsParams{
-		Limit: req.Limit,
-		Since: req.Since,
-		Desc:  req.Desc,
+		Limit:  req.Limit,
+		Since:  req.Since,
+		Desc:   req.Desc,
+		Sort:   req.Sort,
+		Window: req.Window,
 	}
 }
 
@@ -98,6 +105,11 @@ type ForumGetThreadsResponse struct {
 	Message string `json:"message"`
 	Created string `json:"created"`
 	Votes   int64  `json:"votes"`
+
+	LastPostAt     string `json:"lastPostAt,omitempty"`
+	LastPostAuthor string `json:"lastPostAuthor,omitempty"`
+	Replies        int64  `json:"replies,omitempty"`
+	UnreadCount    int64  `json:"unreadCount,omitempty"`
 }
 
 //easyjson:json
@@ -116,6 +128,11 @@ func NewForumGetThreadsResponse(threads []*models.Thread) ThreadsList {
 			Message: value.Message,
 			Created: value.Created,
 			Votes:   value.Votes,
+
+			LastPostAt:     value.LastPostAt,
+			LastPostAuthor: value.LastPostAuthor,
+			Replies:        value.Replies,
+			UnreadCount:    value.Unread,
 		}
 	}
 
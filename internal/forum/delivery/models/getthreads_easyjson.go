@@ -17,7 +17,7 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjsonDbae79bDecodeDbPerformanceProjectInternalForumDeliveryModels(in *jlexer.Lexer, out *ThreadsList) {
+func easyjsonDbae79bDecodeProjectInternalForumDeliveryModels(in *jlexer.Lexer, out *ThreadsList) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		in.Skip()
@@ -45,7 +45,7 @@ func easyjsonDbae79bDecodeDbPerformanceProjectInternalForumDeliveryModels(in *jl
 		in.Consumed()
 	}
 }
-func easyjsonDbae79bEncodeDbPerformanceProjectInternalForumDeliveryModels(out *jwriter.Writer, in ThreadsList) {
+func easyjsonDbae79bEncodeProjectInternalForumDeliveryModels(out *jwriter.Writer, in ThreadsList) {
 	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 		out.RawString("null")
 	} else {
@@ -63,27 +63,27 @@ func easyjsonDbae79bEncodeDbPerformanceProjectInternalForumDeliveryModels(out *j
 // MarshalJSON supports json.Marshaler interface
 func (v ThreadsList) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonDbae79bEncodeDbPerformanceProjectInternalForumDeliveryModels(&w, v)
+	easyjsonDbae79bEncodeProjectInternalForumDeliveryModels(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ThreadsList) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonDbae79bEncodeDbPerformanceProjectInternalForumDeliveryModels(w, v)
+	easyjsonDbae79bEncodeProjectInternalForumDeliveryModels(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ThreadsList) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonDbae79bDecodeDbPerformanceProjectInternalForumDeliveryModels(&r, v)
+	easyjsonDbae79bDecodeProjectInternalForumDeliveryModels(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ThreadsList) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonDbae79bDecodeDbPerformanceProjectInternalForumDeliveryModels(l, v)
+	easyjsonDbae79bDecodeProjectInternalForumDeliveryModels(l, v)
 }
-func easyjsonDbae79bDecodeDbPerformanceProjectInternalForumDeliveryModels1(in *jlexer.Lexer, out *ForumGetThreadsResponse) {
+func easyjsonDbae79bDecodeProjectInternalForumDeliveryModels1(in *jlexer.Lexer, out *ForumGetThreadsResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -118,6 +118,14 @@ func easyjsonDbae79bDecodeDbPerformanceProjectInternalForumDeliveryModels1(in *j
 			out.Created = string(in.String())
 		case "votes":
 			out.Votes = int64(in.Int64())
+		case "lastPostAt":
+			out.LastPostAt = string(in.String())
+		case "lastPostAuthor":
+			out.LastPostAuthor = string(in.String())
+		case "replies":
+			out.Replies = int64(in.Int64())
+		case "unreadCount":
+			out.UnreadCount = int64(in.Int64())
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -132,7 +140,7 @@ func easyjsonDbae79bDecodeDbPerformanceProjectInternalForumDeliveryModels1(in *j
 		in.Consumed()
 	}
 }
-func easyjsonDbae79bEncodeDbPerformanceProjectInternalForumDeliveryModels1(out *jwriter.Writer, in ForumGetThreadsResponse) {
+func easyjsonDbae79bEncodeProjectInternalForumDeliveryModels1(out *jwriter.Writer, in ForumGetThreadsResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -212,29 +220,69 @@ func easyjsonDbae79bEncodeDbPerformanceProjectInternalForumDeliveryModels1(out *
 		}
 		out.Int64(int64(in.Votes))
 	}
+	if in.LastPostAt != "" {
+		const prefix string = ",\"lastPostAt\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.LastPostAt))
+	}
+	if in.LastPostAuthor != "" {
+		const prefix string = ",\"lastPostAuthor\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.LastPostAuthor))
+	}
+	if in.Replies != 0 {
+		const prefix string = ",\"replies\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Replies))
+	}
+	if in.UnreadCount != 0 {
+		const prefix string = ",\"unreadCount\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.UnreadCount))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v ForumGetThreadsResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonDbae79bEncodeDbPerformanceProjectInternalForumDeliveryModels1(&w, v)
+	easyjsonDbae79bEncodeProjectInternalForumDeliveryModels1(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ForumGetThreadsResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonDbae79bEncodeDbPerformanceProjectInternalForumDeliveryModels1(w, v)
+	easyjsonDbae79bEncodeProjectInternalForumDeliveryModels1(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ForumGetThreadsResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonDbae79bDecodeDbPerformanceProjectInternalForumDeliveryModels1(&r, v)
+	easyjsonDbae79bDecodeProjectInternalForumDeliveryModels1(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ForumGetThreadsResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonDbae79bDecodeDbPerformanceProjectInternalForumDeliveryModels1(l, v)
+	easyjsonDbae79bDecodeProjectInternalForumDeliveryModels1(l, v)
 }
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mailru/easyjson"
+
+	"project/internal/models"
+)
+
+//go:generate easyjson -disallow_unknown_fields -omit_empty members.go
+
+//easyjson:json
+type ForumMemberRequest struct {
+	Slug     string
+	Nickname string `json:"nickname"`
+}
+
+func NewForumMemberRequest() *ForumMemberRequest {
+	return &ForumMemberRequest{}
+}
+
+func (req *ForumMemberRequest) Bind(r *http.Request) error {
+	body, _ := io.ReadAll(r.Body)
+
+	if len(body) > 0 {
+		easyjson.Unmarshal(body, req)
+	}
+
+	vars := mux.Vars(r)
+
+	req.Slug = vars["slug"]
+
+	return nil
+}
+
+func (req *ForumMemberRequest) GetMember() *models.ForumMember {
+	return &models.ForumMember{
+		Forum:    req.Slug,
+		Nickname: req.Nickname,
+	}
+}
+
+//easyjson:json
+type ForumMemberResponse struct {
+	Forum     string `json:"forum"`
+	Nickname  string `json:"nickname"`
+	Status    string `json:"status"`
+	InvitedBy string `json:"invitedBy,omitempty"`
+}
+
+func NewForumMemberResponse(member *models.ForumMember) *ForumMemberResponse {
+	return &ForumMemberResponse{
+		Forum:     member.Forum,
+		Nickname:  member.Nickname,
+		Status:    member.Status,
+		InvitedBy: member.InvitedBy,
+	}
+}
@@ -0,0 +1,209 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package models
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonB695bf6bDecodeProjectInternalForumDeliveryModels(in *jlexer.Lexer, out *ForumMemberResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "forum":
+			out.Forum = string(in.String())
+		case "nickname":
+			out.Nickname = string(in.String())
+		case "status":
+			out.Status = string(in.String())
+		case "invitedBy":
+			out.InvitedBy = string(in.String())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonB695bf6bEncodeProjectInternalForumDeliveryModels(out *jwriter.Writer, in ForumMemberResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Forum != "" {
+		const prefix string = ",\"forum\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Forum))
+	}
+	if in.Nickname != "" {
+		const prefix string = ",\"nickname\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Nickname))
+	}
+	if in.Status != "" {
+		const prefix string = ",\"status\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Status))
+	}
+	if in.InvitedBy != "" {
+		const prefix string = ",\"invitedBy\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.InvitedBy))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ForumMemberResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonB695bf6bEncodeProjectInternalForumDeliveryModels(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ForumMemberResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonB695bf6bEncodeProjectInternalForumDeliveryModels(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ForumMemberResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonB695bf6bDecodeProjectInternalForumDeliveryModels(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ForumMemberResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonB695bf6bDecodeProjectInternalForumDeliveryModels(l, v)
+}
+func easyjsonB695bf6bDecodeProjectInternalForumDeliveryModels1(in *jlexer.Lexer, out *ForumMemberRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Slug":
+			out.Slug = string(in.String())
+		case "nickname":
+			out.Nickname = string(in.String())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonB695bf6bEncodeProjectInternalForumDeliveryModels1(out *jwriter.Writer, in ForumMemberRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Slug != "" {
+		const prefix string = ",\"Slug\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Slug))
+	}
+	if in.Nickname != "" {
+		const prefix string = ",\"nickname\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Nickname))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ForumMemberRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonB695bf6bEncodeProjectInternalForumDeliveryModels1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ForumMemberRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonB695bf6bEncodeProjectInternalForumDeliveryModels1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ForumMemberRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonB695bf6bDecodeProjectInternalForumDeliveryModels1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ForumMemberRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonB695bf6bDecodeProjectInternalForumDeliveryModels1(l, v)
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"project/internal/models"
+	"project/internal/pkg"
+	"project/internal/pkg/cache"
+)
+
+// Invalidator drops cached forums whose counters were changed by writes of other repositories.
+type Invalidator interface {
+	InvalidateForum(ctx context.Context, slug string)
+}
+
+// ForumLink is the place of a forum in the tree. It never changes once the forum is created.
+type ForumLink struct {
+	Parent string
+	RollUp bool
+}
+
+// ForumCache serves GetDetailsForumBySlug from the cache, except to reads which must see the latest state. Misses
+// are read from the primary, so that a lagging replica does not put back what was invalidated. The other methods
+// go straight to the repository.
+type ForumCache struct {
+	ForumRepository
+
+	forums cache.Cache[models.Forum]
+	links  cache.Cache[ForumLink]
+}
+
+func NewForumCache(repo ForumRepository, forums cache.Cache[models.Forum], links cache.Cache[ForumLink]) *ForumCache {
+	return &ForumCache{
+		ForumRepository: repo,
+		forums:          forums,
+		links:           links,
+	}
+}
+
+func (f *ForumCache) GetDetailsForumBySlug(ctx context.Context, forum *models.Forum) (*models.Forum, error) {
+	key := strings.ToLower(forum.Slug)
+
+	if !pkg.ReadsPrimary(ctx) {
+		cached, ok := f.forums.Get(key)
+		if ok {
+			*forum = cached
+			return forum, nil
+		}
+	}
+
+	res, err := f.ForumRepository.GetDetailsForumBySlug(pkg.WithPrimaryReads(ctx), forum)
+	if err != nil {
+		return nil, err
+	}
+
+	f.forums.Set(key, *res)
+	f.links.Set(key, ForumLink{Parent: res.Parent, RollUp: res.RollUp})
+
+	return res, nil
+}
+
+// InvalidateForum drops the forum together with the ancestors its counters roll up into, as the triggers of
+// threads and posts update them.
+func (f *ForumCache) InvalidateForum(ctx context.Context, slug string) {
+	for slug != "" {
+		key := strings.ToLower(slug)
+
+		f.forums.Delete(key)
+
+		link, ok := f.links.Get(key)
+		if !ok {
+			forum, err := f.ForumRepository.GetDetailsForumBySlug(ctx, &models.Forum{Slug: slug})
+			if err != nil {
+				return
+			}
+
+			link = ForumLink{Parent: forum.Parent, RollUp: forum.RollUp}
+			f.links.Set(key, link)
+		}
+
+		if !link.RollUp {
+			return
+		}
+
+		slug = link.Parent
+	}
+}
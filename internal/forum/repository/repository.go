@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -18,13 +19,20 @@ type ForumRepository interface {
 	GetDetailsForumBySlug(ctx context.Context, forum *models.Forum) (*models.Forum, error)
 	GetThreads(ctx context.Context, forum *models.Forum, params *pkg.GetThreadsParams) ([]*models.Thread, error)
 	GetUsers(ctx context.Context, forum *models.Forum, params *pkg.GetUsersParams) ([]*models.User, error)
+	GetChildren(ctx context.Context, forum *models.Forum, nickname string) ([]*models.Forum, error)
+	GetBreadcrumb(ctx context.Context, forum *models.Forum) ([]models.Forum, error)
+	GetAccessForum(ctx context.Context, forum *models.Forum, nickname string) (*models.ForumAccess, error)
+	CreateInvite(ctx context.Context, member *models.ForumMember) (*models.ForumMember, error)
+	AcceptInvite(ctx context.Context, member *models.ForumMember) (*models.ForumMember, error)
+	DeleteMember(ctx context.Context, member *models.ForumMember) error
+	GetForumStats(ctx context.Context, forum *models.Forum, params *pkg.GetForumStatsParams) (*models.ForumStats, error)
 }
 
 type forumPostgres struct {
-	conn *sql.DB
+	conn *sqltools.Cluster
 }
 
-func NewForumPostgres(conn *sql.DB) ForumRepository {
+func NewForumPostgres(conn *sqltools.Cluster) ForumRepository {
 	return &forumPostgres{
 		conn,
 	}
@@ -33,7 +41,7 @@ func NewForumPostgres(conn *sql.DB) ForumRepository {
 func (f forumPostgres) CheckExistForum(ctx context.Context, forum *models.Forum) (bool, error) {
 	res := false
 
-	row := f.conn.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM forums WHERE slug = $1);`, forum.Slug)
+	row := f.conn.Primary(ctx).QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM forums WHERE slug = $1);`, forum.Slug)
 	if row.Err() != nil {
 		return false, row.Err()
 	}
@@ -47,21 +55,26 @@ func (f forumPostgres) CheckExistForum(ctx context.Context, forum *models.Forum)
 }
 
 func (f forumPostgres) CreateForum(ctx context.Context, forum *models.Forum) (*models.Forum, error) {
-	errMain := sqltools.RunTxOnConn(ctx, pkg.TxInsertOptions, f.conn, func(ctx context.Context, tx *sql.Tx) error {
-		row := tx.QueryRowContext(ctx, `INSERT INTO forums(title, users_nickname, slug)
-			VALUES ($1, $2, $3);`, forum.Title, forum.User, forum.Slug)
-		if row.Err() != nil {
-			return row.Err()
+	errMain := sqltools.RunTxOnConn(ctx, pkg.TxInsertOptions, f.conn.Write(ctx), func(ctx context.Context, tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `INSERT INTO forums(title, users_nickname, slug, parent, roll_up, visibility)
+			VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6);`, forum.Title, forum.User, forum.Slug, forum.Parent, forum.RollUp, forum.Visibility)
+		if err != nil {
+			return err
 		}
 
-		return nil
+		after, err := sqltools.Snapshot(ctx, tx, `SELECT slug::text, to_jsonb(f) FROM forums f WHERE slug = $1;`, forum.Slug)
+		if err != nil {
+			return err
+		}
+
+		return sqltools.Audit(ctx, tx, pkg.AuditEntityForum, pkg.AuditActionCreate, nil, after)
 	})
 
 	return forum, errMain
 }
 
 func (f forumPostgres) GetDetailsForumBySlug(ctx context.Context, forum *models.Forum) (*models.Forum, error) {
-	row := f.conn.QueryRowContext(ctx, `SELECT title, users_nickname, posts, threads, slug
+	row := f.conn.Replica(ctx).QueryRowContext(ctx, `SELECT title, users_nickname, posts, threads, slug, COALESCE(parent, ''), roll_up, visibility, version
 			FROM forums
 			WHERE slug = $1`, forum.Slug)
 	if row.Err() != nil {
@@ -77,7 +90,11 @@ func (f forumPostgres) GetDetailsForumBySlug(ctx context.Context, forum *models.
 		&forum.User,
 		&forum.Posts,
 		&forum.Threads,
-		&forum.Slug)
+		&forum.Slug,
+		&forum.Parent,
+		&forum.RollUp,
+		&forum.Visibility,
+		&forum.Version)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, pkg.ErrSuchUserNotFound
@@ -89,17 +106,22 @@ func (f forumPostgres) GetDetailsForumBySlug(ctx context.Context, forum *models.
 	return forum, nil
 }
 
+// threadsList selects threads for listings, with $2 the nickname to count unread replies for, empty for none.
+const threadsList = `SELECT t.thread_id, t.title, t.author, t.forum, t.message, t.votes, t.slug, t.created,
+		t.last_post_at, coalesce(t.last_post_author, ''), t.replies,
+		CASE WHEN $2 = '' THEN 0 ELSE t.replies - coalesce(r.replies, 0) END
+	FROM threads AS t
+		LEFT JOIN thread_reads r ON r.thread_id = t.thread_id AND r.nickname = $2 `
+
 func (f forumPostgres) GetThreads(ctx context.Context, forum *models.Forum, params *pkg.GetThreadsParams) ([]*models.Thread, error) {
-	query := `SELECT t.thread_id, t.title, t.author, t.forum, t.message, t.votes, t.slug, t.created
-		FROM threads AS t
-        	LEFT JOIN forums f ON t.forum = f.slug
-		WHERE f.slug = $1 `
+	if params.Sort != "" && params.Sort != pkg.ThreadSortCreated {
+		return f.getRankedThreads(ctx, forum, params)
+	}
 
-	orderBy := "ORDER BY t.created "
-	querySince := " AND t.created >= $2 "
+	query := threadsList + `WHERE t.forum = $1 `
 
-	var rows *sql.Rows
-	var err error
+	orderBy := "ORDER BY t.created "
+	querySince := " AND t.created >= $3 "
 
 	if params.Desc {
 		orderBy += "DESC"
@@ -111,9 +133,9 @@ func (f forumPostgres) GetThreads(ctx context.Context, forum *models.Forum, para
 
 	switch {
 	case params.Since != "" && params.Desc:
-		querySince = " AND t.created <= $2 "
+		querySince = " AND t.created <= $3 "
 	case params.Since != "" && !params.Desc:
-		querySince = " AND t.created >= $2 "
+		querySince = " AND t.created >= $3 "
 	}
 
 	var values []interface{}
@@ -121,21 +143,49 @@ func (f forumPostgres) GetThreads(ctx context.Context, forum *models.Forum, para
 	if params.Since != "" {
 		query += querySince + orderBy
 
-		values = []interface{}{forum.Slug, params.Since}
+		values = []interface{}{forum.Slug, params.Nickname, params.Since}
 	} else {
 		query += orderBy
 
-		values = []interface{}{forum.Slug}
+		values = []interface{}{forum.Slug, params.Nickname}
 	}
 
-	res := make([]*models.Thread, 0)
+	return f.queryThreads(ctx, query, values...)
+}
 
-	rows, err = f.conn.QueryContext(ctx, query, values...)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, pkg.ErrSuchThreadNotFound
+// getRankedThreads lists by the ranking columns kept by the triggers of posts and votes, ignoring since and desc.
+func (f forumPostgres) getRankedThreads(ctx context.Context, forum *models.Forum, params *pkg.GetThreadsParams) ([]*models.Thread, error) {
+	query := threadsList + `WHERE t.forum = $1 `
+
+	values := []interface{}{forum.Slug, params.Nickname}
+
+	switch params.Sort {
+	case pkg.ThreadSortHot:
+		query += "ORDER BY t.hot_score DESC "
+	case pkg.ThreadSortActive:
+		query += "ORDER BY t.last_post_at DESC, t.thread_id DESC "
+	case pkg.ThreadSortTop:
+		if params.Window != pkg.ThreadWindowAll {
+			query += "AND t.created >= now() - ('1 ' || $3)::interval "
+
+			values = append(values, params.Window)
 		}
 
+		query += "ORDER BY t.votes DESC, t.thread_id "
+	}
+
+	if params.Limit > 0 {
+		query += fmt.Sprintf("LIMIT %d", params.Limit)
+	}
+
+	return f.queryThreads(ctx, query, values...)
+}
+
+func (f forumPostgres) queryThreads(ctx context.Context, query string, values ...interface{}) ([]*models.Thread, error) {
+	res := make([]*models.Thread, 0)
+
+	rows, err := f.conn.Replica(ctx).QueryContext(ctx, query, values...)
+	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
@@ -151,7 +201,11 @@ func (f forumPostgres) GetThreads(ctx context.Context, forum *models.Forum, para
 			&thread.Message,
 			&thread.Votes,
 			&thread.Slug,
-			&thread.Created)
+			&thread.Created,
+			&thread.LastPostAt,
+			&thread.LastPostAuthor,
+			&thread.Replies,
+			&thread.Unread)
 		if err != nil {
 			return nil, err
 		}
@@ -159,7 +213,7 @@ func (f forumPostgres) GetThreads(ctx context.Context, forum *models.Forum, para
 		res = append(res, thread)
 	}
 
-	return res, nil
+	return res, rows.Err()
 }
 
 func (f forumPostgres) GetUsers(ctx context.Context, forum *models.Forum, params *pkg.GetUsersParams) ([]*models.User, error) {
@@ -187,7 +241,7 @@ func (f forumPostgres) GetUsers(ctx context.Context, forum *models.Forum, params
 
 	res := make([]*models.User, 0)
 
-	rows, err = f.conn.QueryContext(ctx, query, forum.Slug)
+	rows, err = f.conn.Replica(ctx).QueryContext(ctx, query, forum.Slug)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, pkg.ErrSuchThreadNotFound
@@ -214,3 +268,369 @@ func (f forumPostgres) GetUsers(ctx context.Context, forum *models.Forum, params
 
 	return res, nil
 }
+
+func (f forumPostgres) GetChildren(ctx context.Context, forum *models.Forum, nickname string) ([]*models.Forum, error) {
+	rows, err := f.conn.Replica(ctx).QueryContext(ctx, `SELECT f.title, f.users_nickname, f.posts, f.threads, f.slug, f.parent, f.roll_up, f.visibility
+		FROM forums f
+		WHERE f.parent = $1
+		  AND (f.visibility <> 'private'
+			OR f.users_nickname = $2
+			OR EXISTS(SELECT 1 FROM forum_members m WHERE m.forum = f.slug AND m.nickname = $2 AND m.status = 'member'))
+		ORDER BY f.slug;`, forum.Slug, nickname)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*models.Forum, 0)
+
+	for rows.Next() {
+		child := &models.Forum{}
+
+		err = rows.Scan(
+			&child.Title,
+			&child.User,
+			&child.Posts,
+			&child.Threads,
+			&child.Slug,
+			&child.Parent,
+			&child.RollUp,
+			&child.Visibility)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, child)
+	}
+
+	return res, rows.Err()
+}
+
+// GetBreadcrumb returns ancestors of the forum ordered from the root down to the direct parent.
+func (f forumPostgres) GetBreadcrumb(ctx context.Context, forum *models.Forum) ([]models.Forum, error) {
+	rows, err := f.conn.Replica(ctx).QueryContext(ctx, `WITH RECURSIVE chain AS (
+			SELECT slug, title, parent, 0 AS depth
+			FROM forums
+			WHERE slug = $1
+			UNION ALL
+			SELECT f.slug, f.title, f.parent, c.depth + 1
+			FROM forums f
+				JOIN chain c ON f.slug = c.parent
+		)
+		SELECT slug, title
+		FROM chain
+		WHERE depth > 0
+		ORDER BY depth DESC;`, forum.Slug)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]models.Forum, 0)
+
+	for rows.Next() {
+		ancestor := models.Forum{}
+
+		err = rows.Scan(
+			&ancestor.Slug,
+			&ancestor.Title)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, ancestor)
+	}
+
+	return res, rows.Err()
+}
+
+func (f forumPostgres) GetAccessForum(ctx context.Context, forum *models.Forum, nickname string) (*models.ForumAccess, error) {
+	res := &models.ForumAccess{}
+
+	row := f.conn.Primary(ctx).QueryRowContext(ctx, `SELECT f.visibility,
+			f.users_nickname = $2
+				OR EXISTS(SELECT 1 FROM forum_members m WHERE m.forum = f.slug AND m.nickname = $2 AND m.status = 'member')
+		FROM forums f
+		WHERE f.slug = $1;`, forum.Slug, nickname)
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	err := row.Scan(
+		&res.Visibility,
+		&res.Member)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, pkg.ErrSuchForumNotFound
+		}
+
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (f forumPostgres) CreateInvite(ctx context.Context, member *models.ForumMember) (*models.ForumMember, error) {
+	res := &models.ForumMember{}
+
+	err := sqltools.RunTxOnConn(ctx, pkg.TxInsertOptions, f.conn.Write(ctx), func(ctx context.Context, tx *sql.Tx) error {
+		row := tx.QueryRowContext(ctx, `INSERT INTO forum_members(forum, nickname, status, invited_by)
+			VALUES ($1, $2, 'invited', NULLIF($3, ''))
+			ON CONFLICT DO NOTHING
+			RETURNING forum, nickname, status, COALESCE(invited_by, '');`, member.Forum, member.Nickname, member.InvitedBy)
+		if row.Err() != nil {
+			return row.Err()
+		}
+
+		err := row.Scan(
+			&res.Forum,
+			&res.Nickname,
+			&res.Status,
+			&res.InvitedBy)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return pkg.ErrSuchMemberExist
+			}
+
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (f forumPostgres) AcceptInvite(ctx context.Context, member *models.ForumMember) (*models.ForumMember, error) {
+	res := &models.ForumMember{}
+
+	err := sqltools.RunTxOnConn(ctx, pkg.TxInsertOptions, f.conn.Write(ctx), func(ctx context.Context, tx *sql.Tx) error {
+		row := tx.QueryRowContext(ctx, `UPDATE forum_members
+			SET status = 'member'
+			WHERE forum = $1
+			  AND nickname = $2
+			  AND status = 'invited'
+			RETURNING forum, nickname, status, COALESCE(invited_by, '');`, member.Forum, member.Nickname)
+		if row.Err() != nil {
+			return row.Err()
+		}
+
+		err := row.Scan(
+			&res.Forum,
+			&res.Nickname,
+			&res.Status,
+			&res.InvitedBy)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return pkg.ErrSuchInviteNotFound
+			}
+
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (f forumPostgres) DeleteMember(ctx context.Context, member *models.ForumMember) error {
+	err := sqltools.RunTxOnConn(ctx, pkg.TxInsertOptions, f.conn.Write(ctx), func(ctx context.Context, tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `DELETE FROM forum_members
+			WHERE forum = $1
+			  AND nickname = $2;`, member.Forum, member.Nickname)
+		if err != nil {
+			return err
+		}
+
+		count, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if count == 0 {
+			return pkg.ErrSuchMemberNotFound
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+// GetForumStats reads the rollups kept by the triggers. The leaders are over all time, the activity within the
+// bounds of params.
+func (f forumPostgres) GetForumStats(ctx context.Context, forum *models.Forum, params *pkg.GetForumStatsParams) (*models.ForumStats, error) {
+	res := &models.ForumStats{
+		Forum:  forum.Slug,
+		Period: params.Period,
+	}
+
+	from, to := statsDay(params.From), statsDay(params.To)
+
+	var err error
+
+	res.Activity, err = f.getActivity(ctx, forum, params.Period, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	row := f.conn.Replica(ctx).QueryRowContext(ctx, `SELECT count(DISTINCT nickname)
+		FROM forum_active_users
+		WHERE forum = $1
+		  AND ($2::date IS NULL OR day >= $2::date)
+		  AND ($3::date IS NULL OR day < $3::date);`, forum.Slug, from, to)
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	err = row.Scan(&res.ActiveUsers)
+	if err != nil {
+		return nil, err
+	}
+
+	res.TopPosters, err = f.getTopAuthors(ctx, forum, "posts", params.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	res.TopVoted, err = f.getTopAuthors(ctx, forum, "votes", params.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	res.TopThreads, err = f.getTopThreads(ctx, forum, params.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	row = f.conn.Replica(ctx).QueryRowContext(ctx, `SELECT coalesce(
+			(SELECT depth::float8 / nullif(threads, 0) FROM forum_depths WHERE forum = $1), 0);`, forum.Slug)
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	err = row.Scan(&res.AverageDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (f forumPostgres) getActivity(ctx context.Context, forum *models.Forum, period string, from sql.NullString, to sql.NullString) ([]models.ForumActivity, error) {
+	res := make([]models.ForumActivity, 0)
+
+	rows, err := f.conn.Replica(ctx).QueryContext(ctx, `SELECT to_char(a.start, 'YYYY-MM-DD'), a.posts, a.threads, coalesce(u.users, 0)
+		FROM (SELECT date_trunc($2::text, day::timestamp) AS start, sum(posts) AS posts, sum(threads) AS threads
+			  FROM forum_activity
+			  WHERE forum = $1
+				AND ($3::date IS NULL OR day >= $3::date)
+				AND ($4::date IS NULL OR day < $4::date)
+			  GROUP BY 1) a
+			LEFT JOIN (SELECT date_trunc($2::text, day::timestamp) AS start, count(DISTINCT nickname) AS users
+					   FROM forum_active_users
+					   WHERE forum = $1
+						 AND ($3::date IS NULL OR day >= $3::date)
+						 AND ($4::date IS NULL OR day < $4::date)
+					   GROUP BY 1) u USING (start)
+		ORDER BY a.start;`, forum.Slug, period, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		activity := models.ForumActivity{}
+
+		err = rows.Scan(
+			&activity.Start,
+			&activity.Posts,
+			&activity.Threads,
+			&activity.Users)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, activity)
+	}
+
+	return res, rows.Err()
+}
+
+// getTopAuthors orders by column, posts or votes, leaving out the authors with none.
+func (f forumPostgres) getTopAuthors(ctx context.Context, forum *models.Forum, column string, limit int64) ([]models.ForumAuthor, error) {
+	res := make([]models.ForumAuthor, 0)
+
+	rows, err := f.conn.Replica(ctx).QueryContext(ctx, fmt.Sprintf(`SELECT nickname, posts, votes
+		FROM forum_authors
+		WHERE forum = $1
+		  AND %[1]s > 0
+		ORDER BY %[1]s DESC, nickname
+		LIMIT $2;`, column), forum.Slug, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		author := models.ForumAuthor{}
+
+		err = rows.Scan(
+			&author.Nickname,
+			&author.Posts,
+			&author.Votes)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, author)
+	}
+
+	return res, rows.Err()
+}
+
+func (f forumPostgres) getTopThreads(ctx context.Context, forum *models.Forum, limit int64) ([]models.Thread, error) {
+	res := make([]models.Thread, 0)
+
+	rows, err := f.conn.Replica(ctx).QueryContext(ctx, `SELECT thread_id, title, author, forum, message, votes, coalesce(slug, ''), created
+		FROM threads
+		WHERE forum = $1
+		ORDER BY votes DESC, thread_id
+		LIMIT $2;`, forum.Slug, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		thread := models.Thread{}
+
+		err = rows.Scan(
+			&thread.ID,
+			&thread.Title,
+			&thread.Author,
+			&thread.Forum,
+			&thread.Message,
+			&thread.Votes,
+			&thread.Slug,
+			&thread.Created)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, thread)
+	}
+
+	return res, rows.Err()
+}
+
+func statsDay(value time.Time) sql.NullString {
+	return sql.NullString{String: value.Format("2006-01-02"), Valid: !value.IsZero()}
+}
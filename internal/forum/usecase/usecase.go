@@ -16,6 +16,11 @@ type ForumService interface {
 	GetDetailsForum(ctx context.Context, forum *models.Forum) (*models.Forum, error)
 	GetThreads(ctx context.Context, forum *models.Forum, params *pkg.GetThreadsParams) ([]*models.Thread, error)
 	GetUsers(ctx context.Context, forum *models.Forum, params *pkg.GetUsersParams) ([]*models.User, error)
+	GetChildren(ctx context.Context, forum *models.Forum) ([]*models.Forum, error)
+	InviteMember(ctx context.Context, member *models.ForumMember) (*models.ForumMember, error)
+	AcceptInvite(ctx context.Context, member *models.ForumMember) (*models.ForumMember, error)
+	LeaveForum(ctx context.Context, member *models.ForumMember) error
+	GetStats(ctx context.Context, forum *models.Forum, params *pkg.GetForumStatsParams) (*models.ForumStats, error)
 }
 
 type forumService struct {
@@ -30,7 +35,29 @@ func NewForumService(rf repoForum.ForumRepository, ru repoUser.UserRepository) F
 	}
 }
 
+// checkReadAccess hides private forums from non-members as if they did not exist.
+func (f forumService) checkReadAccess(ctx context.Context, forum *models.Forum) error {
+	access, err := f.forumRepo.GetAccessForum(ctx, forum, pkg.GetNickname(ctx))
+	if err != nil {
+		return err
+	}
+
+	if !pkg.CanReadForum(access) {
+		return pkg.ErrSuchForumNotFound
+	}
+
+	return nil
+}
+
 func (f forumService) CreateForum(ctx context.Context, forum *models.Forum) (*models.Forum, error) {
+	switch forum.Visibility {
+	case "":
+		forum.Visibility = pkg.ForumVisibilityPublic
+	case pkg.ForumVisibilityPublic, pkg.ForumVisibilityPrivate, pkg.ForumVisibilityReadOnly:
+	default:
+		return nil, errors.Wrap(pkg.ErrBadRequestParams, "CreateForum")
+	}
+
 	res, err := f.forumRepo.GetDetailsForumBySlug(ctx, forum)
 	if err == nil {
 		return res, errors.Wrap(pkg.ErrSuchForumExist, "CreateForum")
@@ -43,6 +70,22 @@ func (f forumService) CreateForum(ctx context.Context, forum *models.Forum) (*mo
 
 	forum.User = user.Nickname
 
+	if forum.Parent != "" {
+		var parent *models.Forum
+
+		parent, err = f.forumRepo.GetDetailsForumBySlug(ctx, &models.Forum{Slug: forum.Parent})
+		if err != nil {
+			return nil, errors.Wrap(pkg.ErrSuchParentForumNotFound, "CreateForum")
+		}
+
+		err = f.checkReadAccess(ctx, parent)
+		if err != nil {
+			return nil, errors.Wrap(pkg.ErrSuchParentForumNotFound, "CreateForum")
+		}
+
+		forum.Parent = parent.Slug
+	}
+
 	res, err = f.forumRepo.CreateForum(ctx, forum)
 	if err != nil {
 		_, err = f.userRepo.GetUserByNickname(ctx, &models.User{Nickname: forum.User})
@@ -60,15 +103,45 @@ func (f forumService) GetDetailsForum(ctx context.Context, forum *models.Forum)
 		return nil, errors.Wrap(err, "GetDetailsForumBySlug")
 	}
 
+	if res.Visibility != pkg.ForumVisibilityPublic {
+		err = f.checkReadAccess(ctx, res)
+		if err != nil {
+			return nil, errors.Wrap(err, "GetDetailsForumBySlug")
+		}
+	}
+
+	if res.Parent != "" {
+		res.Breadcrumb, err = f.forumRepo.GetBreadcrumb(ctx, res)
+		if err != nil {
+			return nil, errors.Wrap(err, "GetBreadcrumb")
+		}
+	}
+
 	return res, nil
 }
 
 func (f forumService) GetThreads(ctx context.Context, forum *models.Forum, params *pkg.GetThreadsParams) ([]*models.Thread, error) {
-	exist, _ := f.forumRepo.CheckExistForum(ctx, forum)
-	if !exist {
+	switch params.Sort {
+	case "", pkg.ThreadSortCreated, pkg.ThreadSortHot, pkg.ThreadSortActive:
+	case pkg.ThreadSortTop:
+		switch params.Window {
+		case "":
+			params.Window = pkg.ThreadWindowAll
+		case pkg.ThreadWindowDay, pkg.ThreadWindowWeek, pkg.ThreadWindowMonth, pkg.ThreadWindowYear, pkg.ThreadWindowAll:
+		default:
+			return nil, errors.Wrap(pkg.ErrBadRequestParams, "GetThreads")
+		}
+	default:
+		return nil, errors.Wrap(pkg.ErrBadRequestParams, "GetThreads")
+	}
+
+	err := f.checkReadAccess(ctx, forum)
+	if err != nil {
 		return nil, errors.Wrap(pkg.ErrSuchForumNotFound, "GetThreads")
 	}
 
+	params.Nickname = pkg.GetNickname(ctx)
+
 	res, err := f.forumRepo.GetThreads(ctx, forum, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "GetThreads")
@@ -78,9 +151,9 @@ func (f forumService) GetThreads(ctx context.Context, forum *models.Forum, param
 }
 
 func (f forumService) GetUsers(ctx context.Context, forum *models.Forum, params *pkg.GetUsersParams) ([]*models.User, error) {
-	exist, _ := f.forumRepo.CheckExistForum(ctx, forum)
-	if !exist {
-		return nil, errors.Wrap(pkg.ErrSuchForumNotFound, "GetThreads")
+	err := f.checkReadAccess(ctx, forum)
+	if err != nil {
+		return nil, errors.Wrap(pkg.ErrSuchForumNotFound, "GetUsers")
 	}
 
 	res, err := f.forumRepo.GetUsers(ctx, forum, params)
@@ -90,3 +163,108 @@ func (f forumService) GetUsers(ctx context.Context, forum *models.Forum, params
 
 	return res, nil
 }
+
+func (f forumService) GetChildren(ctx context.Context, forum *models.Forum) ([]*models.Forum, error) {
+	err := f.checkReadAccess(ctx, forum)
+	if err != nil {
+		return nil, errors.Wrap(pkg.ErrSuchForumNotFound, "GetChildren")
+	}
+
+	res, err := f.forumRepo.GetChildren(ctx, forum, pkg.GetNickname(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "GetChildren")
+	}
+
+	return res, nil
+}
+
+func (f forumService) InviteMember(ctx context.Context, member *models.ForumMember) (*models.ForumMember, error) {
+	member.InvitedBy = pkg.GetNickname(ctx)
+	if member.InvitedBy == "" {
+		return nil, errors.Wrap(pkg.ErrAuthRequired, "InviteMember")
+	}
+
+	forum, err := f.forumRepo.GetDetailsForumBySlug(ctx, &models.Forum{Slug: member.Forum})
+	if err != nil {
+		return nil, errors.Wrap(err, "InviteMember")
+	}
+
+	err = f.checkReadAccess(ctx, forum)
+	if err != nil {
+		return nil, errors.Wrap(err, "InviteMember")
+	}
+
+	// Only the owner manages the member list
+	if !pkg.EqualNicknames(forum.User, member.InvitedBy) {
+		return nil, errors.Wrap(pkg.ErrForumAccessDenied, "InviteMember")
+	}
+
+	user, err := f.userRepo.GetUserByNickname(ctx, &models.User{Nickname: member.Nickname})
+	if err != nil {
+		return nil, errors.Wrap(err, "InviteMember")
+	}
+
+	member.Forum = forum.Slug
+	member.Nickname = user.Nickname
+
+	res, err := f.forumRepo.CreateInvite(ctx, member)
+	if err != nil {
+		return nil, errors.Wrap(err, "InviteMember")
+	}
+
+	return res, nil
+}
+
+func (f forumService) AcceptInvite(ctx context.Context, member *models.ForumMember) (*models.ForumMember, error) {
+	member.Nickname = pkg.GetNickname(ctx)
+	if member.Nickname == "" {
+		return nil, errors.Wrap(pkg.ErrAuthRequired, "AcceptInvite")
+	}
+
+	res, err := f.forumRepo.AcceptInvite(ctx, member)
+	if err != nil {
+		return nil, errors.Wrap(err, "AcceptInvite")
+	}
+
+	return res, nil
+}
+
+func (f forumService) LeaveForum(ctx context.Context, member *models.ForumMember) error {
+	member.Nickname = pkg.GetNickname(ctx)
+	if member.Nickname == "" {
+		return errors.Wrap(pkg.ErrAuthRequired, "LeaveForum")
+	}
+
+	err := f.forumRepo.DeleteMember(ctx, member)
+	if err != nil {
+		return errors.Wrap(err, "LeaveForum")
+	}
+
+	return nil
+}
+
+func (f forumService) GetStats(ctx context.Context, forum *models.Forum, params *pkg.GetForumStatsParams) (*models.ForumStats, error) {
+	switch params.Period {
+	case "":
+		params.Period = pkg.StatsPeriodDay
+	case pkg.StatsPeriodDay, pkg.StatsPeriodWeek:
+	default:
+		return nil, errors.Wrap(pkg.ErrBadRequestParams, "GetStats")
+	}
+
+	if params.Limit <= 0 {
+		return nil, errors.Wrap(pkg.ErrBadRequestParams, "GetStats")
+	}
+
+	err := f.checkReadAccess(ctx, forum)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetStats")
+	}
+
+	res, err := f.forumRepo.GetForumStats(ctx, forum, params)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetStats")
+	}
+
+	return res, nil
+}
@@ -0,0 +1,40 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/graph-gophers/graphql-go"
+
+	"project/internal/graphql/delivery/models"
+	"project/internal/graphql/resolver"
+	"project/internal/pkg"
+)
+
+type GraphQLHandler struct {
+	resolver *resolver.Resolver
+	schema   *graphql.Schema
+}
+
+// GraphQLHandler answers with 200 whenever the query was executed, errors of single fields included,
+// as GraphQL clients expect.
+func (h *GraphQLHandler) GraphQLHandler(w http.ResponseWriter, r *http.Request) {
+	request := models.NewGraphQLRequest()
+
+	err := request.Bind(r)
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	ctx := h.resolver.WithLoaders(r.Context())
+
+	res := h.schema.Exec(ctx, request.Query, request.OperationName, request.Variables)
+
+	pkg.Response(r.Context(), w, http.StatusOK, models.NewGraphQLResponse(res))
+}
+
+func NewGraphQLHandler(resolver *resolver.Resolver, schema *graphql.Schema, r *mux.Router) *GraphQLHandler {
+	h := &GraphQLHandler{resolver: resolver, schema: schema}
+	return h
+}
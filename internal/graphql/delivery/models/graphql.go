@@ -0,0 +1,66 @@
+package models
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/mailru/easyjson"
+	"github.com/mailru/easyjson/jwriter"
+
+	"project/internal/pkg"
+)
+
+//go:generate easyjson -disallow_unknown_fields -omit_empty graphql.go
+
+//easyjson:json
+type GraphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+func NewGraphQLRequest() *GraphQLRequest {
+	return &GraphQLRequest{}
+}
+
+// Bind reads the query from the body of POST or, for GET, from the query string.
+func (req *GraphQLRequest) Bind(r *http.Request) error {
+	if r.Method == http.MethodGet {
+		req.Query = r.FormValue("query")
+		req.OperationName = r.FormValue("operationName")
+
+		variables := r.FormValue("variables")
+		if variables != "" && json.Unmarshal([]byte(variables), &req.Variables) != nil {
+			return pkg.ErrBadRequestParams
+		}
+	} else {
+		body, _ := io.ReadAll(r.Body)
+
+		err := easyjson.Unmarshal(body, req)
+		if err != nil {
+			return pkg.ErrBadBodyRequest
+		}
+	}
+
+	if req.Query == "" {
+		return pkg.ErrEmptyBody
+	}
+
+	return nil
+}
+
+// GraphQLResponse passes the result of the executor, which brings its own JSON encoding, to pkg.Response.
+type GraphQLResponse struct {
+	Response *graphql.Response
+}
+
+func NewGraphQLResponse(res *graphql.Response) *GraphQLResponse {
+	return &GraphQLResponse{Response: res}
+}
+
+func (res *GraphQLResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	out, err := json.Marshal(res.Response)
+	w.Raw(out, err)
+}
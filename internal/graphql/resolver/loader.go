@@ -0,0 +1,100 @@
+package resolver
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	loaderWait     = 2 * time.Millisecond
+	loaderMaxBatch = 100
+)
+
+type loaderResult[V any] struct {
+	value V
+	err   error
+	done  chan struct{}
+}
+
+// fetchFunc returns the values and the errors of the keys, in their order.
+type fetchFunc[V any] func(ctx context.Context, keys []string) ([]V, []error)
+
+// loader collects the keys asked for by concurrent resolvers during a short window and fetches them with
+// a single call, so that lists of items do not cost a query per item. Results are kept for the request.
+// Keys are case-insensitive, like nicknames and slugs.
+type loader[V any] struct {
+	fetch fetchFunc[V]
+
+	mu      sync.Mutex
+	results map[string]*loaderResult[V]
+	pending []string
+}
+
+func newLoader[V any](fetch fetchFunc[V]) *loader[V] {
+	return &loader[V]{
+		fetch:   fetch,
+		results: make(map[string]*loaderResult[V]),
+	}
+}
+
+func (l *loader[V]) Load(ctx context.Context, key string) (V, error) {
+	key = strings.ToLower(key)
+
+	l.mu.Lock()
+
+	result, ok := l.results[key]
+	if !ok {
+		result = &loaderResult[V]{done: make(chan struct{})}
+		l.results[key] = result
+
+		l.pending = append(l.pending, key)
+
+		switch len(l.pending) {
+		case 1:
+			go func() {
+				time.Sleep(loaderWait)
+				l.dispatch(ctx)
+			}()
+		case loaderMaxBatch:
+			go l.dispatch(ctx)
+		}
+	}
+
+	l.mu.Unlock()
+
+	select {
+	case <-result.done:
+		return result.value, result.err
+	case <-ctx.Done():
+		var empty V
+
+		return empty, ctx.Err()
+	}
+}
+
+func (l *loader[V]) dispatch(ctx context.Context) {
+	l.mu.Lock()
+	keys := l.pending
+	l.pending = nil
+	l.mu.Unlock()
+
+	if len(keys) == 0 {
+		return
+	}
+
+	values, errs := l.fetch(ctx, keys)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for idx, key := range keys {
+		result := l.results[key]
+
+		result.value = values[idx]
+		result.err = errs[idx]
+
+		close(result.done)
+	}
+}
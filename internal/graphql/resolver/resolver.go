@@ -0,0 +1,203 @@
+package resolver
+
+import (
+	"context"
+	_ "embed"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/pkg/errors"
+
+	usecaseForum "project/internal/forum/usecase"
+	"project/internal/models"
+	"project/internal/pkg"
+	usecasePost "project/internal/post/usecase"
+	usecaseThread "project/internal/thread/usecase"
+	usecaseUser "project/internal/user/usecase"
+	usecaseVote "project/internal/vote/usecase"
+)
+
+//go:embed schema.graphql
+var schema string
+
+const (
+	maxDepth       = 10
+	maxParallelism = 20
+)
+
+type loadersKey struct{}
+
+type loaders struct {
+	users   *loader[models.User]
+	forums  *loader[*models.Forum]
+	threads *loader[models.Thread]
+}
+
+type Resolver struct {
+	forumUsecase  usecaseForum.ForumService
+	threadUsecase usecaseThread.ThreadService
+	postUsecase   usecasePost.PostService
+	userUsecase   usecaseUser.UserService
+	voteUsecase   usecaseVote.VoteService
+}
+
+func NewResolver(fu usecaseForum.ForumService, tu usecaseThread.ThreadService, pu usecasePost.PostService, uu usecaseUser.UserService, vu usecaseVote.VoteService) *Resolver {
+	return &Resolver{
+		forumUsecase:  fu,
+		threadUsecase: tu,
+		postUsecase:   pu,
+		userUsecase:   uu,
+		voteUsecase:   vu,
+	}
+}
+
+func NewSchema(r *Resolver) (*graphql.Schema, error) {
+	return graphql.ParseSchema(schema, r,
+		graphql.UseFieldResolvers(),
+		graphql.MaxDepth(maxDepth),
+		graphql.MaxParallelism(maxParallelism))
+}
+
+// WithLoaders prepares the loaders of a request. They cache what they load, so they must not outlive it.
+func (r *Resolver) WithLoaders(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loadersKey{}, &loaders{
+		users:   newLoader(r.fetchUsers),
+		forums:  newLoader(r.fetchForums),
+		threads: newLoader(r.fetchThreads),
+	})
+}
+
+func getLoaders(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// fetchUsers reads all the users with one query.
+func (r *Resolver) fetchUsers(ctx context.Context, keys []string) ([]models.User, []error) {
+	values := make([]models.User, len(keys))
+	errs := make([]error, len(keys))
+
+	users, err := r.userUsecase.GetProfiles(ctx, keys)
+
+	found := make(map[string]models.User, len(users))
+	for _, user := range users {
+		found[strings.ToLower(user.Nickname)] = user
+	}
+
+	for idx, key := range keys {
+		user, ok := found[key]
+
+		switch {
+		case err != nil:
+			errs[idx] = err
+		case !ok:
+			errs[idx] = pkg.ErrSuchUserNotFound
+		default:
+			values[idx] = user
+		}
+	}
+
+	return values, errs
+}
+
+// fetchForums goes through the usecase forum by forum, for the access checks; the loader still saves
+// the repeated ones.
+func (r *Resolver) fetchForums(ctx context.Context, keys []string) ([]*models.Forum, []error) {
+	values := make([]*models.Forum, len(keys))
+	errs := make([]error, len(keys))
+
+	for idx, key := range keys {
+		values[idx], errs[idx] = r.forumUsecase.GetDetailsForum(ctx, &models.Forum{Slug: key})
+	}
+
+	return values, errs
+}
+
+func (r *Resolver) fetchThreads(ctx context.Context, keys []string) ([]models.Thread, []error) {
+	values := make([]models.Thread, len(keys))
+	errs := make([]error, len(keys))
+
+	for idx, key := range keys {
+		id, err := strconv.ParseInt(key, 10, 64)
+		if err != nil {
+			values[idx], errs[idx] = r.threadUsecase.GetDetailsThread(ctx, &models.Thread{Slug: key})
+			continue
+		}
+
+		values[idx], errs[idx] = r.threadUsecase.GetDetailsThread(ctx, &models.Thread{ID: id})
+	}
+
+	return values, errs
+}
+
+// notFound turns missing entities into nulls, as GraphQL clients expect, keeping other errors.
+func notFound(err error) error {
+	code, _ := pkg.GetErrorCodeHTTP(errors.Cause(err))
+	if code == http.StatusNotFound {
+		return nil
+	}
+
+	return err
+}
+
+func (r *Resolver) User(ctx context.Context, args struct{ Nickname string }) (*userResolver, error) {
+	user, err := getLoaders(ctx).users.Load(ctx, args.Nickname)
+	if err != nil {
+		return nil, notFound(err)
+	}
+
+	return &userResolver{user: user}, nil
+}
+
+func (r *Resolver) Forum(ctx context.Context, args struct{ Slug string }) (*forumResolver, error) {
+	forum, err := getLoaders(ctx).forums.Load(ctx, args.Slug)
+	if err != nil {
+		return nil, notFound(err)
+	}
+
+	return &forumResolver{root: r, forum: forum}, nil
+}
+
+func (r *Resolver) Thread(ctx context.Context, args struct{ SlugOrID string }) (*threadResolver, error) {
+	thread, err := getLoaders(ctx).threads.Load(ctx, args.SlugOrID)
+	if err != nil {
+		return nil, notFound(err)
+	}
+
+	return &threadResolver{root: r, thread: thread}, nil
+}
+
+func (r *Resolver) Post(ctx context.Context, args struct{ ID int32 }) (*postResolver, error) {
+	details, err := r.postUsecase.GetDetailsPost(ctx, &models.Post{ID: int64(args.ID)}, &pkg.PostDetailsParams{})
+	if err != nil {
+		return nil, notFound(err)
+	}
+
+	return &postResolver{root: r, post: details.Post}, nil
+}
+
+func (r *Resolver) Vote(ctx context.Context, args struct {
+	Thread string
+	Vote   struct {
+		Nickname string
+		Voice    int32
+	}
+}) (*threadResolver, error) {
+	thread := &models.Thread{Slug: args.Thread}
+
+	id, err := strconv.ParseInt(args.Thread, 10, 64)
+	if err == nil {
+		thread = &models.Thread{ID: id}
+	}
+
+	res, err := r.voteUsecase.Vote(ctx, thread, &pkg.VoteParams{
+		Nickname: args.Vote.Nickname,
+		Voice:    int64(args.Vote.Voice),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &threadResolver{root: r, thread: res}, nil
+}
@@ -0,0 +1,259 @@
+package resolver
+
+import (
+	"context"
+	"strconv"
+
+	"project/internal/models"
+	"project/internal/pkg"
+)
+
+type userResolver struct {
+	user models.User
+}
+
+func (u *userResolver) Nickname() string {
+	return u.user.Nickname
+}
+
+func (u *userResolver) Fullname() string {
+	return u.user.FullName
+}
+
+func (u *userResolver) About() string {
+	return u.user.About
+}
+
+func (u *userResolver) Email() string {
+	return u.user.Email
+}
+
+func loadUser(ctx context.Context, nickname string) (*userResolver, error) {
+	user, err := getLoaders(ctx).users.Load(ctx, nickname)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userResolver{user: user}, nil
+}
+
+type forumResolver struct {
+	root  *Resolver
+	forum *models.Forum
+}
+
+func (f *forumResolver) Slug() string {
+	return f.forum.Slug
+}
+
+func (f *forumResolver) Title() string {
+	return f.forum.Title
+}
+
+func (f *forumResolver) User(ctx context.Context) (*userResolver, error) {
+	return loadUser(ctx, f.forum.User)
+}
+
+func (f *forumResolver) PostCount() int32 {
+	return int32(f.forum.Posts)
+}
+
+func (f *forumResolver) ThreadCount() int32 {
+	return int32(f.forum.Threads)
+}
+
+func (f *forumResolver) Visibility() string {
+	return f.forum.Visibility
+}
+
+func (f *forumResolver) Parent(ctx context.Context) (*forumResolver, error) {
+	if f.forum.Parent == "" {
+		return nil, nil
+	}
+
+	forum, err := getLoaders(ctx).forums.Load(ctx, f.forum.Parent)
+	if err != nil {
+		return nil, notFound(err)
+	}
+
+	return &forumResolver{root: f.root, forum: forum}, nil
+}
+
+func (f *forumResolver) Children(ctx context.Context) ([]*forumResolver, error) {
+	forums, err := f.root.forumUsecase.GetChildren(ctx, f.forum)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*forumResolver, len(forums))
+	for idx, forum := range forums {
+		res[idx] = &forumResolver{root: f.root, forum: forum}
+	}
+
+	return res, nil
+}
+
+type listArgs struct {
+	Limit int32
+	Since string
+	Desc  bool
+}
+
+func (f *forumResolver) Threads(ctx context.Context, args listArgs) ([]*threadResolver, error) {
+	threads, err := f.root.forumUsecase.GetThreads(ctx, f.forum, &pkg.GetThreadsParams{
+		Limit: int64(args.Limit),
+		Since: args.Since,
+		Desc:  args.Desc,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*threadResolver, len(threads))
+	for idx, thread := range threads {
+		res[idx] = &threadResolver{root: f.root, thread: *thread}
+	}
+
+	return res, nil
+}
+
+func (f *forumResolver) Users(ctx context.Context, args listArgs) ([]*userResolver, error) {
+	users, err := f.root.forumUsecase.GetUsers(ctx, f.forum, &pkg.GetUsersParams{
+		Limit: int64(args.Limit),
+		Since: args.Since,
+		Desc:  args.Desc,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*userResolver, len(users))
+	for idx, user := range users {
+		res[idx] = &userResolver{user: *user}
+	}
+
+	return res, nil
+}
+
+type threadResolver struct {
+	root   *Resolver
+	thread models.Thread
+}
+
+func (t *threadResolver) ID() int32 {
+	return int32(t.thread.ID)
+}
+
+func (t *threadResolver) Title() string {
+	return t.thread.Title
+}
+
+func (t *threadResolver) Author(ctx context.Context) (*userResolver, error) {
+	return loadUser(ctx, t.thread.Author)
+}
+
+func (t *threadResolver) Forum(ctx context.Context) (*forumResolver, error) {
+	forum, err := getLoaders(ctx).forums.Load(ctx, t.thread.Forum)
+	if err != nil {
+		return nil, err
+	}
+
+	return &forumResolver{root: t.root, forum: forum}, nil
+}
+
+func (t *threadResolver) Slug() *string {
+	if t.thread.Slug == "" {
+		return nil
+	}
+
+	return &t.thread.Slug
+}
+
+func (t *threadResolver) Message() string {
+	return t.thread.Message
+}
+
+func (t *threadResolver) Created() string {
+	return t.thread.Created
+}
+
+func (t *threadResolver) Votes() int32 {
+	return int32(t.thread.Votes)
+}
+
+func (t *threadResolver) Posts(ctx context.Context, args struct {
+	Limit int32
+	Since int32
+	Sort  string
+	Desc  bool
+}) ([]*postResolver, error) {
+	posts, err := t.root.threadUsecase.GetPosts(ctx, &models.Thread{ID: t.thread.ID}, &pkg.GetPostsParams{
+		Limit: int64(args.Limit),
+		Since: int64(args.Since),
+		Desc:  args.Desc,
+		Sort:  args.Sort,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*postResolver, len(posts))
+	for idx := range posts {
+		res[idx] = &postResolver{root: t.root, post: posts[idx]}
+	}
+
+	return res, nil
+}
+
+type postResolver struct {
+	root *Resolver
+	post models.Post
+}
+
+func (p *postResolver) ID() int32 {
+	return int32(p.post.ID)
+}
+
+func (p *postResolver) Parent() *int32 {
+	if p.post.Parent == 0 {
+		return nil
+	}
+
+	parent := int32(p.post.Parent)
+
+	return &parent
+}
+
+func (p *postResolver) Author(ctx context.Context) (*userResolver, error) {
+	return loadUser(ctx, p.post.Author.Nickname)
+}
+
+func (p *postResolver) Message() string {
+	return p.post.Message
+}
+
+func (p *postResolver) IsEdited() bool {
+	return p.post.IsEdited
+}
+
+func (p *postResolver) Forum(ctx context.Context) (*forumResolver, error) {
+	forum, err := getLoaders(ctx).forums.Load(ctx, p.post.Forum)
+	if err != nil {
+		return nil, err
+	}
+
+	return &forumResolver{root: p.root, forum: forum}, nil
+}
+
+func (p *postResolver) Thread(ctx context.Context) (*threadResolver, error) {
+	thread, err := getLoaders(ctx).threads.Load(ctx, strconv.FormatInt(p.post.Thread, 10))
+	if err != nil {
+		return nil, err
+	}
+
+	return &threadResolver{root: p.root, thread: thread}, nil
+}
+
+func (p *postResolver) Created() string {
+	return p.post.Created
+}
@@ -0,0 +1,91 @@
+package loadgen
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/mailru/easyjson"
+	"github.com/pkg/errors"
+
+	"project/internal/pkg"
+)
+
+// Client calls the REST API of a server, either a running one or an httptest one in the process.
+type Client struct {
+	baseURL string
+	http    *http.Client
+}
+
+// NewClient calls the API under baseURL, as in http://localhost:5000. A nil client is http.DefaultClient.
+func NewClient(baseURL string, client *http.Client) *Client {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		http:    client,
+	}
+}
+
+// Do sends body, if any, to the path and decodes the answer into out, if any. The body of the answer is read to the
+// end either way, for the connection to be reused. Only transport errors are returned, the status is left to the
+// caller.
+func (c *Client) Do(ctx context.Context, method string, path string, body easyjson.Marshaler, out easyjson.Unmarshaler) (int, error) {
+	var reader io.Reader
+
+	if body != nil {
+		data, err := easyjson.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return 0, err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", pkg.ContentTypeJSON)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, err
+	}
+
+	if out != nil && resp.StatusCode < http.StatusBadRequest {
+		err = easyjson.Unmarshal(data, out)
+		if err != nil {
+			return resp.StatusCode, errors.Wrapf(err, "%s %s", method, path)
+		}
+	}
+
+	return resp.StatusCode, nil
+}
+
+// mustDo is Do for the calls which have to succeed, turning an unexpected status into an error.
+func (c *Client) mustDo(ctx context.Context, method string, path string, body easyjson.Marshaler, out easyjson.Unmarshaler) error {
+	status, err := c.Do(ctx, method, path, body, out)
+	if err != nil {
+		return err
+	}
+
+	if status >= http.StatusBadRequest {
+		return errors.Errorf("%s %s: status %d", method, path, status)
+	}
+
+	return nil
+}
@@ -0,0 +1,385 @@
+package loadgen
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	forumModels "project/internal/forum/delivery/models"
+	threadModels "project/internal/thread/delivery/models"
+	userModels "project/internal/user/delivery/models"
+	voteModels "project/internal/vote/delivery/models"
+)
+
+const (
+	// usersPerBatch is how many users are created by a call of the batch endpoint.
+	usersPerBatch = 1000
+
+	// zipfSkew is how steeply activity falls from the most active users, forums and threads to the others.
+	zipfSkew = 1.2
+
+	// fillSpan is how far back in time the threads are spread.
+	fillSpan = 30 * 24 * time.Hour
+)
+
+// FillOptions sizes the data created by Fill. Prefix keeps the names of a fill apart from those of previous ones,
+// so that the same database can be filled again.
+type FillOptions struct {
+	Prefix  string
+	Seed    int64
+	Workers int
+
+	Users   int
+	Forums  int
+	Threads int
+	Posts   int
+	Votes   int
+
+	// PostsPerBatch is how many posts of a thread are created by a call. A post replies to the posts of the calls
+	// before, so smaller batches make deeper trees.
+	PostsPerBatch int
+	// MaxDepth bounds the nesting of the posts.
+	MaxDepth int
+}
+
+func DefaultFillOptions() FillOptions {
+	return FillOptions{
+		Prefix:        "load" + strconv.FormatInt(time.Now().Unix(), 36),
+		Seed:          1,
+		Workers:       8,
+		Users:         1000,
+		Forums:        20,
+		Threads:       2000,
+		Posts:         50000,
+		Votes:         10000,
+		PostsPerBatch: 20,
+		MaxDepth:      40,
+	}
+}
+
+// Dataset is what Fill created, for the calls replayed by Run to pick from.
+type Dataset struct {
+	Users   []string  `json:"users"`
+	Forums  []string  `json:"forums"`
+	Threads []int64   `json:"threads"`
+	Posts   []PostRef `json:"posts"`
+}
+
+type PostRef struct {
+	ID     int64 `json:"id"`
+	Thread int64 `json:"thread"`
+}
+
+// Fill creates users, forums, threads, posts and votes through the API. Most of the activity goes to a few users,
+// forums and threads, as on a real forum, and the posts of a thread form trees nested up to MaxDepth.
+func Fill(ctx context.Context, client *Client, opts FillOptions) (*Dataset, error) {
+	if opts.Users <= 0 || opts.Forums <= 0 || opts.Threads <= 0 {
+		return nil, fmt.Errorf("fill needs at least a user, a forum and a thread")
+	}
+
+	if opts.Workers <= 0 {
+		opts.Workers = 1
+	}
+
+	if opts.PostsPerBatch <= 0 {
+		opts.PostsPerBatch = 1
+	}
+
+	r := rand.New(rand.NewSource(opts.Seed))
+
+	res := &Dataset{
+		Users:   make([]string, opts.Users),
+		Forums:  make([]string, opts.Forums),
+		Threads: make([]int64, opts.Threads),
+	}
+
+	for idx := range res.Users {
+		res.Users[idx] = fmt.Sprintf("%s.u%d", opts.Prefix, idx)
+	}
+
+	for idx := range res.Forums {
+		res.Forums[idx] = fmt.Sprintf("%s-f%d", opts.Prefix, idx)
+	}
+
+	logrus.Infof("fill: %d users", opts.Users)
+
+	err := fillUsers(ctx, client, res.Users)
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Infof("fill: %d forums", opts.Forums)
+
+	users := newZipf(r, len(res.Users))
+
+	for idx, slug := range res.Forums {
+		err = client.mustDo(ctx, http.MethodPost, "/api/forum/create", &forumModels.ForumCreateRequest{
+			Title: fmt.Sprintf("Forum %d of %s", idx, opts.Prefix),
+			User:  res.Users[users.next()],
+			Slug:  slug,
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	logrus.Infof("fill: %d threads", opts.Threads)
+
+	threads := make([]*threadModels.ForumCreateThreadRequest, opts.Threads)
+	forums := newZipf(r, len(res.Forums))
+	now := time.Now()
+
+	for idx := range threads {
+		threads[idx] = &threadModels.ForumCreateThreadRequest{
+			Title:   fmt.Sprintf("Thread %d of %s", idx, opts.Prefix),
+			Author:  res.Users[users.next()],
+			Message: message(r),
+			Created: now.Add(-time.Duration(r.Int63n(int64(fillSpan)))).Format(time.RFC3339),
+			Forum:   res.Forums[forums.next()],
+		}
+	}
+
+	err = parallel(ctx, opts.Workers, len(threads), func(idx int) error {
+		created := &threadModels.ForumCreateThreadResponse{}
+
+		err := client.mustDo(ctx, http.MethodPost, "/api/forum/"+threads[idx].Forum+"/create", threads[idx], created)
+		res.Threads[idx] = created.ID
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Infof("fill: %d posts", opts.Posts)
+
+	// Each thread gets a generator of its own, for the trees to be the same whatever the order of the workers
+	sizes := make([]int, opts.Threads)
+	seeds := make([]int64, opts.Threads)
+	popular := newZipf(r, opts.Threads)
+
+	for idx := 0; idx < opts.Posts; idx++ {
+		sizes[popular.next()]++
+	}
+
+	for idx := range seeds {
+		seeds[idx] = r.Int63()
+	}
+
+	var mu sync.Mutex
+
+	err = parallel(ctx, opts.Workers, opts.Threads, func(idx int) error {
+		posts, err := fillPosts(ctx, client, res, opts, res.Threads[idx], sizes[idx], rand.New(rand.NewSource(seeds[idx])))
+
+		mu.Lock()
+		res.Posts = append(res.Posts, posts...)
+		mu.Unlock()
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Infof("fill: %d votes", opts.Votes)
+
+	votes := make([]*voteModels.VoteRequest, opts.Votes)
+	votedThreads := make([]int64, opts.Votes)
+
+	for idx := range votes {
+		voice := int64(1)
+		if r.Intn(10) < 3 {
+			voice = -1
+		}
+
+		votes[idx] = &voteModels.VoteRequest{Nickname: res.Users[r.Intn(len(res.Users))], Voice: voice}
+		votedThreads[idx] = res.Threads[popular.next()]
+	}
+
+	err = parallel(ctx, opts.Workers, len(votes), func(idx int) error {
+		return client.mustDo(ctx, http.MethodPost, threadPath(votedThreads[idx], "vote"), votes[idx], nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func fillUsers(ctx context.Context, client *Client, nicknames []string) error {
+	for start := 0; start < len(nicknames); start += usersPerBatch {
+		end := start + usersPerBatch
+		if end > len(nicknames) {
+			end = len(nicknames)
+		}
+
+		batch := make(userModels.UsersCreateRequestList, 0, end-start)
+
+		for _, nickname := range nicknames[start:end] {
+			batch = append(batch, userModels.UserCreateRequest{
+				Nickname: nickname,
+				FullName: "User " + nickname,
+				About:    "Created by the load generator.",
+				Email:    nickname + "@load.test",
+			})
+		}
+
+		err := client.mustDo(ctx, http.MethodPost, "/api/users/batch", batch, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fillPosts creates the posts of a thread call after call. Each post starts a new branch, continues one of the
+// latest posts, which makes long chains, or answers any earlier post.
+func fillPosts(ctx context.Context, client *Client, data *Dataset, opts FillOptions, thread int64, size int, r *rand.Rand) ([]PostRef, error) {
+	res := make([]PostRef, 0, size)
+
+	depths := make(map[int64]int, size)
+	latest := make([]int64, 0, opts.PostsPerBatch)
+	users := newZipf(r, len(data.Users))
+
+	for len(res) < size {
+		count := opts.PostsPerBatch
+		if size-len(res) < count {
+			count = size - len(res)
+		}
+
+		batch := make(threadModels.PostsRequestList, count)
+
+		for idx := range batch {
+			var parent int64
+
+			switch dice := r.Intn(10); {
+			case len(res) == 0 || dice < 2:
+			case dice < 6:
+				parent = latest[r.Intn(len(latest))]
+			default:
+				parent = res[r.Intn(len(res))].ID
+			}
+
+			if depths[parent] >= opts.MaxDepth {
+				parent = 0
+			}
+
+			batch[idx] = threadModels.PostRequest{
+				Parent:  parent,
+				Author:  data.Users[users.next()],
+				Message: message(r),
+			}
+		}
+
+		created := threadModels.PostsResponseList{}
+
+		err := client.mustDo(ctx, http.MethodPost, threadPath(thread, "create"), batch, &created)
+		if err != nil {
+			return res, err
+		}
+
+		latest = latest[:0]
+
+		for _, post := range created {
+			depths[post.ID] = depths[post.Parent] + 1
+			latest = append(latest, post.ID)
+			res = append(res, PostRef{ID: post.ID, Thread: thread})
+		}
+	}
+
+	return res, nil
+}
+
+// parallel calls do for 0 to n-1 on up to workers goroutines and returns the first error, after which the calls not
+// started yet are skipped.
+func parallel(ctx context.Context, workers int, n int, do func(idx int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+
+	var once sync.Once
+
+	var res error
+
+	next := make(chan int)
+
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for idx := range next {
+				err := do(idx)
+				if err != nil {
+					once.Do(func() {
+						res = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+feed:
+	for idx := 0; idx < n; idx++ {
+		select {
+		case next <- idx:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+
+	close(next)
+	wg.Wait()
+
+	if res == nil {
+		res = ctx.Err()
+	}
+
+	return res
+}
+
+// zipf picks indexes in [0, n), the first ones far more often than the others.
+type zipf struct {
+	zipf *rand.Zipf
+}
+
+func newZipf(r *rand.Rand, n int) *zipf {
+	return &zipf{rand.NewZipf(r, zipfSkew, 1, uint64(n-1))}
+}
+
+func (z *zipf) next() int {
+	return int(z.zipf.Uint64())
+}
+
+var words = []string{"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit", "sed", "do",
+	"eiusmod", "tempor", "incididunt", "ut", "labore", "et", "dolore", "magna", "aliqua"}
+
+// message makes a text of a few to a few dozen words.
+func message(r *rand.Rand) string {
+	size := 3 + r.Intn(40)
+	buf := make([]byte, 0, size*8)
+
+	for idx := 0; idx < size; idx++ {
+		if idx > 0 {
+			buf = append(buf, ' ')
+		}
+
+		buf = append(buf, words[r.Intn(len(words))]...)
+	}
+
+	return string(buf)
+}
+
+func threadPath(thread int64, action string) string {
+	return "/api/thread/" + strconv.FormatInt(thread, 10) + "/" + action
+}
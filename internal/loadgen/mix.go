@@ -0,0 +1,188 @@
+package loadgen
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	threadModels "project/internal/thread/delivery/models"
+	voteModels "project/internal/vote/delivery/models"
+)
+
+// call makes one call of the API on data and returns its status.
+type call func(ctx context.Context, client *Client, data *Dataset, r *rand.Rand) (int, error)
+
+func get(ctx context.Context, client *Client, path string) (int, error) {
+	return client.Do(ctx, http.MethodGet, path, nil, nil)
+}
+
+var sorts = []string{"flat", "tree", "parent_tree"}
+
+// calls are the endpoints Run can replay, by the names a Mix weights them with.
+var calls = map[string]call{
+	"user_profile": func(ctx context.Context, client *Client, data *Dataset, r *rand.Rand) (int, error) {
+		return get(ctx, client, "/api/user/"+pickUser(data, r)+"/profile")
+	},
+	"forum_details": func(ctx context.Context, client *Client, data *Dataset, r *rand.Rand) (int, error) {
+		return get(ctx, client, "/api/forum/"+data.Forums[r.Intn(len(data.Forums))]+"/details")
+	},
+	"forum_threads": func(ctx context.Context, client *Client, data *Dataset, r *rand.Rand) (int, error) {
+		return get(ctx, client, "/api/forum/"+data.Forums[r.Intn(len(data.Forums))]+"/threads?limit=20&desc=true")
+	},
+	"forum_users": func(ctx context.Context, client *Client, data *Dataset, r *rand.Rand) (int, error) {
+		return get(ctx, client, "/api/forum/"+data.Forums[r.Intn(len(data.Forums))]+"/users?limit=20")
+	},
+	"thread_details": func(ctx context.Context, client *Client, data *Dataset, r *rand.Rand) (int, error) {
+		return get(ctx, client, threadPath(pickThread(data, r), "details"))
+	},
+	"thread_posts": func(ctx context.Context, client *Client, data *Dataset, r *rand.Rand) (int, error) {
+		return get(ctx, client, threadPath(pickThread(data, r), "posts")+"?limit=20&sort="+sorts[r.Intn(len(sorts))])
+	},
+	"post_details": func(ctx context.Context, client *Client, data *Dataset, r *rand.Rand) (int, error) {
+		if len(data.Posts) == 0 {
+			return 0, errNoPosts
+		}
+
+		post := data.Posts[r.Intn(len(data.Posts))]
+
+		return get(ctx, client, "/api/post/"+strconv.FormatInt(post.ID, 10)+"/details?related=user,thread,forum")
+	},
+	"trending": func(ctx context.Context, client *Client, data *Dataset, r *rand.Rand) (int, error) {
+		return get(ctx, client, "/api/threads/trending?limit=20")
+	},
+	"status": func(ctx context.Context, client *Client, data *Dataset, r *rand.Rand) (int, error) {
+		return get(ctx, client, "/api/service/status")
+	},
+	"create_posts": func(ctx context.Context, client *Client, data *Dataset, r *rand.Rand) (int, error) {
+		thread := pickThread(data, r)
+		batch := make(threadModels.PostsRequestList, 1+r.Intn(5))
+
+		for idx := range batch {
+			batch[idx] = threadModels.PostRequest{Author: pickUser(data, r), Message: message(r)}
+		}
+
+		return client.Do(ctx, http.MethodPost, threadPath(thread, "create"), batch, nil)
+	},
+	"vote": func(ctx context.Context, client *Client, data *Dataset, r *rand.Rand) (int, error) {
+		voice := int64(1 - 2*r.Intn(2))
+
+		return client.Do(ctx, http.MethodPost, threadPath(pickThread(data, r), "vote"),
+			&voteModels.VoteRequest{Nickname: pickUser(data, r), Voice: voice}, nil)
+	},
+}
+
+var errNoPosts = fmt.Errorf("the dataset has no posts")
+
+// pickThread and pickUser favour the first threads and users, which Fill made the most active.
+func pickThread(data *Dataset, r *rand.Rand) int64 {
+	return data.Threads[skewed(r, len(data.Threads))]
+}
+
+func pickUser(data *Dataset, r *rand.Rand) string {
+	return data.Users[skewed(r, len(data.Users))]
+}
+
+// skewed picks half of the time among the first tenth of n, else anywhere.
+func skewed(r *rand.Rand, n int) int {
+	if r.Intn(2) == 0 && n >= 10 {
+		return r.Intn(n / 10)
+	}
+
+	return r.Intn(n)
+}
+
+// Mix weights the endpoints replayed by Run.
+type Mix map[string]int
+
+// DefaultMix is mostly reads, as is the traffic of a forum.
+func DefaultMix() Mix {
+	return Mix{
+		"user_profile":   10,
+		"forum_details":  10,
+		"forum_threads":  15,
+		"forum_users":    5,
+		"thread_details": 15,
+		"thread_posts":   25,
+		"post_details":   10,
+		"trending":       2,
+		"status":         1,
+		"create_posts":   5,
+		"vote":           2,
+	}
+}
+
+// ParseMix reads a mix written as name=weight pairs separated by commas, as in thread_posts=5,vote=1.
+func ParseMix(value string) (Mix, error) {
+	res := Mix{}
+
+	for _, pair := range strings.Split(value, ",") {
+		name, weight, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return nil, fmt.Errorf("mix: %q is not name=weight", pair)
+		}
+
+		if _, ok = calls[name]; !ok {
+			return nil, fmt.Errorf("mix: unknown endpoint %q, known are %s", name, strings.Join(Endpoints(), ", "))
+		}
+
+		count, err := strconv.Atoi(weight)
+		if err != nil || count < 0 {
+			return nil, fmt.Errorf("mix: bad weight %q of %s", weight, name)
+		}
+
+		res[name] = count
+	}
+
+	return res, nil
+}
+
+// Endpoints lists the names a Mix may weight.
+func Endpoints() []string {
+	res := make([]string, 0, len(calls))
+
+	for name := range calls {
+		res = append(res, name)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// picker draws the names of a mix in proportion to their weights.
+type picker struct {
+	names  []string
+	bounds []int
+}
+
+func newPicker(mix Mix) (*picker, error) {
+	res := &picker{}
+
+	total := 0
+
+	for _, name := range Endpoints() {
+		if mix[name] == 0 {
+			continue
+		}
+
+		total += mix[name]
+		res.names = append(res.names, name)
+		res.bounds = append(res.bounds, total)
+	}
+
+	if total == 0 {
+		return nil, fmt.Errorf("mix: no endpoint has a weight")
+	}
+
+	return res, nil
+}
+
+func (p *picker) pick(r *rand.Rand) string {
+	draw := r.Intn(p.bounds[len(p.bounds)-1])
+
+	return p.names[sort.SearchInts(p.bounds, draw+1)]
+}
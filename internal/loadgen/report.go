@@ -0,0 +1,153 @@
+package loadgen
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Report sums up a run per endpoint, sorted by name, and over all of them as Total.
+type Report struct {
+	Elapsed   time.Duration    `json:"elapsed"`
+	Endpoints []EndpointReport `json:"endpoints"`
+	Total     EndpointReport   `json:"total"`
+}
+
+// EndpointReport counts the calls of an endpoint, ClientErrors being the 4xx answers and Failures the 5xx ones and
+// the transport errors, and gives the percentiles of their latencies, in nanoseconds in JSON.
+type EndpointReport struct {
+	Name         string        `json:"name"`
+	Requests     int           `json:"requests"`
+	ClientErrors int           `json:"clientErrors"`
+	Failures     int           `json:"failures"`
+	Throughput   float64       `json:"throughput"`
+	P50          time.Duration `json:"p50"`
+	P90          time.Duration `json:"p90"`
+	P95          time.Duration `json:"p95"`
+	P99          time.Duration `json:"p99"`
+	Max          time.Duration `json:"max"`
+	LastError    string        `json:"lastError,omitempty"`
+}
+
+type samples struct {
+	latencies    []time.Duration
+	clientErrors int
+	failures     int
+	lastError    string
+}
+
+// recorder keeps every latency, a run being short enough for them to fit in memory, so that the percentiles are
+// exact.
+type recorder struct {
+	mu        sync.Mutex
+	endpoints map[string]*samples
+}
+
+func newRecorder() *recorder {
+	return &recorder{
+		endpoints: make(map[string]*samples),
+	}
+}
+
+func (r *recorder) record(name string, latency time.Duration, status int, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	endpoint, ok := r.endpoints[name]
+	if !ok {
+		endpoint = &samples{}
+		r.endpoints[name] = endpoint
+	}
+
+	endpoint.latencies = append(endpoint.latencies, latency)
+
+	switch {
+	case failed(status, err):
+		endpoint.failures++
+
+		if err != nil {
+			endpoint.lastError = err.Error()
+		} else {
+			endpoint.lastError = http.StatusText(status)
+		}
+	case status >= http.StatusBadRequest:
+		endpoint.clientErrors++
+	}
+}
+
+func failed(status int, err error) bool {
+	return err != nil || status >= http.StatusInternalServerError
+}
+
+func (r *recorder) report(elapsed time.Duration) *Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	res := &Report{
+		Elapsed:   elapsed,
+		Endpoints: make([]EndpointReport, 0, len(r.endpoints)),
+	}
+
+	total := &samples{}
+
+	for name, endpoint := range r.endpoints {
+		res.Endpoints = append(res.Endpoints, summarize(name, endpoint, elapsed))
+
+		total.latencies = append(total.latencies, endpoint.latencies...)
+		total.clientErrors += endpoint.clientErrors
+		total.failures += endpoint.failures
+	}
+
+	sort.Slice(res.Endpoints, func(i, j int) bool {
+		return res.Endpoints[i].Name < res.Endpoints[j].Name
+	})
+
+	res.Total = summarize("total", total, elapsed)
+
+	return res
+}
+
+func summarize(name string, endpoint *samples, elapsed time.Duration) EndpointReport {
+	latencies := endpoint.latencies
+
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i] < latencies[j]
+	})
+
+	res := EndpointReport{
+		Name:         name,
+		Requests:     len(latencies),
+		ClientErrors: endpoint.clientErrors,
+		Failures:     endpoint.failures,
+		P50:          percentile(latencies, 50),
+		P90:          percentile(latencies, 90),
+		P95:          percentile(latencies, 95),
+		P99:          percentile(latencies, 99),
+		LastError:    endpoint.lastError,
+	}
+
+	if len(latencies) > 0 {
+		res.Max = latencies[len(latencies)-1]
+	}
+
+	if elapsed > 0 {
+		res.Throughput = float64(len(latencies)) / elapsed.Seconds()
+	}
+
+	return res
+}
+
+// percentile is the nearest-rank percentile of sorted latencies.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
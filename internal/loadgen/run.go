@@ -0,0 +1,101 @@
+package loadgen
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// RunOptions sets how Run replays the mix: Workers call the API back to back, for Duration or until Requests calls
+// were made, whichever comes first. A zero Requests runs for the whole Duration.
+type RunOptions struct {
+	Mix      Mix
+	Workers  int
+	Duration time.Duration
+	Requests int
+	Seed     int64
+}
+
+func DefaultRunOptions() RunOptions {
+	return RunOptions{
+		Mix:      DefaultMix(),
+		Workers:  16,
+		Duration: time.Minute,
+		Seed:     1,
+	}
+}
+
+// Run replays the mix of calls on data and reports the latencies and the throughput of each endpoint. A call fails
+// on a transport error or a status of 500 and above; 4xx answers, such as a vote on a thread gone, are counted as
+// such but timed like the others.
+func Run(ctx context.Context, client *Client, data *Dataset, opts RunOptions) (*Report, error) {
+	if len(data.Users) == 0 || len(data.Forums) == 0 || len(data.Threads) == 0 {
+		return nil, fmt.Errorf("run needs a dataset with users, forums and threads")
+	}
+
+	picker, err := newPicker(opts.Mix)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Workers <= 0 {
+		opts.Workers = 1
+	}
+
+	if opts.Duration > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, opts.Duration)
+		defer cancel()
+	}
+
+	recorder := newRecorder()
+
+	// Each worker takes a ticket per call, so that Requests bounds the calls of all of them
+	tickets := make(chan struct{})
+
+	go func() {
+		defer close(tickets)
+
+		for sent := 0; opts.Requests <= 0 || sent < opts.Requests; sent++ {
+			select {
+			case tickets <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+
+	started := time.Now()
+
+	for worker := 0; worker < opts.Workers; worker++ {
+		wg.Add(1)
+
+		go func(r *rand.Rand) {
+			defer wg.Done()
+
+			for range tickets {
+				name := picker.pick(r)
+
+				start := time.Now()
+				status, err := calls[name](ctx, client, data, r)
+				elapsed := time.Since(start)
+
+				// A call cut by the end of the run is not a failure of the server
+				if err != nil && ctx.Err() != nil {
+					return
+				}
+
+				recorder.record(name, elapsed, status, err)
+			}
+		}(rand.New(rand.NewSource(opts.Seed + int64(worker))))
+	}
+
+	wg.Wait()
+
+	return recorder.report(time.Since(started)), nil
+}
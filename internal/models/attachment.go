@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// Attachment is a file uploaded for a post. Post is 0 until a post takes it, Key names its content in the storage.
+type Attachment struct {
+	ID       int64
+	Post     int64
+	Author   string
+	Filename string
+	MimeType string
+	Size     int64
+	Checksum string
+	Key      string
+	Created  time.Time
+}
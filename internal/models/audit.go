@@ -0,0 +1,15 @@
+package models
+
+// AuditEntry is a change recorded in the audit log. Before and After are JSON documents, empty where the entity did
+// not exist.
+type AuditEntry struct {
+	ID        int64
+	Actor     string
+	RequestID string
+	Entity    string
+	EntityID  string
+	Action    string
+	Before    string
+	After     string
+	Created   string
+}
@@ -0,0 +1,27 @@
+package models
+
+// ConsistencyReport is the outcome of a run of the consistency checker.
+type ConsistencyReport struct {
+	Started  string
+	Finished string
+	Repair   bool
+	Checks   []ConsistencyCheck
+}
+
+// ConsistencyCheck counts the discrepancies found by a check and those repaired, with the first ones as samples.
+type ConsistencyCheck struct {
+	Name     string
+	Found    int64
+	Repaired int64
+	Samples  []ConsistencyIssue
+}
+
+// ConsistencyIssue is a derived value which differs from the one recomputed from the source data. Position orders
+// the issues of a check for the checker to go through them in batches.
+type ConsistencyIssue struct {
+	Position string
+	Key      string
+	Field    string
+	Stored   string
+	Expected string
+}
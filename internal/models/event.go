@@ -0,0 +1,15 @@
+package models
+
+type Event struct {
+	ID         int64
+	Kind       string
+	Thread     int64
+	Forum      string
+	Nickname   string
+	Entity     int64
+	Created    string
+	Post       Post
+	Votes      int64
+	ThreadData Thread
+	User       User
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+type Feed struct {
+	ID          string
+	Title       string
+	Link        string
+	Author      string
+	Updated     time.Time
+	ETag        string
+	NotModified bool
+	Entries     []FeedEntry
+}
+
+type FeedEntry struct {
+	ID        string
+	Title     string
+	Link      string
+	Author    string
+	Content   string
+	Published time.Time
+	Updated   time.Time
+}
+
+// FeedState is what a feed depends on, read without building the feed.
+type FeedState struct {
+	Version string
+	Updated time.Time
+}
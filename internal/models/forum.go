@@ -1,10 +1,52 @@
 package models
 
 type Forum struct {
-	ID      int64
-	Title   string
-	User    string
-	Slug    string
+	ID         int64
+	Title      string
+	User       string
+	Slug       string
+	Posts      int64
+	Threads    int64
+	Parent     string
+	RollUp     bool
+	Visibility string
+	Version    int64
+	Breadcrumb []Forum
+}
+
+type ForumAccess struct {
+	Visibility string
+	Member     bool
+}
+
+type ForumMember struct {
+	Forum     string
+	Nickname  string
+	Status    string
+	InvitedBy string
+}
+
+// ForumStats is the activity of a forum by period, starting with the oldest, and its leaders over all time.
+type ForumStats struct {
+	Forum        string
+	Period       string
+	Activity     []ForumActivity
+	ActiveUsers  int64
+	TopPosters   []ForumAuthor
+	TopVoted     []ForumAuthor
+	TopThreads   []Thread
+	AverageDepth float64
+}
+
+type ForumActivity struct {
+	Start   string
 	Posts   int64
 	Threads int64
+	Users   int64
+}
+
+type ForumAuthor struct {
+	Nickname string
+	Posts    int64
+	Votes    int64
 }
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type Post struct {
 	ID       int64
 	Parent   int64
@@ -9,4 +11,12 @@ type Post struct {
 	Forum    string
 	Thread   int64
 	Created  string
+
+	Attachments []Attachment
+
+	// Visibility is that of the forum, read along with the post for the access checks
+	Visibility string
+
+	Modified time.Time
+	Version  int64
 }
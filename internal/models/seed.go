@@ -0,0 +1,13 @@
+package models
+
+// SeedResult counts what a seeding created. Prefix starts the names of its users and forums.
+type SeedResult struct {
+	Profile string
+	Seed    int64
+	Prefix  string
+	Users   int64
+	Forums  int64
+	Threads int64
+	Posts   int64
+	Votes   int64
+}
@@ -5,6 +5,18 @@ type StatusService struct {
 	Forum  int64
 	Thread int64
 	Post   int64
+
+	// Consistency is the report of the last run of the consistency checker, if any
+	Consistency *ConsistencyReport
+}
+
+// RecountService counts the rows whose counters had drifted and were fixed by a recount, Rollups the rows of the
+// forum stats rebuilt.
+type RecountService struct {
+	Forums     int64
+	Threads    int64
+	UserForums int64
+	Rollups    int64
 }
 
 type PostDetails struct {
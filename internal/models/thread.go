@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type Thread struct {
 	ID      int64
 	Title   string
@@ -9,4 +11,24 @@ type Thread struct {
 	Message string
 	Created string
 	Votes   int64
+
+	Modified time.Time
+	Version  int64
+
+	// Visibility is that of the forum, read along with the thread for the access checks
+	Visibility string
+
+	LastPostAt     string
+	LastPostAuthor string
+	Replies        int64
+	// Unread is the count of replies the current user has not read, in listings
+	Unread int64
+}
+
+// ThreadRead is the read position of a user in a thread, as the count of replies seen.
+type ThreadRead struct {
+	Thread   int64
+	Nickname string
+	Replies  int64
+	Updated  string
 }
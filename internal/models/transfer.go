@@ -0,0 +1,29 @@
+package models
+
+type Vote struct {
+	Nickname string
+	Thread   int64
+	Voice    int64
+}
+
+// TransferRecord is one line of an export. Exactly one of the entities is set, according to Kind.
+type TransferRecord struct {
+	Kind   string
+	User   *User
+	Forum  *Forum
+	Member *ForumMember
+	Thread *Thread
+	Post   *Post
+	Vote   *Vote
+	Count  int64
+}
+
+type TransferStats struct {
+	Users        int64
+	SkippedUsers int64
+	Forums       int64
+	Members      int64
+	Threads      int64
+	Posts        int64
+	Votes        int64
+}
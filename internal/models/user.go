@@ -6,4 +6,11 @@ type User struct {
 	FullName string
 	About    string
 	Email    string
+	Version  int64
+}
+
+type UserCreateResult struct {
+	User      User
+	Created   bool
+	Conflicts []User
 }
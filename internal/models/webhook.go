@@ -0,0 +1,32 @@
+package models
+
+type Webhook struct {
+	ID      int64
+	Forum   string
+	URL     string
+	Secret  string
+	Events  []string
+	Created string
+}
+
+type WebhookDelivery struct {
+	ID          int64
+	Webhook     int64
+	Forum       string
+	URL         string
+	Secret      string
+	Kind        string
+	Thread      int64
+	Seq         int64
+	Entity      int64
+	Payload     string
+	Status      string
+	Attempts    int64
+	NextAttempt string
+	LastStatus  int64
+	LastError   string
+	Created     string
+
+	// Event is the state of the entity when the delivery was queued, nil if it is gone
+	Event *Event
+}
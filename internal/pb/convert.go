@@ -0,0 +1,139 @@
+package pb
+
+import (
+	"strconv"
+
+	"project/internal/models"
+)
+
+// Conversions between the messages and the models of the usecases. The generated files are left untouched.
+
+func NewUser(user *models.User) *User {
+	return &User{
+		Nickname: user.Nickname,
+		Fullname: user.FullName,
+		About:    user.About,
+		Email:    user.Email,
+		Version:  user.Version,
+	}
+}
+
+func (x *User) GetModel() *models.User {
+	return &models.User{
+		Nickname: x.GetNickname(),
+		FullName: x.GetFullname(),
+		About:    x.GetAbout(),
+		Email:    x.GetEmail(),
+		Version:  x.GetVersion(),
+	}
+}
+
+func NewForum(forum *models.Forum) *Forum {
+	return &Forum{
+		Slug:       forum.Slug,
+		Title:      forum.Title,
+		User:       forum.User,
+		Posts:      forum.Posts,
+		Threads:    forum.Threads,
+		Parent:     forum.Parent,
+		Visibility: forum.Visibility,
+		Rollup:     forum.RollUp,
+		Version:    forum.Version,
+	}
+}
+
+func (x *Forum) GetModel() *models.Forum {
+	return &models.Forum{
+		Slug:       x.GetSlug(),
+		Title:      x.GetTitle(),
+		User:       x.GetUser(),
+		Parent:     x.GetParent(),
+		Visibility: x.GetVisibility(),
+		RollUp:     x.GetRollup(),
+	}
+}
+
+func NewThread(thread *models.Thread) *Thread {
+	return &Thread{
+		Id:      thread.ID,
+		Title:   thread.Title,
+		Author:  thread.Author,
+		Forum:   thread.Forum,
+		Slug:    thread.Slug,
+		Message: thread.Message,
+		Created: thread.Created,
+		Votes:   thread.Votes,
+		Version: thread.Version,
+	}
+}
+
+func (x *Thread) GetModel() *models.Thread {
+	return &models.Thread{
+		Title:   x.GetTitle(),
+		Author:  x.GetAuthor(),
+		Forum:   x.GetForum(),
+		Slug:    x.GetSlug(),
+		Message: x.GetMessage(),
+		Created: x.GetCreated(),
+	}
+}
+
+// NewThreadSlugOrID addresses a thread the way {slug_or_id} does in REST.
+func NewThreadSlugOrID(slugOrID string) *models.Thread {
+	id, err := strconv.ParseInt(slugOrID, 10, 64)
+	if err != nil {
+		return &models.Thread{Slug: slugOrID}
+	}
+
+	return &models.Thread{ID: id}
+}
+
+func NewPost(post *models.Post) *Post {
+	return &Post{
+		Id:       post.ID,
+		Parent:   post.Parent,
+		Author:   post.Author.Nickname,
+		Message:  post.Message,
+		IsEdited: post.IsEdited,
+		Forum:    post.Forum,
+		Thread:   post.Thread,
+		Created:  post.Created,
+		Version:  post.Version,
+	}
+}
+
+func (x *Post) GetModel() *models.Post {
+	return &models.Post{
+		Parent:  x.GetParent(),
+		Author:  models.User{Nickname: x.GetAuthor()},
+		Message: x.GetMessage(),
+	}
+}
+
+// NewPostDetails sets only the related entities that were asked for.
+func NewPostDetails(details *models.PostDetails) *PostDetails {
+	res := &PostDetails{Post: NewPost(&details.Post)}
+
+	if details.Author.Nickname != "" {
+		res.Author = NewUser(&details.Author)
+	}
+
+	if details.Thread.ID != 0 {
+		res.Thread = NewThread(&details.Thread)
+	}
+
+	if details.Forum.Slug != "" {
+		res.Forum = NewForum(&details.Forum)
+	}
+
+	return res
+}
+
+func NewStatus(status *models.StatusService) *Status {
+	return &Status{
+		User:   status.User,
+		Forum:  status.Forum,
+		Thread: status.Thread,
+		Post:   status.Post,
+	}
+}
@@ -0,0 +1,22 @@
+package pkg
+
+import (
+	"strings"
+
+	"project/internal/models"
+)
+
+// CanReadForum reports whether the forum content may be shown. Private forums are visible to members only.
+func CanReadForum(access *models.ForumAccess) bool {
+	return access.Visibility != ForumVisibilityPrivate || access.Member
+}
+
+// CanWriteForum reports whether new threads, posts and votes may be added to the forum.
+func CanWriteForum(access *models.ForumAccess) bool {
+	return access.Visibility == ForumVisibilityPublic || access.Member
+}
+
+// EqualNicknames compares nicknames the same way the citext columns do.
+func EqualNicknames(lhs string, rhs string) bool {
+	return strings.EqualFold(lhs, rhs)
+}
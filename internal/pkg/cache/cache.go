@@ -0,0 +1,73 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	DefaultCapacity = 10000
+	DefaultTTL      = time.Duration(10) * time.Second
+)
+
+// Cache keeps values of one kind by key. Implementations are safe for concurrent use.
+type Cache[V any] interface {
+	Get(key string) (V, bool)
+	Set(key string, value V)
+	Delete(key string)
+	Purge()
+	Stats() Stats
+}
+
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Expired   uint64
+	Size      int
+	Capacity  int
+}
+
+type named interface {
+	Purge()
+	Stats() Stats
+}
+
+// Set groups the caches of the server, so that they can be purged at once, as after clearing the database, and
+// their stats reported together.
+type Set struct {
+	mu     sync.Mutex
+	caches map[string]named
+}
+
+func NewSet() *Set {
+	return &Set{caches: make(map[string]named)}
+}
+
+func (s *Set) Add(name string, c named) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.caches[name] = c
+}
+
+func (s *Set) Purge() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, c := range s.caches {
+		c.Purge()
+	}
+}
+
+func (s *Set) Stats() map[string]Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := make(map[string]Stats, len(s.caches))
+	for name, c := range s.caches {
+		res[name] = c.Stats()
+	}
+
+	return res
+}
@@ -0,0 +1,135 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type entry[V any] struct {
+	key     string
+	value   V
+	expires time.Time
+}
+
+// LRU is an in-process cache holding at most capacity entries, each for at most ttl. When full, the least
+// recently used entry makes room for the new one.
+type LRU[V any] struct {
+	capacity int
+	ttl      time.Duration
+
+	mu    sync.Mutex
+	order *list.List
+	items map[string]*list.Element
+
+	hits      uint64
+	misses    uint64
+	evictions uint64
+	expired   uint64
+}
+
+func NewLRU[V any](capacity int, ttl time.Duration) *LRU[V] {
+	return &LRU[V]{
+		capacity: capacity,
+		ttl:      ttl,
+		order:    list.New(),
+		items:    make(map[string]*list.Element, capacity),
+	}
+}
+
+func (c *LRU[V]) Get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var res V
+
+	elem, ok := c.items[key]
+	if !ok {
+		c.misses++
+		return res, false
+	}
+
+	item := elem.Value.(*entry[V])
+
+	if time.Now().After(item.expires) {
+		c.remove(elem)
+
+		c.expired++
+		c.misses++
+
+		return res, false
+	}
+
+	c.order.MoveToFront(elem)
+
+	c.hits++
+
+	return item.value, true
+}
+
+func (c *LRU[V]) Set(key string, value V) {
+	if c.capacity <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires := time.Now().Add(c.ttl)
+
+	elem, ok := c.items[key]
+	if ok {
+		item := elem.Value.(*entry[V])
+		item.value = value
+		item.expires = expires
+
+		c.order.MoveToFront(elem)
+
+		return
+	}
+
+	for c.order.Len() >= c.capacity {
+		c.remove(c.order.Back())
+
+		c.evictions++
+	}
+
+	c.items[key] = c.order.PushFront(&entry[V]{key: key, value: value, expires: expires})
+}
+
+func (c *LRU[V]) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if ok {
+		c.remove(elem)
+	}
+}
+
+func (c *LRU[V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	c.items = make(map[string]*list.Element, c.capacity)
+}
+
+func (c *LRU[V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return Stats{
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+		Expired:   c.expired,
+		Size:      c.order.Len(),
+		Capacity:  c.capacity,
+	}
+}
+
+func (c *LRU[V]) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.items, elem.Value.(*entry[V]).key)
+}
@@ -0,0 +1,114 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLRU(t *testing.T) {
+	type op struct {
+		set    bool
+		key    string
+		value  int
+		want   int
+		wantOk bool
+	}
+
+	tests := []struct {
+		name     string
+		capacity int
+		ttl      time.Duration
+		ops      []op
+	}{
+		{
+			name:     "get what was set",
+			capacity: 2,
+			ttl:      time.Minute,
+			ops: []op{
+				{set: true, key: "a", value: 1},
+				{key: "a", want: 1, wantOk: true},
+				{key: "b"},
+			},
+		},
+		{
+			name:     "least recently used makes room",
+			capacity: 2,
+			ttl:      time.Minute,
+			ops: []op{
+				{set: true, key: "a", value: 1},
+				{set: true, key: "b", value: 2},
+				{key: "a", want: 1, wantOk: true},
+				{set: true, key: "c", value: 3},
+				{key: "b"},
+				{key: "a", want: 1, wantOk: true},
+				{key: "c", want: 3, wantOk: true},
+			},
+		},
+		{
+			name:     "set replaces",
+			capacity: 2,
+			ttl:      time.Minute,
+			ops: []op{
+				{set: true, key: "a", value: 1},
+				{set: true, key: "a", value: 2},
+				{key: "a", want: 2, wantOk: true},
+			},
+		},
+		{
+			name:     "expired",
+			capacity: 2,
+			ttl:      -time.Second,
+			ops: []op{
+				{set: true, key: "a", value: 1},
+				{key: "a"},
+			},
+		},
+		{
+			name:     "zero capacity keeps nothing",
+			capacity: 0,
+			ttl:      time.Minute,
+			ops: []op{
+				{set: true, key: "a", value: 1},
+				{key: "a"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewLRU[int](tt.capacity, tt.ttl)
+
+			for idx, step := range tt.ops {
+				if step.set {
+					c.Set(step.key, step.value)
+					continue
+				}
+
+				got, ok := c.Get(step.key)
+				if got != step.want || ok != step.wantOk {
+					t.Errorf("step %d: Get(%s) = %d, %v, want %d, %v", idx, step.key, got, ok, step.want, step.wantOk)
+				}
+			}
+		})
+	}
+}
+
+func TestSetPurge(t *testing.T) {
+	first := NewLRU[int](10, time.Minute)
+	second := NewLRU[string](10, time.Minute)
+
+	caches := NewSet()
+	caches.Add("first", first)
+	caches.Add("second", second)
+
+	first.Set("a", 1)
+	second.Set("b", "b")
+
+	caches.Purge()
+
+	for name, stats := range caches.Stats() {
+		if stats.Size != 0 {
+			t.Errorf("cache %s keeps %d entries after Purge()", name, stats.Size)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package pkg
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ConditionalParams struct {
+	Safe            bool
+	IfNoneMatch     string
+	IfModifiedSince time.Time
+	IfMatch         string
+}
+
+func NewConditionalParams(r *http.Request) *ConditionalParams {
+	res := &ConditionalParams{
+		Safe:        r.Method == http.MethodGet || r.Method == http.MethodHead,
+		IfNoneMatch: r.Header.Get("If-None-Match"),
+		IfMatch:     r.Header.Get("If-Match"),
+	}
+
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err == nil {
+		res.IfModifiedSince = since
+	}
+
+	return res
+}
+
+// NotModified reports whether the client copy with the given validators is fresh. If-None-Match takes precedence
+// over If-Modified-Since, as RFC 9110 requires; ETags are compared weakly.
+func (c *ConditionalParams) NotModified(etag string, modified time.Time) bool {
+	if c.IfNoneMatch != "" {
+		for _, tag := range strings.Split(c.IfNoneMatch, ",") {
+			tag = strings.TrimSpace(tag)
+
+			if tag == "*" || strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if c.IfModifiedSince.IsZero() || modified.IsZero() {
+		return false
+	}
+
+	// Last-Modified has a precision of seconds
+	return !modified.Truncate(time.Second).After(c.IfModifiedSince)
+}
+
+func SetValidators(w http.ResponseWriter, etag string, modified time.Time) {
+	if etag != "" {
+		w.Header().Set("ETag", etag)
+	}
+
+	if !modified.IsZero() {
+		w.Header().Set("Last-Modified", modified.UTC().Format(http.TimeFormat))
+	}
+}
+
+// IfMatchVersions returns the versions the If-Match header allows the update for, nil meaning any version. Tags
+// other than those of VersionETag never match, so a header listing only such tags yields an empty slice.
+func (c *ConditionalParams) IfMatchVersions() []time.Time {
+	if c.IfMatch == "" || strings.TrimSpace(c.IfMatch) == "*" {
+		return nil
+	}
+
+	res := []time.Time{}
+
+	for _, tag := range strings.Split(c.IfMatch, ",") {
+		version, ok := parseVersionETag(strings.TrimSpace(tag))
+		if ok {
+			res = append(res, version)
+		}
+	}
+
+	return res
+}
+
+// MatchesVersion checks the version against If-Match the same way the repositories do when updating.
+func (c *ConditionalParams) MatchesVersion(modified time.Time) bool {
+	versions := c.IfMatchVersions()
+	if versions == nil {
+		return true
+	}
+
+	for _, version := range versions {
+		if version.Equal(modified) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// VersionETag is the strong ETag of a resource versioned by the time of its last change, kept in microseconds as
+// the database does.
+func VersionETag(modified time.Time) string {
+	return `"` + strconv.FormatInt(modified.UnixMicro(), 36) + `"`
+}
+
+func parseVersionETag(tag string) (time.Time, bool) {
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return time.Time{}, false
+	}
+
+	micro, err := strconv.ParseInt(tag[1:len(tag)-1], 36, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return time.UnixMicro(micro), true
+}
+
+// BodyETag is the strong ETag of a response without a version, derived from its bytes.
+func BodyETag(body []byte) string {
+	sum := sha256.Sum256(body)
+
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+func GetConditionalParams(ctx context.Context) *ConditionalParams {
+	params, ok := ctx.Value(ConditionalKey).(*ConditionalParams)
+	if !ok {
+		return &ConditionalParams{}
+	}
+
+	return params
+}
+
+// ConditionalMiddleware keeps the conditional headers of the request for Response and for the updates checking
+// If-Match.
+func ConditionalMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), ConditionalKey, NewConditionalParams(r))
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,226 @@
+package pkg
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestConditionalMiddleware(t *testing.T) {
+	body := ErrResponse{ErrMassage: "hello"}
+
+	out, err := getEasyJSON(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bodyTag := BodyETag(out)
+
+	modified := time.Date(2024, 3, 1, 12, 0, 0, 500, time.UTC)
+	versionTag := VersionETag(modified)
+
+	tests := []struct {
+		name      string
+		method    string
+		headers   map[string]string
+		versioned bool
+		status    int
+		wantCode  int
+		wantETag  string
+	}{
+		{
+			name:     "no conditions",
+			method:   http.MethodGet,
+			status:   http.StatusOK,
+			wantCode: http.StatusOK,
+			wantETag: bodyTag,
+		},
+		{
+			name:     "matching body tag",
+			method:   http.MethodGet,
+			headers:  map[string]string{"If-None-Match": bodyTag},
+			status:   http.StatusOK,
+			wantCode: http.StatusNotModified,
+			wantETag: bodyTag,
+		},
+		{
+			name:     "weak comparison",
+			method:   http.MethodGet,
+			headers:  map[string]string{"If-None-Match": `"other", W/` + bodyTag},
+			status:   http.StatusOK,
+			wantCode: http.StatusNotModified,
+			wantETag: bodyTag,
+		},
+		{
+			name:     "any tag",
+			method:   http.MethodGet,
+			headers:  map[string]string{"If-None-Match": "*"},
+			status:   http.StatusOK,
+			wantCode: http.StatusNotModified,
+			wantETag: bodyTag,
+		},
+		{
+			name:     "stale tag",
+			method:   http.MethodGet,
+			headers:  map[string]string{"If-None-Match": `"other"`},
+			status:   http.StatusOK,
+			wantCode: http.StatusOK,
+			wantETag: bodyTag,
+		},
+		{
+			name:      "version tag is kept",
+			method:    http.MethodGet,
+			headers:   map[string]string{"If-None-Match": versionTag},
+			versioned: true,
+			status:    http.StatusOK,
+			wantCode:  http.StatusNotModified,
+			wantETag:  versionTag,
+		},
+		{
+			name:      "not modified since",
+			method:    http.MethodGet,
+			headers:   map[string]string{"If-Modified-Since": modified.Format(http.TimeFormat)},
+			versioned: true,
+			status:    http.StatusOK,
+			wantCode:  http.StatusNotModified,
+			wantETag:  versionTag,
+		},
+		{
+			name:      "modified since",
+			method:    http.MethodGet,
+			headers:   map[string]string{"If-Modified-Since": modified.Add(-time.Second).Format(http.TimeFormat)},
+			versioned: true,
+			status:    http.StatusOK,
+			wantCode:  http.StatusOK,
+			wantETag:  versionTag,
+		},
+		{
+			name: "tags take precedence over dates",
+			headers: map[string]string{
+				"If-None-Match":     `"other"`,
+				"If-Modified-Since": modified.Format(http.TimeFormat),
+			},
+			method:    http.MethodGet,
+			versioned: true,
+			status:    http.StatusOK,
+			wantCode:  http.StatusOK,
+			wantETag:  versionTag,
+		},
+		{
+			name:     "updates are not conditional",
+			method:   http.MethodPost,
+			headers:  map[string]string{"If-None-Match": bodyTag},
+			status:   http.StatusOK,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "only successful reads",
+			method:   http.MethodGet,
+			headers:  map[string]string{"If-None-Match": "*"},
+			status:   http.StatusNotFound,
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, "/api/thread/1/details", nil)
+			for key, value := range tt.headers {
+				r.Header.Set(key, value)
+			}
+
+			w := httptest.NewRecorder()
+
+			ConditionalMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.versioned {
+					SetValidators(w, VersionETag(modified), modified)
+				}
+
+				Response(r.Context(), w, tt.status, body)
+			})).ServeHTTP(w, r)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("code = %d, want %d", w.Code, tt.wantCode)
+			}
+
+			if got := w.Header().Get("ETag"); got != tt.wantETag {
+				t.Errorf("ETag = %s, want %s", got, tt.wantETag)
+			}
+
+			if tt.wantCode == http.StatusNotModified && w.Body.Len() != 0 {
+				t.Errorf("304 with a body: %s", w.Body.String())
+			}
+
+			if tt.wantCode != http.StatusNotModified && w.Body.String() != string(out) {
+				t.Errorf("body = %s, want %s", w.Body.String(), out)
+			}
+		})
+	}
+}
+
+func TestIfMatchVersions(t *testing.T) {
+	first := time.UnixMicro(1700000000123456)
+	second := first.Add(time.Millisecond)
+
+	tests := []struct {
+		name    string
+		ifMatch string
+		want    []time.Time
+		matches map[time.Time]bool
+	}{
+		{
+			name:    "no header",
+			want:    nil,
+			matches: map[time.Time]bool{first: true, second: true},
+		},
+		{
+			name:    "any version",
+			ifMatch: " * ",
+			want:    nil,
+			matches: map[time.Time]bool{first: true, second: true},
+		},
+		{
+			name:    "one version",
+			ifMatch: VersionETag(first),
+			want:    []time.Time{first},
+			matches: map[time.Time]bool{first: true, second: false},
+		},
+		{
+			name:    "several versions",
+			ifMatch: VersionETag(first) + ", " + VersionETag(second),
+			want:    []time.Time{first, second},
+			matches: map[time.Time]bool{first: true, second: true},
+		},
+		{
+			name:    "weak and foreign tags never match",
+			ifMatch: `W/` + VersionETag(first) + `, "not-a-version!"`,
+			want:    []time.Time{},
+			matches: map[time.Time]bool{first: false, second: false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := &ConditionalParams{IfMatch: tt.ifMatch}
+
+			got := params.IfMatchVersions()
+
+			if (got == nil) != (tt.want == nil) || len(got) != len(tt.want) {
+				t.Fatalf("IfMatchVersions() = %v, want %v", got, tt.want)
+			}
+
+			for idx := range got {
+				if !got[idx].Equal(tt.want[idx]) {
+					t.Errorf("version %d = %v, want %v", idx, got[idx], tt.want[idx])
+				}
+			}
+
+			for version, want := range tt.matches {
+				if params.MatchesVersion(version) != want {
+					t.Errorf("MatchesVersion(%v) = %v, want %v", version, !want, want)
+				}
+			}
+		})
+	}
+}
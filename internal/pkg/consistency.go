@@ -0,0 +1,118 @@
+package pkg
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// ReadSession tells the repositories whether reads may go to a replica. Once the session has written, or when
+// strong consistency was asked for, its reads go to the primary so that it sees its own writes. Client is the
+// read session cookie, which carries this on to the next requests of clients without a nickname.
+type ReadSession struct {
+	strong bool
+	wrote  int32
+	client string
+}
+
+func NewReadSession(strong bool) *ReadSession {
+	return &ReadSession{strong: strong}
+}
+
+func (s *ReadSession) Client() string {
+	return s.client
+}
+
+func (s *ReadSession) MarkWrite() {
+	atomic.StoreInt32(&s.wrote, 1)
+}
+
+func (s *ReadSession) NeedsPrimary() bool {
+	return s.strong || atomic.LoadInt32(&s.wrote) == 1
+}
+
+func WithReadSession(ctx context.Context, session *ReadSession) context.Context {
+	return context.WithValue(ctx, ReadSessionKey, session)
+}
+
+func GetReadSession(ctx context.Context) *ReadSession {
+	session, _ := ctx.Value(ReadSessionKey).(*ReadSession)
+
+	return session
+}
+
+// WithPrimaryReads returns ctx whose reads go to the primary past the caches, for reads which must see the latest
+// state, such as the version checks of optimistic updates. It is for reads only: a write would mark the session
+// made here rather than that of the request.
+func WithPrimaryReads(ctx context.Context) context.Context {
+	return WithReadSession(ctx, NewReadSession(true))
+}
+
+// ReadsPrimary tells whether reads of ctx must see the latest state, so neither a replica nor a cache may serve them.
+func ReadsPrimary(ctx context.Context) bool {
+	session := GetReadSession(ctx)
+
+	return session != nil && session.NeedsPrimary()
+}
+
+// ConsistencyMiddleware starts a read session for the request, strong with the X-Consistency: strong header.
+// Sub-requests of a batch keep the session of the batch, so later items see the writes of earlier ones.
+//
+// Requests which may write get the read session cookie if they come without one, so that the primary serves the
+// next reads of the client for the sticky window even when it sends no nickname.
+func ConsistencyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		strong := strings.EqualFold(r.Header.Get(HeaderConsistency), ConsistencyStrong)
+
+		session := GetReadSession(r.Context())
+		if session == nil || strong && !session.strong {
+			client := readSessionClient(w, r, session)
+
+			session = NewReadSession(strong)
+			session.client = client
+
+			r = r.WithContext(WithReadSession(r.Context(), session))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// readSessionClient returns the client of the enclosing session, or that of the cookie, or a new one set in the
+// cookie of the response when the request may write.
+func readSessionClient(w http.ResponseWriter, r *http.Request, enclosing *ReadSession) string {
+	if enclosing != nil {
+		return enclosing.client
+	}
+
+	cookie, err := r.Cookie(CookieReadSession)
+	if err == nil && cookie.Value != "" {
+		return cookie.Value
+	}
+
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return ""
+	}
+
+	token := make([]byte, 16)
+
+	_, err = rand.Read(token)
+	if err != nil {
+		return ""
+	}
+
+	client := hex.EncodeToString(token)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     CookieReadSession,
+		Value:    client,
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	return client
+}
@@ -0,0 +1,78 @@
+package pkg
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReadsPrimary(t *testing.T) {
+	written := NewReadSession(false)
+	written.MarkWrite()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want bool
+	}{
+		{name: "no session", ctx: context.Background(), want: false},
+		{name: "eventual session", ctx: WithReadSession(context.Background(), NewReadSession(false)), want: false},
+		{name: "strong session", ctx: WithReadSession(context.Background(), NewReadSession(true)), want: true},
+		{name: "session which wrote", ctx: WithReadSession(context.Background(), written), want: true},
+		{name: "primary reads", ctx: WithPrimaryReads(WithReadSession(context.Background(), NewReadSession(false))), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ReadsPrimary(tt.ctx); got != tt.want {
+				t.Errorf("ReadsPrimary() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConsistencyMiddlewareCookie(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		cookie     string
+		wantClient bool
+		wantSet    bool
+	}{
+		{name: "read without cookie", method: http.MethodGet},
+		{name: "write without cookie", method: http.MethodPost, wantClient: true, wantSet: true},
+		{name: "read with cookie", method: http.MethodGet, cookie: "c1", wantClient: true},
+		{name: "write with cookie", method: http.MethodPost, cookie: "c1", wantClient: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, "/api/forum/create", nil)
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: CookieReadSession, Value: tt.cookie})
+			}
+
+			w := httptest.NewRecorder()
+
+			var client string
+
+			ConsistencyMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				client = GetReadSession(r.Context()).Client()
+			})).ServeHTTP(w, r)
+
+			if (client != "") != tt.wantClient {
+				t.Errorf("client = %q, wantClient %v", client, tt.wantClient)
+			}
+
+			if tt.cookie != "" && client != tt.cookie {
+				t.Errorf("client = %q, want the cookie %q", client, tt.cookie)
+			}
+
+			set := len(w.Result().Cookies()) != 0
+			if set != tt.wantSet {
+				t.Errorf("cookie set = %v, want %v", set, tt.wantSet)
+			}
+		})
+	}
+}
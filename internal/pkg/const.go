@@ -3,8 +3,12 @@ package pkg
 import "database/sql"
 
 const (
-	ContentTypeJSON = "application/json"
-	BufSizeRequest  = 1024 * 1024 * 1
+	ContentTypeJSON        = "application/json"
+	ContentTypeEventStream = "text/event-stream"
+	ContentTypeAtom        = "application/atom+xml"
+	ContentTypeRSS         = "application/rss+xml"
+	ContentTypeNDJSON      = "application/x-ndjson"
+	BufSizeRequest         = 1024 * 1024 * 1
 )
 
 type ContextKeyType string
@@ -15,8 +19,33 @@ const RequestID = "req-id"
 
 var RequestIDKey ContextKeyType = RequestID
 
+const HeaderRequestID = "X-Request-ID"
+
 var LoggerKey ContextKeyType = "logger"
 
+const HeaderNickname = "X-Nickname"
+
+// HeaderNicknameSignature proves the nickname when IDENTITY_SECRET is set, see AuthenticateNickname.
+const (
+	HeaderNicknameSignature = "X-Nickname-Signature"
+	EnvIdentitySecret       = "IDENTITY_SECRET"
+	EnvIdentityTrusted      = "IDENTITY_TRUSTED"
+)
+
+var NicknameKey ContextKeyType = "nickname"
+
+const (
+	HeaderConsistency = "X-Consistency"
+	ConsistencyStrong = "strong"
+)
+
+// CookieReadSession tells apart clients without a nickname, so that they read their own writes too.
+const CookieReadSession = "forum_session"
+
+var ReadSessionKey ContextKeyType = "read-session"
+
+var ConditionalKey ContextKeyType = "conditional"
+
 var TxInsertOptions = &sql.TxOptions{
 	Isolation: sql.LevelDefault,
 	ReadOnly:  false,
@@ -27,7 +56,154 @@ const (
 	TypeSortTree       = "tree"
 	TypeSortParentTree = "parent_tree"
 
+	// Threads of a forum are listed by creation time, which since and desc apply to, or ranked
+	ThreadSortCreated = "created"
+	ThreadSortHot     = "hot"
+	ThreadSortTop     = "top"
+	ThreadSortActive  = "active"
+
+	// ThreadSortTop counts the threads created within the window
+	ThreadWindowDay   = "day"
+	ThreadWindowWeek  = "week"
+	ThreadWindowMonth = "month"
+	ThreadWindowYear  = "year"
+	ThreadWindowAll   = "all"
+
 	PostDetailForum  = "forum"
 	PostDetailThread = "thread"
 	PostDetailAuthor = "user"
 )
+
+const (
+	ForumVisibilityPublic   = "public"
+	ForumVisibilityPrivate  = "private"
+	ForumVisibilityReadOnly = "read-only"
+
+	ForumMemberInvited = "invited"
+	ForumMemberJoined  = "member"
+
+	StatsPeriodDay  = "day"
+	StatsPeriodWeek = "week"
+)
+
+const (
+	EventThreadCreated = "thread.created"
+	EventPostCreated   = "post.created"
+	EventPostUpdated   = "post.updated"
+	EventVoteChanged   = "vote.changed"
+	EventUserUpdated   = "user.updated"
+
+	EventsChannel = "forum_events"
+)
+
+// StreamRoutePrefix marks routes serving long-lived connections, which are not bound by the request timeout.
+const StreamRoutePrefix = "stream"
+
+const BatchRouteName = "batch"
+
+const (
+	TopicForum  = "forum"
+	TopicThread = "thread"
+	TopicUser   = "user"
+)
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryDead      = "dead"
+
+	HeaderWebhookEvent     = "X-Webhook-Event"
+	HeaderWebhookDelivery  = "X-Webhook-Delivery"
+	HeaderWebhookTimestamp = "X-Webhook-Timestamp"
+	HeaderWebhookSignature = "X-Webhook-Signature"
+)
+
+const (
+	TransferUser   = "user"
+	TransferForum  = "forum"
+	TransferMember = "member"
+	TransferThread = "thread"
+	TransferPost   = "post"
+	TransferVote   = "vote"
+	TransferEnd    = "end"
+
+	TransferKeepIDs  = "keep"
+	TransferRemapIDs = "remap"
+)
+
+const (
+	HeaderAdminToken = "X-Admin-Token"
+	EnvAdminToken    = "ADMIN_TOKEN"
+)
+
+const (
+	EnvReplicaDSNs   = "POSTGRES_REPLICAS"
+	EnvReplicaSticky = "POSTGRES_REPLICA_STICKY"
+	ReplicaDSNsDelim = ";"
+)
+
+const (
+	EnvCacheSize = "CACHE_SIZE"
+	EnvCacheTTL  = "CACHE_TTL"
+)
+
+const (
+	UserCreateStatusCreated  = "created"
+	UserCreateStatusConflict = "conflict"
+)
+
+const (
+	EnvRateLimitIP      = "RATE_LIMIT_IP"
+	EnvRateLimitUser    = "RATE_LIMIT_USER"
+	EnvRateLimitRoutes  = "RATE_LIMIT_ROUTES"
+	EnvRateLimitShared  = "RATE_LIMIT_SHARED"
+	EnvFloodInterval    = "FLOOD_INTERVAL"
+	RateLimitRulesDelim = ";"
+)
+
+const (
+	AuditEntityUser    = "user"
+	AuditEntityForum   = "forum"
+	AuditEntityThread  = "thread"
+	AuditEntityPost    = "post"
+	AuditEntityVote    = "vote"
+	AuditEntityService = "service"
+
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionClear   = "clear"
+	AuditActionRecount = "recount"
+	AuditActionRepair  = "repair"
+)
+
+const (
+	EnvConsistencyInterval = "CONSISTENCY_INTERVAL"
+	EnvConsistencyRepair   = "CONSISTENCY_REPAIR"
+
+	ConsistencyForumCounters  = "forum_counters"
+	ConsistencyThreadCounters = "thread_counters"
+	ConsistencyPostPaths      = "post_paths"
+	ConsistencyUserForums     = "user_forums"
+)
+
+const (
+	SeedProfileSmall    = "small"
+	SeedProfileMedium   = "medium"
+	SeedProfileDeepTree = "deep-tree"
+	SeedProfileHotForum = "hot-forum"
+)
+
+const (
+	EnvAttachmentDir       = "ATTACHMENT_DIR"
+	EnvAttachmentMaxSize   = "ATTACHMENT_MAX_SIZE"
+	EnvAttachmentTypes     = "ATTACHMENT_TYPES"
+	EnvAttachmentOrphanTTL = "ATTACHMENT_ORPHAN_TTL"
+	AttachmentTypesDelim   = ","
+
+	AttachmentFormField = "file"
+)
+
+const (
+	EnvWSOrigins   = "WS_ALLOWED_ORIGINS"
+	WSOriginsDelim = ","
+)
@@ -1,7 +1,10 @@
 package pkg
 
 import (
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -23,6 +26,23 @@ var (
 	ErrGetParamsConvert         = errors.New("err get sql params")
 	ErrUnsupportedSortParameter = errors.New("unsupported sort parameter")
 
+	ErrStreamUnsupported = errors.New("streaming unsupported")
+	ErrEventsExpired     = errors.New("events after Last-Event-ID are expired, resubscribe without it")
+	ErrSuchTopicNotFound = errors.New("such topic not found")
+
+	ErrSuchWebhookNotFound  = errors.New("such webhook not found")
+	ErrSuchDeliveryNotFound = errors.New("such delivery not found")
+	ErrWebhookEventUnknown  = errors.New("unknown webhook event")
+	ErrWebhookURLForbidden  = errors.New("webhook url must resolve to public addresses")
+	ErrWebhookNoSnapshot    = errors.New("webhook delivery has no event data")
+
+	ErrBatchRouteUnsupported = errors.New("route is not allowed in a batch")
+
+	ErrAdminRequired   = errors.New("admin token required")
+	ErrImportInvalid   = errors.New("invalid import record")
+	ErrImportTruncated = errors.New("import is truncated")
+	ErrImportConflict  = errors.New("imported data conflicts with existing data")
+
 	ErrBigRequest    = errors.New("big request")
 	ErrConvertLength = errors.New("getting content-length failed")
 
@@ -38,10 +58,60 @@ var (
 	ErrPostParentNotFound  = errors.New("such post parent not found")
 	ErrInvalidParent       = errors.New("parent not valid")
 
-	ErrSuchForumNotFound = errors.New("such forum not fount")
-	ErrSuchForumExist    = errors.New("such forum exist")
+	ErrSuchForumNotFound       = errors.New("such forum not fount")
+	ErrSuchForumExist          = errors.New("such forum exist")
+	ErrSuchParentForumNotFound = errors.New("such parent forum not found")
+	ErrForumReadOnly           = errors.New("forum is read-only")
+	ErrForumAccessDenied       = errors.New("forum access denied")
+	ErrSuchMemberExist         = errors.New("such member exist")
+	ErrSuchMemberNotFound      = errors.New("such member not found")
+	ErrSuchInviteNotFound      = errors.New("such invite not found")
+
+	ErrAuthRequired    = errors.New("authorization required")
+	ErrIdentityInvalid = errors.New("invalid nickname signature")
+	ErrIdentityOff     = errors.New("nicknames are not accepted, neither IDENTITY_SECRET nor IDENTITY_TRUSTED is set")
+
+	ErrPreconditionFailed = errors.New("resource was changed meanwhile")
+	ErrVersionConflict    = errors.New("resource version conflict")
+
+	ErrTooManyRequests = errors.New("too many requests")
+
+	ErrSeedProfileUnknown = errors.New("unknown seed profile")
+
+	ErrSuchAttachmentNotFound    = errors.New("such attachment not found")
+	ErrAttachmentMissing         = errors.New("attachment file missing")
+	ErrAttachmentTooBig          = errors.New("attachment is too big")
+	ErrAttachmentTypeUnsupported = errors.New("attachment type not allowed")
+	ErrAttachmentUnavailable     = errors.New("attachment is missing or attached already")
 )
 
+// RetryError refuses a request with ErrTooManyRequests, telling when it is worth trying again.
+type RetryError struct {
+	After time.Duration
+}
+
+func NewRetryError(after time.Duration) *RetryError {
+	return &RetryError{After: after}
+}
+
+func (e *RetryError) Error() string {
+	return ErrTooManyRequests.Error()
+}
+
+func (e *RetryError) Cause() error {
+	return ErrTooManyRequests
+}
+
+// RetryAfter is the value of the Retry-After header, in whole seconds rounded up.
+func (e *RetryError) RetryAfter() string {
+	seconds := int64(math.Ceil(e.After.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	return strconv.FormatInt(seconds, 10)
+}
+
 type ErrHTTPClassifier struct {
 	table map[string]int
 }
@@ -66,6 +136,22 @@ func NewErrHTTPClassifier() ErrHTTPClassifier {
 	res[ErrGetParamsConvert.Error()] = http.StatusInternalServerError
 	res[ErrUnsupportedSortParameter.Error()] = http.StatusBadRequest
 
+	res[ErrStreamUnsupported.Error()] = http.StatusInternalServerError
+	res[ErrEventsExpired.Error()] = http.StatusGone
+	res[ErrSuchTopicNotFound.Error()] = http.StatusNotFound
+
+	res[ErrSuchWebhookNotFound.Error()] = http.StatusNotFound
+	res[ErrSuchDeliveryNotFound.Error()] = http.StatusNotFound
+	res[ErrWebhookEventUnknown.Error()] = http.StatusBadRequest
+	res[ErrWebhookURLForbidden.Error()] = http.StatusBadRequest
+
+	res[ErrBatchRouteUnsupported.Error()] = http.StatusBadRequest
+
+	res[ErrAdminRequired.Error()] = http.StatusForbidden
+	res[ErrImportInvalid.Error()] = http.StatusBadRequest
+	res[ErrImportTruncated.Error()] = http.StatusBadRequest
+	res[ErrImportConflict.Error()] = http.StatusConflict
+
 	res[ErrBigRequest.Error()] = http.StatusBadRequest
 	res[ErrConvertLength.Error()] = http.StatusBadRequest
 
@@ -73,6 +159,7 @@ func NewErrHTTPClassifier() ErrHTTPClassifier {
 	res[ErrSuchUserNotFound.Error()] = http.StatusNotFound
 	res[ErrUpdateUserDataConflict.Error()] = http.StatusConflict
 
+	res[ErrSuchThreadExist.Error()] = http.StatusConflict
 	res[ErrSuchThreadNotFound.Error()] = http.StatusNotFound
 
 	res[ErrNoSuchRuleSortPosts.Error()] = http.StatusNotFound
@@ -80,9 +167,31 @@ func NewErrHTTPClassifier() ErrHTTPClassifier {
 	res[ErrPostParentNotFound.Error()] = http.StatusNotFound
 	res[ErrPostParentNotFound.Error()] = http.StatusConflict
 
+	res[ErrSuchForumExist.Error()] = http.StatusConflict
 	res[ErrSuchForumNotFound.Error()] = http.StatusNotFound
+	res[ErrSuchParentForumNotFound.Error()] = http.StatusNotFound
+	res[ErrForumReadOnly.Error()] = http.StatusForbidden
+	res[ErrForumAccessDenied.Error()] = http.StatusForbidden
+	res[ErrSuchMemberExist.Error()] = http.StatusConflict
+	res[ErrSuchMemberNotFound.Error()] = http.StatusNotFound
+	res[ErrSuchInviteNotFound.Error()] = http.StatusNotFound
+
+	res[ErrAuthRequired.Error()] = http.StatusUnauthorized
+	res[ErrIdentityInvalid.Error()] = http.StatusUnauthorized
+	res[ErrIdentityOff.Error()] = http.StatusUnauthorized
+	res[ErrPreconditionFailed.Error()] = http.StatusPreconditionFailed
+	res[ErrVersionConflict.Error()] = http.StatusConflict
+	res[ErrTooManyRequests.Error()] = http.StatusTooManyRequests
 	res[ErrInvalidParent.Error()] = http.StatusConflict
 
+	res[ErrSeedProfileUnknown.Error()] = http.StatusBadRequest
+
+	res[ErrSuchAttachmentNotFound.Error()] = http.StatusNotFound
+	res[ErrAttachmentMissing.Error()] = http.StatusBadRequest
+	res[ErrAttachmentTooBig.Error()] = http.StatusRequestEntityTooLarge
+	res[ErrAttachmentTypeUnsupported.Error()] = http.StatusUnsupportedMediaType
+	res[ErrAttachmentUnavailable.Error()] = http.StatusConflict
+
 	return ErrHTTPClassifier{
 		table: res,
 	}
@@ -0,0 +1,61 @@
+package grpctools
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/protoadapt"
+
+	"project/internal/pkg"
+)
+
+var codesByHTTP = map[int]codes.Code{
+	http.StatusBadRequest:           codes.InvalidArgument,
+	http.StatusUnauthorized:         codes.Unauthenticated,
+	http.StatusForbidden:            codes.PermissionDenied,
+	http.StatusNotFound:             codes.NotFound,
+	http.StatusConflict:             codes.AlreadyExists,
+	http.StatusPreconditionFailed:   codes.FailedPrecondition,
+	http.StatusTooManyRequests:      codes.ResourceExhausted,
+	http.StatusUnsupportedMediaType: codes.InvalidArgument,
+}
+
+// codesByError overrides the classification of REST where gRPC has a more precise code.
+var codesByError = map[error]codes.Code{
+	pkg.ErrVersionConflict: codes.Aborted,
+}
+
+// Error classifies err the same way REST does and converts it into a status. The details, such as the existing
+// entities of a conflict, are attached to it.
+func Error(err error, details ...proto.Message) error {
+	cause := errors.Cause(err)
+
+	httpCode, _ := pkg.GetErrorCodeHTTP(cause)
+
+	code, ok := codesByError[cause]
+	if !ok {
+		code, ok = codesByHTTP[httpCode]
+	}
+	if !ok {
+		return status.Error(codes.Internal, cause.Error())
+	}
+
+	res := status.New(code, cause.Error())
+
+	if len(details) != 0 {
+		adapted := make([]protoadapt.MessageV1, len(details))
+		for idx, detail := range details {
+			adapted[idx] = protoadapt.MessageV1Of(detail)
+		}
+
+		withDetails, detailsErr := res.WithDetails(adapted...)
+		if detailsErr == nil {
+			res = withDetails
+		}
+	}
+
+	return res.Err()
+}
@@ -0,0 +1,65 @@
+package grpctools
+
+import (
+	"context"
+	"net"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+
+	"project/internal/pkg"
+)
+
+const inProcessBufferSize = 1024 * 1024
+
+// InProcess serves a server over an in-memory listener, so a client can call it without a network port, as tests
+// and tools embedding the services do.
+type InProcess struct {
+	server   *grpc.Server
+	listener *bufconn.Listener
+	Conn     *grpc.ClientConn
+}
+
+// NewInProcess starts server, with its services already registered, and connects a client to it.
+func NewInProcess(server *grpc.Server) (*InProcess, error) {
+	listener := bufconn.Listen(inProcessBufferSize)
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		server.Stop()
+		return nil, err
+	}
+
+	return &InProcess{server: server, listener: listener, Conn: conn}, nil
+}
+
+func (p *InProcess) Close() error {
+	err := p.Conn.Close()
+
+	p.server.Stop()
+
+	return err
+}
+
+// WithNickname makes the calls with ctx act as the user, signing the nickname when IDENTITY_SECRET is set.
+func WithNickname(ctx context.Context, nickname string) context.Context {
+	secret := os.Getenv(pkg.EnvIdentitySecret)
+	if secret == "" {
+		return metadata.AppendToOutgoingContext(ctx, MetadataNickname, nickname)
+	}
+
+	signature := pkg.SignNickname(secret, nickname)
+
+	return metadata.AppendToOutgoingContext(ctx, MetadataNickname, nickname, MetadataNicknameSignature, signature)
+}
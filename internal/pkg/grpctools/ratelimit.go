@@ -0,0 +1,69 @@
+package grpctools
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+
+	"project/internal/pb"
+	"project/internal/pkg"
+	"project/internal/pkg/ratelimit"
+)
+
+// RateLimitUnaryInterceptor does for a call what ratelimit.Middleware does for a request. Routes are keyed by the
+// full method name, as in "/forum.ThreadService/CreatePosts".
+func RateLimitUnaryInterceptor(limiter ratelimit.Limiter, rules ratelimit.Rules) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !rules.Enabled() {
+			return handler(ctx, req)
+		}
+
+		nicknames := requestActors(req)
+		if len(nicknames) == 0 {
+			nicknames = []string{pkg.GetNickname(ctx)}
+		}
+
+		err := rules.Check(ctx, limiter, info.FullMethod, peerIP(ctx), nicknames)
+		if err != nil {
+			return nil, Error(err)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// requestActors returns who a call acts as, by its message, like the body of a request for ratelimit.Middleware.
+func requestActors(req interface{}) []string {
+	switch message := req.(type) {
+	case *pb.CreateThreadRequest:
+		return []string{message.GetThread().GetAuthor()}
+	case *pb.CreatePostsRequest:
+		res := make([]string, 0, len(message.GetPosts()))
+
+		for _, post := range message.GetPosts() {
+			res = append(res, post.GetAuthor())
+		}
+
+		return res
+	case *pb.VoteRequest:
+		return []string{message.GetNickname()}
+	default:
+		return nil
+	}
+}
+
+func peerIP(ctx context.Context) string {
+	client, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(client.Addr.String())
+	if err != nil {
+		return client.Addr.String()
+	}
+
+	return host
+}
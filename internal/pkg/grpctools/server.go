@@ -0,0 +1,128 @@
+package grpctools
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"project/internal/pkg"
+)
+
+const ServerAddr = ":5001"
+
+// MetadataNickname carries the acting user, as the X-Nickname header does for REST.
+var MetadataNickname = strings.ToLower(pkg.HeaderNickname)
+
+// MetadataNicknameSignature proves the nickname, as the X-Nickname-Signature header does for REST.
+var MetadataNicknameSignature = strings.ToLower(pkg.HeaderNicknameSignature)
+
+// MetadataRequestID identifies the call in the audit log, as the X-Request-ID header does for REST.
+var MetadataRequestID = strings.ToLower(pkg.HeaderRequestID)
+
+// MetadataConsistency asks for reads from the primary, as the X-Consistency header does for REST.
+var MetadataConsistency = strings.ToLower(pkg.HeaderConsistency)
+
+// NewServer creates a server with the same identity and timeout rules as the REST router. Streams, like the
+// stream routes there, are not bound by the request timeout. The interceptors run after the identity is known.
+func NewServer(interceptors ...grpc.UnaryServerInterceptor) *grpc.Server {
+	unary := append([]grpc.UnaryServerInterceptor{identityUnaryInterceptor}, interceptors...)
+	unary = append(unary, timeoutUnaryInterceptor(pkg.RequestTimeout))
+
+	return grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(identityStreamInterceptor),
+	)
+}
+
+func Launch(server *grpc.Server, addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	err = server.Serve(listener)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// withIdentity does for a call what IdentityMiddleware, RequestIDMiddleware and ConsistencyMiddleware do for a
+// request.
+func withIdentity(ctx context.Context) (context.Context, error) {
+	consistency := metadata.ValueFromIncomingContext(ctx, MetadataConsistency)
+	strong := len(consistency) != 0 && strings.EqualFold(consistency[0], pkg.ConsistencyStrong)
+
+	ctx = pkg.WithReadSession(ctx, pkg.NewReadSession(strong))
+
+	requestID := metadata.ValueFromIncomingContext(ctx, MetadataRequestID)
+	if len(requestID) == 0 || requestID[0] == "" {
+		ctx = pkg.WithRequestID(ctx, pkg.NewRequestID())
+	} else {
+		ctx = pkg.WithRequestID(ctx, requestID[0])
+	}
+
+	nickname, err := pkg.AuthenticateNickname(firstValue(ctx, MetadataNickname), firstValue(ctx, MetadataNicknameSignature))
+	if err != nil {
+		return nil, Error(err)
+	}
+
+	if nickname == "" {
+		return ctx, nil
+	}
+
+	return context.WithValue(ctx, pkg.NicknameKey, nickname), nil
+}
+
+func firstValue(ctx context.Context, key string) string {
+	values := metadata.ValueFromIncomingContext(ctx, key)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+func identityUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := withIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+type identityStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *identityStream) Context() context.Context {
+	return s.ctx
+}
+
+func identityStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := withIdentity(ss.Context())
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &identityStream{ServerStream: ss, ctx: ctx})
+}
+
+func timeoutUnaryInterceptor(timeout time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		return handler(ctx, req)
+	}
+}
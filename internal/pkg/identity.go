@@ -0,0 +1,55 @@
+package pkg
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"strings"
+)
+
+// SignNickname is the signature of X-Nickname-Signature, an HMAC-SHA256 of the lowercased nickname keyed by
+// IDENTITY_SECRET. The service authenticating the users issues it along with their session.
+func SignNickname(secret string, nickname string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strings.ToLower(nickname)))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// IdentityTrusted reports whether nicknames are taken without a signature: IDENTITY_SECRET is unset and
+// IDENTITY_TRUSTED=1 opts in. This is only safe behind a proxy which authenticates the users, sets X-Nickname itself
+// and drops the one of the client.
+func IdentityTrusted() bool {
+	return os.Getenv(EnvIdentitySecret) == "" && os.Getenv(EnvIdentityTrusted) == "1"
+}
+
+// IdentityOff reports whether no nickname is accepted, neither a secret nor trust being configured.
+func IdentityOff() bool {
+	return os.Getenv(EnvIdentitySecret) == "" && !IdentityTrusted()
+}
+
+// AuthenticateNickname returns the nickname the request acts as, empty for an anonymous one. With IDENTITY_SECRET
+// set a nickname needs its signature, and ErrIdentityInvalid refuses one without, rather than serving it as anonymous
+// and hiding the mistake. Without the secret a nickname is taken as sent only if IdentityTrusted, otherwise
+// ErrIdentityOff refuses it: an unconfigured server must not let a header pick the user.
+func AuthenticateNickname(nickname string, signature string) (string, error) {
+	if nickname == "" {
+		return "", nil
+	}
+
+	secret := os.Getenv(EnvIdentitySecret)
+	if secret == "" {
+		if !IdentityTrusted() {
+			return "", ErrIdentityOff
+		}
+
+		return nickname, nil
+	}
+
+	if !hmac.Equal([]byte(SignNickname(secret, nickname)), []byte(strings.ToLower(signature))) {
+		return "", ErrIdentityInvalid
+	}
+
+	return nickname, nil
+}
@@ -0,0 +1,44 @@
+package pkg
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAuthenticateNickname(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		trusted   string
+		nickname  string
+		signature string
+		want      string
+		wantErr   error
+	}{
+		{name: "anonymous without configuration", nickname: "", want: ""},
+		{name: "nickname without configuration", nickname: "user", wantErr: ErrIdentityOff},
+		{name: "trusted proxy", trusted: "1", nickname: "user", want: "user"},
+		{name: "trust other than 1", trusted: "yes", nickname: "user", wantErr: ErrIdentityOff},
+		{name: "signed", secret: "secret", nickname: "User", signature: SignNickname("secret", "user"), want: "User"},
+		{name: "unsigned with secret", secret: "secret", nickname: "user", wantErr: ErrIdentityInvalid},
+		{name: "wrong signature", secret: "secret", nickname: "user", signature: SignNickname("other", "user"), wantErr: ErrIdentityInvalid},
+		{name: "secret wins over trust", secret: "secret", trusted: "1", nickname: "user", wantErr: ErrIdentityInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvIdentitySecret, tt.secret)
+			t.Setenv(EnvIdentityTrusted, tt.trusted)
+
+			got, err := AuthenticateNickname(tt.nickname, tt.signature)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("nickname = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package pkg
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// IdentityMiddleware stores the nickname of the requesting user, taken from the X-Nickname header and checked by
+// AuthenticateNickname, in the request context.
+func IdentityMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nickname, err := AuthenticateNickname(r.Header.Get(HeaderNickname), r.Header.Get(HeaderNicknameSignature))
+		if err != nil {
+			DefaultHandlerHTTPError(r.Context(), w, err)
+			return
+		}
+
+		if nickname != "" {
+			r = r.WithContext(context.WithValue(r.Context(), NicknameKey, nickname))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func GetNickname(ctx context.Context) string {
+	nickname, _ := ctx.Value(NicknameKey).(string)
+
+	return nickname
+}
+
+// RequestIDMiddleware keeps the X-Request-ID of the request in the request context and echoes it in the response.
+// Without the header the ID is new, except for the sub-requests of a batch, which share the ID of the batch.
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(HeaderRequestID)
+		if requestID == "" {
+			requestID = GetRequestID(r.Context())
+		}
+		if requestID == "" {
+			requestID = NewRequestID()
+		}
+
+		w.Header().Set(HeaderRequestID, requestID)
+
+		next.ServeHTTP(w, r.WithContext(WithRequestID(r.Context(), requestID)))
+	})
+}
+
+func NewRequestID() string {
+	value := make([]byte, 16)
+
+	_, _ = rand.Read(value)
+
+	return hex.EncodeToString(value)
+}
+
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, RequestIDKey, requestID)
+}
+
+func GetRequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(RequestIDKey).(string)
+
+	return requestID
+}
+
+// TimeoutMiddleware limits the time a handler may spend on a request. Routes named with StreamRoutePrefix are
+// long-lived by design and pass through untouched.
+func TimeoutMiddleware(timeout time.Duration) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		limited := http.TimeoutHandler(next, timeout, "")
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route := mux.CurrentRoute(r)
+			if route != nil && strings.HasPrefix(route.GetName(), StreamRoutePrefix) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			limited.ServeHTTP(w, r)
+		})
+	}
+}
+
+// AdminMiddleware lets through requests carrying the token set in the ADMIN_TOKEN environment variable. Without
+// the variable admin routes are closed to everybody.
+func AdminMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := os.Getenv(EnvAdminToken)
+
+		given := r.Header.Get(HeaderAdminToken)
+
+		if token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(given)) != 1 {
+			DefaultHandlerHTTPError(r.Context(), w, ErrAdminRequired)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,48 @@
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"project/internal/pkg"
+)
+
+// Flood keeps consecutive posts of one author in one thread apart by an interval. A nil Flood or a zero interval
+// lets everything through.
+type Flood struct {
+	limiter  Limiter
+	interval time.Duration
+}
+
+func NewFlood(limiter Limiter, interval time.Duration) *Flood {
+	return &Flood{
+		limiter:  limiter,
+		interval: interval,
+	}
+}
+
+// Check takes the turn of the author to post into the thread, returning a *pkg.RetryError when it is too early.
+func (f *Flood) Check(ctx context.Context, thread int64, author string) error {
+	if f == nil || f.interval <= 0 {
+		return nil
+	}
+
+	key := "flood:" + strconv.FormatInt(thread, 10) + ":" + strings.ToLower(author)
+
+	wait, err := f.limiter.Take(ctx, key, Rule{Rate: 1 / f.interval.Seconds(), Burst: 1})
+	if err != nil {
+		logrus.Error(errors.Wrap(err, "flood check"))
+		return nil
+	}
+
+	if wait > 0 {
+		return pkg.NewRetryError(wait)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// Memory keeps the buckets in the process, so each replica of the server limits on its own.
+type Memory struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+func NewMemory() *Memory {
+	return &Memory{buckets: make(map[string]*bucket)}
+}
+
+func (m *Memory) Take(ctx context.Context, key string, rule Rule) (time.Duration, error) {
+	now := time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	value, ok := m.buckets[key]
+	if !ok {
+		value = &bucket{tokens: rule.Burst, updated: now}
+		m.buckets[key] = value
+	}
+
+	value.tokens = math.Min(rule.Burst, value.tokens+now.Sub(value.updated).Seconds()*rule.Rate)
+	value.updated = now
+
+	if value.tokens < 1 {
+		return rule.wait(value.tokens), nil
+	}
+
+	value.tokens--
+
+	return 0, nil
+}
+
+func (m *Memory) Refund(ctx context.Context, key string, rule Rule) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	value, ok := m.buckets[key]
+	if ok {
+		value.tokens = math.Min(rule.Burst, value.tokens+1)
+	}
+
+	return nil
+}
+
+// Run forgets the buckets idle for IdleTimeout until ctx is done.
+func (m *Memory) Run(ctx context.Context) error {
+	ticker := time.NewTicker(IdleTimeout)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			m.forgetIdle(time.Now().Add(-IdleTimeout))
+		}
+	}
+}
+
+func (m *Memory) forgetIdle(before time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, value := range m.buckets {
+		if value.updated.Before(before) {
+			delete(m.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package ratelimit
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"project/internal/pkg"
+)
+
+// peekLimit bounds how much of a body is read to find who the request acts as. Batches of posts longer than this
+// are limited by the authors of the posts which fit.
+const peekLimit = 64 << 10
+
+// Middleware refuses requests over the rules with 429 and Retry-After. Users are those the body acts as, the
+// authors of posts and threads or the voter, so that leaving out or changing the identity header does not get
+// around the limits. Requests without any fall back to the nickname of IdentityMiddleware.
+func Middleware(limiter Limiter, rules Rules) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		if !rules.Enabled() {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			template := ""
+
+			var nicknames []string
+
+			route := mux.CurrentRoute(r)
+			if route != nil {
+				template, _ = route.GetPathTemplate()
+
+				// Streamed bodies, such as imports, are not requests of one user
+				if !strings.HasPrefix(route.GetName(), pkg.StreamRoutePrefix) {
+					nicknames = bodyActors(r)
+				}
+			}
+
+			if len(nicknames) == 0 {
+				nicknames = []string{pkg.GetNickname(r.Context())}
+			}
+
+			err := rules.Check(r.Context(), limiter, RouteKey(r.Method, template), clientIP(r), nicknames)
+			if err != nil {
+				pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// actor holds the fields naming who a request body acts as.
+type actor struct {
+	Author   string `json:"author"`
+	Nickname string `json:"nickname"`
+}
+
+func (a actor) name() string {
+	if a.Author != "" {
+		return a.Author
+	}
+
+	return a.Nickname
+}
+
+// bodyActors reads the authors or voters of a JSON body, an object or an array of them, and puts back what it read
+// so the handler gets the body whole.
+func bodyActors(r *http.Request) []string {
+	if r.Body == nil || r.Method == http.MethodGet || r.Method == http.MethodHead ||
+		strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/") {
+		return nil
+	}
+
+	head, err := io.ReadAll(io.LimitReader(r.Body, peekLimit))
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(head), r.Body), r.Body}
+
+	if err != nil {
+		return nil
+	}
+
+	return parseActors(head)
+}
+
+// parseActors takes as many actors as it can from data, which may be cut short.
+func parseActors(data []byte) []string {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	token, err := decoder.Token()
+	if err != nil {
+		return nil
+	}
+
+	res := make([]string, 0)
+
+	switch token {
+	case json.Delim('{'):
+		name := objectActor(decoder)
+		if name != "" {
+			res = append(res, name)
+		}
+	case json.Delim('['):
+		for decoder.More() {
+			value := actor{}
+
+			err = decoder.Decode(&value)
+			if err != nil {
+				break
+			}
+
+			if value.name() != "" {
+				res = append(res, value.name())
+			}
+		}
+	}
+
+	return res
+}
+
+// objectActor reads the fields of an object whose opening brace is read already, up to where data ends.
+func objectActor(decoder *json.Decoder) string {
+	value := actor{}
+
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			break
+		}
+
+		var field json.RawMessage
+
+		err = decoder.Decode(&field)
+		if err != nil {
+			break
+		}
+
+		switch key {
+		case "author":
+			_ = json.Unmarshal(field, &value.Author)
+		case "nickname":
+			_ = json.Unmarshal(field, &value.Nickname)
+		}
+	}
+
+	return value.name()
+}
+
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
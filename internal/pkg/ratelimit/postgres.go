@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"project/internal/pkg/sqltools"
+)
+
+// Postgres keeps the buckets in the rate_limits table, shared by all replicas of the server. Buckets are changed on
+// the primary without marking the read session: they are not data the client reads back.
+type Postgres struct {
+	conn *sqltools.Cluster
+}
+
+func NewPostgres(conn *sqltools.Cluster) *Postgres {
+	return &Postgres{conn: conn}
+}
+
+func (p *Postgres) Take(ctx context.Context, key string, rule Rule) (time.Duration, error) {
+	var wait float64
+
+	row := p.conn.Primary(ctx).QueryRowContext(ctx, `SELECT function_take_token($1, $2, $3);`, key, rule.Rate, rule.Burst)
+	if row.Err() != nil {
+		return 0, row.Err()
+	}
+
+	err := row.Scan(&wait)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(wait * float64(time.Second)), nil
+}
+
+func (p *Postgres) Refund(ctx context.Context, key string, rule Rule) error {
+	_, err := p.conn.Primary(ctx).ExecContext(ctx, `UPDATE rate_limits SET tokens = LEAST($2, tokens + 1) WHERE key = $1;`,
+		key, rule.Burst)
+
+	return err
+}
+
+// Run deletes the buckets idle for IdleTimeout until ctx is done. Every replica does it, which is harmless.
+func (p *Postgres) Run(ctx context.Context) error {
+	ticker := time.NewTicker(IdleTimeout)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			_, err := p.conn.Primary(ctx).ExecContext(ctx, `DELETE FROM rate_limits
+				WHERE updated < clock_timestamp() - make_interval(secs => $1);`, IdleTimeout.Seconds())
+			if err != nil {
+				logrus.Error(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"project/internal/pkg"
+)
+
+// IdleTimeout is how long a bucket is kept without being used. Buckets refilling slower than their burst in this
+// time are forgotten a bit early, which only errs on the side of letting requests through.
+const IdleTimeout = time.Duration(10) * time.Minute
+
+var ErrBadRule = errors.New("rate limit rule must be rate:burst")
+
+// Rule is a token bucket: Burst requests may come at once, then Rate requests per second.
+type Rule struct {
+	Rate  float64
+	Burst float64
+}
+
+// ParseRule reads a rule written as rate:burst, such as 0.5:10. An empty string is a disabled rule.
+func ParseRule(value string) (Rule, error) {
+	if value == "" {
+		return Rule{}, nil
+	}
+
+	rate, burst, found := strings.Cut(value, ":")
+	if !found {
+		return Rule{}, ErrBadRule
+	}
+
+	res := Rule{}
+
+	var err error
+
+	res.Rate, err = strconv.ParseFloat(strings.TrimSpace(rate), 64)
+	if err != nil {
+		return Rule{}, ErrBadRule
+	}
+
+	res.Burst, err = strconv.ParseFloat(strings.TrimSpace(burst), 64)
+	if err != nil {
+		return Rule{}, ErrBadRule
+	}
+
+	return res, nil
+}
+
+func (r Rule) Enabled() bool {
+	return r.Rate > 0 && r.Burst >= 1
+}
+
+// wait is how long it takes to refill the bucket up to a whole token.
+func (r Rule) wait(tokens float64) time.Duration {
+	return time.Duration(math.Ceil((1 - tokens) / r.Rate * float64(time.Second)))
+}
+
+// Limiter keeps the buckets. Take takes a token from the bucket of the key, returning how long to wait for one when
+// the bucket is empty. Refund puts back a token taken for a request which another bucket then refused.
+type Limiter interface {
+	Take(ctx context.Context, key string, rule Rule) (time.Duration, error)
+	Refund(ctx context.Context, key string, rule Rule) error
+	Run(ctx context.Context) error
+}
+
+// Rules limit every client by IP and by the users it acts as, and on some routes by both again. Routes are keyed by RouteKey.
+type Rules struct {
+	IP     Rule
+	User   Rule
+	Routes map[string]Rule
+}
+
+// ParseRoutes reads rules of routes written as route=rate:burst, separated by RateLimitRulesDelim.
+func ParseRoutes(value string) (map[string]Rule, error) {
+	res := make(map[string]Rule)
+
+	for _, item := range strings.Split(value, pkg.RateLimitRulesDelim) {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		route, ruleValue, found := strings.Cut(item, "=")
+		if !found {
+			return nil, ErrBadRule
+		}
+
+		rule, err := ParseRule(ruleValue)
+		if err != nil {
+			return nil, err
+		}
+
+		res[strings.TrimSpace(route)] = rule
+	}
+
+	return res, nil
+}
+
+func (r Rules) Enabled() bool {
+	return r.IP.Enabled() || r.User.Enabled() || len(r.Routes) != 0
+}
+
+// RouteKey names a route the way rules of routes refer to it, as in "POST /api/thread/{slug_or_id}/create".
+func RouteKey(method string, template string) string {
+	return method + " " + template
+}
+
+type check struct {
+	key  string
+	rule Rule
+}
+
+// Check takes a token from each bucket the request falls into: that of the IP, and those of the users the request
+// acts as, both overall and on the route. A request acting as nobody is limited on the route by IP. The error is
+// a *pkg.RetryError when one of the buckets is empty, and the tokens already taken are refunded, so that refused
+// requests do not drain the other limits of the client. A bucket the limiter fails on is skipped and the others are
+// still checked: limits protect the service and must not take it down.
+func (r Rules) Check(ctx context.Context, limiter Limiter, route string, ip string, nicknames []string) error {
+	checks := []check{
+		{key: "ip:" + ip, rule: r.IP},
+	}
+
+	seen := make(map[string]bool, len(nicknames))
+
+	for _, nickname := range nicknames {
+		client := "user:" + strings.ToLower(nickname)
+		if nickname == "" || seen[client] {
+			continue
+		}
+
+		seen[client] = true
+
+		checks = append(checks,
+			check{key: "route:" + route + ":" + client, rule: r.Routes[route]},
+			check{key: client, rule: r.User})
+	}
+
+	if len(seen) == 0 {
+		checks = append(checks, check{key: "route:" + route + ":ip:" + ip, rule: r.Routes[route]})
+	}
+
+	taken := make([]check, 0, len(checks))
+
+	for _, value := range checks {
+		if !value.rule.Enabled() {
+			continue
+		}
+
+		wait, err := limiter.Take(ctx, value.key, value.rule)
+		if err != nil {
+			logrus.Error(errors.Wrap(err, "rate limit"))
+			continue
+		}
+
+		if wait > 0 {
+			refund(ctx, limiter, taken)
+			return pkg.NewRetryError(wait)
+		}
+
+		taken = append(taken, value)
+	}
+
+	return nil
+}
+
+func refund(ctx context.Context, limiter Limiter, taken []check) {
+	for _, value := range taken {
+		err := limiter.Refund(ctx, value.key, value.rule)
+		if err != nil {
+			logrus.Error(errors.Wrap(err, "rate limit refund"))
+		}
+	}
+}
@@ -0,0 +1,346 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"project/internal/pkg"
+)
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Rule
+		wantErr bool
+	}{
+		{value: "", want: Rule{}},
+		{value: "0.5:10", want: Rule{Rate: 0.5, Burst: 10}},
+		{value: " 2 : 5 ", want: Rule{Rate: 2, Burst: 5}},
+		{value: "2", wantErr: true},
+		{value: "a:5", wantErr: true},
+		{value: "2:b", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseRule(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRule(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseRule(%q) = %+v, want %+v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRoutes(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    map[string]Rule
+		wantErr bool
+	}{
+		{name: "empty", value: "", want: map[string]Rule{}},
+		{
+			name:  "two routes",
+			value: "POST /api/forum/create=1:5" + pkg.RateLimitRulesDelim + " POST /api/thread/{slug_or_id}/create = 2:10",
+			want: map[string]Rule{
+				"POST /api/forum/create":               {Rate: 1, Burst: 5},
+				"POST /api/thread/{slug_or_id}/create": {Rate: 2, Burst: 10},
+			},
+		},
+		{name: "no rule", value: "POST /api/forum/create", wantErr: true},
+		{name: "bad rule", value: "POST /api/forum/create=1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRoutes(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRoutes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRoutes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryTake(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    Rule
+		takes   int
+		waiting int
+	}{
+		{name: "within burst", rule: Rule{Rate: 1, Burst: 3}, takes: 3, waiting: 0},
+		{name: "over burst", rule: Rule{Rate: 1, Burst: 3}, takes: 5, waiting: 2},
+		{name: "burst of one", rule: Rule{Rate: 0.1, Burst: 1}, takes: 2, waiting: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := NewMemory()
+
+			waiting := 0
+
+			for i := 0; i < tt.takes; i++ {
+				wait, err := limiter.Take(context.Background(), "key", tt.rule)
+				if err != nil {
+					t.Fatalf("Take() error = %v", err)
+				}
+
+				if wait > 0 {
+					waiting++
+
+					if wait > time.Duration(float64(time.Second)/tt.rule.Rate) {
+						t.Errorf("Take() wait = %v, longer than one token takes", wait)
+					}
+				}
+			}
+
+			if waiting != tt.waiting {
+				t.Errorf("Take() refused %d times, want %d", waiting, tt.waiting)
+			}
+		})
+	}
+}
+
+func TestMemoryBucketsAreSeparate(t *testing.T) {
+	limiter := NewMemory()
+	rule := Rule{Rate: 0.1, Burst: 1}
+
+	for _, key := range []string{"user:alice", "user:bob", "ip:10.0.0.1"} {
+		wait, err := limiter.Take(context.Background(), key, rule)
+		if err != nil || wait != 0 {
+			t.Errorf("Take(%s) = %v, %v, want a token", key, wait, err)
+		}
+	}
+}
+
+// TestCheckRefunds has the bucket of a user refuse requests which the bucket of the IP lets through, the IP keeping
+// its tokens for the requests of other users.
+func TestCheckRefunds(t *testing.T) {
+	limiter := NewMemory()
+	rules := Rules{IP: Rule{Rate: 0.001, Burst: 3}, User: Rule{Rate: 0.001, Burst: 1}}
+	ctx := context.Background()
+
+	for i, want := range []bool{false, true, true, true} {
+		err := rules.Check(ctx, limiter, "route", "10.0.0.1", []string{"alice"})
+
+		var retry *pkg.RetryError
+		if errors.As(err, &retry) != want {
+			t.Fatalf("request %d of alice: error = %v, want refused %v", i+1, err, want)
+		}
+	}
+
+	for _, nickname := range []string{"bob", "carol"} {
+		err := rules.Check(ctx, limiter, "route", "10.0.0.1", []string{nickname})
+		if err != nil {
+			t.Errorf("request of %s: error = %v, want a token", nickname, err)
+		}
+	}
+}
+
+func TestMemoryForgetIdle(t *testing.T) {
+	limiter := NewMemory()
+
+	_, _ = limiter.Take(context.Background(), "key", Rule{Rate: 1, Burst: 1})
+	limiter.forgetIdle(time.Now().Add(time.Second))
+
+	if len(limiter.buckets) != 0 {
+		t.Errorf("forgetIdle() kept %d buckets, want 0", len(limiter.buckets))
+	}
+}
+
+// recorder is a Limiter remembering the keys taken from and refunded, with every bucket empty past the keys listed
+// in full, and failing on the keys listed in failing.
+type recorder struct {
+	keys     []string
+	refunded []string
+	full     map[string]bool
+	failing  map[string]bool
+}
+
+func (r *recorder) Take(ctx context.Context, key string, rule Rule) (time.Duration, error) {
+	r.keys = append(r.keys, key)
+
+	if r.failing[key] {
+		return 0, errors.New("down")
+	}
+
+	if r.full != nil && !r.full[key] {
+		return time.Second, nil
+	}
+
+	return 0, nil
+}
+
+func (r *recorder) Refund(ctx context.Context, key string, rule Rule) error {
+	r.refunded = append(r.refunded, key)
+
+	return nil
+}
+
+func (r *recorder) Run(ctx context.Context) error {
+	return nil
+}
+
+func TestRulesCheck(t *testing.T) {
+	enabled := Rule{Rate: 1, Burst: 1}
+	route := RouteKey("POST", "/api/thread/{slug_or_id}/create")
+
+	tests := []struct {
+		name         string
+		rules        Rules
+		nicknames    []string
+		full         map[string]bool
+		failing      map[string]bool
+		wantKeys     []string
+		wantRefunded []string
+		wantRetry    bool
+	}{
+		{
+			name:     "anonymous is limited on the route by ip",
+			rules:    Rules{IP: enabled, User: enabled, Routes: map[string]Rule{route: enabled}},
+			wantKeys: []string{"ip:10.0.0.1", "route:" + route + ":ip:10.0.0.1"},
+		},
+		{
+			name:      "users are keyed in lower case, once",
+			rules:     Rules{IP: enabled, User: enabled, Routes: map[string]Rule{route: enabled}},
+			nicknames: []string{"Alice", "alice", ""},
+			wantKeys:  []string{"ip:10.0.0.1", "route:" + route + ":user:alice", "user:alice"},
+		},
+		{
+			name:      "every author of a batch",
+			rules:     Rules{User: enabled},
+			nicknames: []string{"alice", "bob"},
+			wantKeys:  []string{"user:alice", "user:bob"},
+		},
+		{
+			name:         "empty bucket",
+			rules:        Rules{IP: enabled, User: enabled},
+			nicknames:    []string{"alice", "bob"},
+			full:         map[string]bool{"ip:10.0.0.1": true, "user:alice": true},
+			wantKeys:     []string{"ip:10.0.0.1", "user:alice", "user:bob"},
+			wantRefunded: []string{"ip:10.0.0.1", "user:alice"},
+			wantRetry:    true,
+		},
+		{
+			name:      "failing limiter lets through",
+			rules:     Rules{IP: enabled, User: enabled},
+			nicknames: []string{"alice"},
+			failing:   map[string]bool{"ip:10.0.0.1": true, "user:alice": true},
+			wantKeys:  []string{"ip:10.0.0.1", "user:alice"},
+		},
+		{
+			name:      "buckets past a failing one are still checked",
+			rules:     Rules{IP: enabled, User: enabled},
+			nicknames: []string{"alice", "bob"},
+			full:      map[string]bool{"ip:10.0.0.1": true, "user:bob": true},
+			failing:   map[string]bool{"ip:10.0.0.1": true},
+			wantKeys:  []string{"ip:10.0.0.1", "user:alice"},
+			wantRetry: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := &recorder{full: tt.full, failing: tt.failing}
+
+			err := tt.rules.Check(context.Background(), limiter, route, "10.0.0.1", tt.nicknames)
+
+			var retry *pkg.RetryError
+			if errors.As(err, &retry) != tt.wantRetry {
+				t.Errorf("Check() error = %v, wantRetry %v", err, tt.wantRetry)
+			}
+
+			if !reflect.DeepEqual(limiter.keys, tt.wantKeys) {
+				t.Errorf("Check() took from %v, want %v", limiter.keys, tt.wantKeys)
+			}
+
+			if !reflect.DeepEqual(limiter.refunded, tt.wantRefunded) {
+				t.Errorf("Check() refunded %v, want %v", limiter.refunded, tt.wantRefunded)
+			}
+		})
+	}
+}
+
+func TestParseActors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{name: "thread", data: `{"title":"t","author":"alice","message":"m"}`, want: []string{"alice"}},
+		{name: "vote", data: `{"nickname":"bob","voice":1}`, want: []string{"bob"}},
+		{name: "posts", data: `[{"author":"alice","message":"a"},{"author":"bob","message":"b"}]`, want: []string{"alice", "bob"}},
+		{name: "cut posts", data: `[{"author":"alice","message":"a"},{"author":"bob","mess`, want: []string{"alice"}},
+		{name: "cut object", data: `{"author":"alice","message":"a very long mess`, want: []string{"alice"}},
+		{name: "nobody", data: `{"title":"t"}`, want: []string{}},
+		{name: "not json", data: `title=t`, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseActors([]byte(tt.data)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseActors() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBodyActorsKeepsBody(t *testing.T) {
+	body := `[{"author":"alice","message":"` + strings.Repeat("a", peekLimit) + `"}]`
+
+	r := httptest.NewRequest("POST", "/api/thread/1/create", strings.NewReader(body))
+
+	bodyActors(r)
+
+	got, err := io.ReadAll(r.Body)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+
+	if string(got) != body {
+		t.Errorf("body after bodyActors() has %d bytes, want %d", len(got), len(body))
+	}
+}
+
+func TestFloodCheck(t *testing.T) {
+	tests := []struct {
+		name      string
+		flood     *Flood
+		posts     int
+		wantRetry bool
+	}{
+		{name: "disabled", flood: nil, posts: 3},
+		{name: "zero interval", flood: NewFlood(NewMemory(), 0), posts: 3},
+		{name: "first post", flood: NewFlood(NewMemory(), time.Minute), posts: 1},
+		{name: "second post too early", flood: NewFlood(NewMemory(), time.Minute), posts: 2, wantRetry: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+
+			for i := 0; i < tt.posts; i++ {
+				err = tt.flood.Check(context.Background(), 1, "Alice")
+			}
+
+			var retry *pkg.RetryError
+			if errors.As(err, &retry) != tt.wantRetry {
+				t.Errorf("Check() error = %v, wantRetry %v", err, tt.wantRetry)
+			}
+		})
+	}
+}
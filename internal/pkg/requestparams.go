@@ -1,9 +1,15 @@
 package pkg
 
+import "time"
+
+// GetThreadsParams lists threads, counting the replies unread by Nickname when it is given.
 type GetThreadsParams struct {
-	Limit int64
-	Since string
-	Desc  bool
+	Limit    int64
+	Since    string
+	Desc     bool
+	Sort     string
+	Window   string
+	Nickname string
 }
 
 type GetUsersParams struct {
@@ -27,3 +33,27 @@ type VoteParams struct {
 type PostDetailsParams struct {
 	Related []string
 }
+
+type GetDeliveriesParams struct {
+	Limit  int64
+	Since  int64
+	Status string
+}
+
+type GetAuditParams struct {
+	Entity   string
+	EntityID string
+	Actor    string
+	From     time.Time
+	To       time.Time
+	Limit    int64
+	Since    int64
+}
+
+// GetForumStatsParams bounds the activity by days, From included and To excluded, zero for no bound.
+type GetForumStatsParams struct {
+	Period string
+	From   time.Time
+	To     time.Time
+	Limit  int64
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+const RequestTimeout = time.Duration(10) * time.Second
+
 type Server struct {
 	logger *logrus.Logger
 }
@@ -17,12 +19,15 @@ func NewServerHTTP(logger *logrus.Logger) *Server {
 	}
 }
 
+// Launch serves the router. Event streams keep their connections open for as long as the client listens, so
+// the server has neither a write timeout nor a whole-request read timeout, which would cancel the stream context
+// once expired. Only reading headers is limited here, regular requests are bounded by TimeoutMiddleware.
 func (s *Server) Launch(router http.Handler) error {
 	server := http.Server{
-		Addr:         ":5000",
-		Handler:      router,
-		ReadTimeout:  time.Duration(10) * time.Second,
-		WriteTimeout: time.Duration(10) * time.Second,
+		Addr:              ":5000",
+		Handler:           router,
+		ReadHeaderTimeout: RequestTimeout,
+		IdleTimeout:       time.Duration(60) * time.Second,
 	}
 
 	err := server.ListenAndServe()
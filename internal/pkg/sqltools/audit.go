@@ -0,0 +1,90 @@
+package sqltools
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"sort"
+
+	"github.com/lib/pq"
+
+	"project/internal/pkg"
+)
+
+// Snapshot selects the states of entities for Audit. The query returns the ID of each entity as text and its
+// state as JSON, as in SELECT nickname::text, to_jsonb(u) FROM users u WHERE ...
+func Snapshot(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) (map[string][]byte, error) {
+	res := make(map[string][]byte)
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, state string
+
+		err = rows.Scan(&id, &state)
+		if err != nil {
+			return nil, err
+		}
+
+		res[id] = []byte(state)
+	}
+
+	return res, rows.Err()
+}
+
+// Audit appends to audit_log an entry for each entity of the snapshots taken before and after the change, in tx,
+// the transaction of the change, so that both are committed or neither. An entity missing from a snapshot did not
+// exist then; entities left as they were get no entry. The actor and the request ID are taken from ctx; without
+// an authenticated nickname in ctx, as for anonymous requests and the admin commands, the actor is left NULL
+// rather than guessed from the entity, whose author need not be who changed it.
+func Audit(ctx context.Context, tx *sql.Tx, entity string, action string, before map[string][]byte, after map[string][]byte) error {
+	ids := make([]string, 0, len(after))
+
+	for id := range after {
+		ids = append(ids, id)
+	}
+
+	for id := range before {
+		if _, ok := after[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Strings(ids)
+
+	changed := make([]string, 0, len(ids))
+	beforeStates := make([]sql.NullString, 0, len(ids))
+	afterStates := make([]sql.NullString, 0, len(ids))
+
+	for _, id := range ids {
+		if bytes.Equal(before[id], after[id]) {
+			continue
+		}
+
+		changed = append(changed, id)
+		beforeStates = append(beforeStates, nullState(before, id))
+		afterStates = append(afterStates, nullState(after, id))
+	}
+
+	if len(changed) == 0 {
+		return nil
+	}
+
+	_, err := tx.ExecContext(ctx, `INSERT INTO audit_log(actor, request_id, entity, entity_id, action, before, after)
+		SELECT NULLIF($1::text, ''), NULLIF($2::text, ''), $3, entry.entity_id, $4, entry.before::jsonb, entry.after::jsonb
+		FROM unnest($5::text[], $6::text[], $7::text[]) AS entry(entity_id, before, after);`,
+		pkg.GetNickname(ctx), pkg.GetRequestID(ctx), entity, action,
+		pq.Array(changed), pq.Array(beforeStates), pq.Array(afterStates))
+
+	return err
+}
+
+func nullState(states map[string][]byte, id string) sql.NullString {
+	state, ok := states[id]
+
+	return sql.NullString{String: string(state), Valid: ok}
+}
@@ -0,0 +1,188 @@
+package sqltools
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"project/internal/pkg"
+)
+
+const (
+	DefaultStickyWindow = time.Duration(5) * time.Second
+
+	healthCheckInterval = time.Duration(1) * time.Second
+	healthCheckTimeout  = time.Duration(500) * time.Millisecond
+)
+
+type replica struct {
+	db      *sql.DB
+	healthy int32
+}
+
+// Cluster is a primary with zero or more replicas. Writes and the reads they depend on use the primary, other
+// reads are spread over the healthy replicas in turn. Without replicas everything goes to the primary.
+//
+// A client who has just written reads from the primary for the sticky window, so that they see their posts even
+// when the replicas lag behind. Clients are told apart by nickname, else by the read session cookie.
+type Cluster struct {
+	primary  *sql.DB
+	replicas []*replica
+	next     uint32
+
+	sticky time.Duration
+	mu     sync.Mutex
+	writes map[string]time.Time
+}
+
+func NewCluster(primary *sql.DB, replicas ...*sql.DB) *Cluster {
+	res := &Cluster{
+		primary: primary,
+		sticky:  DefaultStickyWindow,
+		writes:  make(map[string]time.Time),
+	}
+
+	for _, db := range replicas {
+		res.replicas = append(res.replicas, &replica{db: db})
+	}
+
+	return res
+}
+
+func (c *Cluster) SetStickyWindow(window time.Duration) {
+	c.sticky = window
+}
+
+// Primary is for reads which must not lag behind, such as the checks made before a write.
+func (c *Cluster) Primary(ctx context.Context) *sql.DB {
+	return c.primary
+}
+
+// Write is Primary for queries changing data: it starts the sticky window of the user and marks the read session.
+func (c *Cluster) Write(ctx context.Context) *sql.DB {
+	session := pkg.GetReadSession(ctx)
+	if session != nil {
+		session.MarkWrite()
+	}
+
+	writer := writerKey(ctx)
+	if writer != "" && len(c.replicas) != 0 {
+		c.mu.Lock()
+		c.writes[writer] = time.Now()
+		c.mu.Unlock()
+	}
+
+	return c.primary
+}
+
+// writerKey names the client of ctx for the sticky window: by nickname, else by read session cookie.
+func writerKey(ctx context.Context) string {
+	nickname := pkg.GetNickname(ctx)
+	if nickname != "" {
+		return "user:" + strings.ToLower(nickname)
+	}
+
+	session := pkg.GetReadSession(ctx)
+	if session != nil && session.Client() != "" {
+		return "client:" + session.Client()
+	}
+
+	return ""
+}
+
+// Replica picks the next healthy replica, falling back to the primary when there is none or when the caller has
+// to read their own writes.
+func (c *Cluster) Replica(ctx context.Context) *sql.DB {
+	if len(c.replicas) == 0 || c.needsPrimary(ctx) {
+		return c.primary
+	}
+
+	start := atomic.AddUint32(&c.next, 1)
+
+	for idx := 0; idx < len(c.replicas); idx++ {
+		replica := c.replicas[(int(start)+idx)%len(c.replicas)]
+
+		if atomic.LoadInt32(&replica.healthy) == 1 {
+			return replica.db
+		}
+	}
+
+	return c.primary
+}
+
+func (c *Cluster) needsPrimary(ctx context.Context) bool {
+	if pkg.ReadsPrimary(ctx) {
+		return true
+	}
+
+	writer := writerKey(ctx)
+	if writer == "" {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	wrote, ok := c.writes[writer]
+
+	return ok && time.Since(wrote) < c.sticky
+}
+
+// Run checks the replicas until ctx is done. Replicas count as unhealthy until their first successful check.
+func (c *Cluster) Run(ctx context.Context) error {
+	if len(c.replicas) == 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		c.checkReplicas(ctx)
+		c.forgetWrites()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Cluster) checkReplicas(ctx context.Context) {
+	for idx, replica := range c.replicas {
+		pingCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+		err := replica.db.PingContext(pingCtx)
+		cancel()
+
+		healthy := int32(0)
+		if err == nil {
+			healthy = 1
+		}
+
+		if atomic.SwapInt32(&replica.healthy, healthy) != healthy {
+			if err != nil {
+				logrus.Warnf("replica %d is down: %s", idx, err)
+			} else {
+				logrus.Infof("replica %d is up", idx)
+			}
+		}
+	}
+}
+
+// forgetWrites drops the clients whose sticky window is over.
+func (c *Cluster) forgetWrites() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for writer, wrote := range c.writes {
+		if time.Since(wrote) >= c.sticky {
+			delete(c.writes, writer)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package sqltools
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"project/internal/pkg"
+)
+
+// noConnector makes a *sql.DB for telling pools apart, it never connects.
+type noConnector struct{}
+
+func (noConnector) Connect(context.Context) (driver.Conn, error) {
+	return nil, errors.New("no connections in tests")
+}
+
+func (noConnector) Driver() driver.Driver {
+	return nil
+}
+
+// withClient returns the context ConsistencyMiddleware makes for a request with the read session cookie.
+func withClient(client string) context.Context {
+	r := httptest.NewRequest(http.MethodGet, "/api/service/status", nil)
+	r.AddCookie(&http.Cookie{Name: pkg.CookieReadSession, Value: client})
+
+	var res context.Context
+
+	pkg.ConsistencyMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res = r.Context()
+	})).ServeHTTP(httptest.NewRecorder(), r)
+
+	return res
+}
+
+func TestClusterStickiness(t *testing.T) {
+	tests := []struct {
+		name        string
+		write       context.Context
+		read        context.Context
+		wantPrimary bool
+	}{
+		{
+			name:        "nothing written",
+			read:        context.WithValue(context.Background(), pkg.NicknameKey, "alice"),
+			wantPrimary: false,
+		},
+		{
+			name:        "same user",
+			write:       context.WithValue(context.Background(), pkg.NicknameKey, "Alice"),
+			read:        context.WithValue(context.Background(), pkg.NicknameKey, "alice"),
+			wantPrimary: true,
+		},
+		{
+			name:        "other user",
+			write:       context.WithValue(context.Background(), pkg.NicknameKey, "alice"),
+			read:        context.WithValue(context.Background(), pkg.NicknameKey, "bob"),
+			wantPrimary: false,
+		},
+		{
+			name:        "same client without nickname",
+			write:       withClient("c1"),
+			read:        withClient("c1"),
+			wantPrimary: true,
+		},
+		{
+			name:        "other client without nickname",
+			write:       withClient("c1"),
+			read:        withClient("c2"),
+			wantPrimary: false,
+		},
+		{
+			name:        "anonymous",
+			write:       context.Background(),
+			read:        context.Background(),
+			wantPrimary: false,
+		},
+		{
+			name:        "strong read",
+			read:        pkg.WithPrimaryReads(context.Background()),
+			wantPrimary: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary := sql.OpenDB(noConnector{})
+			replica := sql.OpenDB(noConnector{})
+
+			cluster := NewCluster(primary, replica)
+			cluster.replicas[0].healthy = 1
+
+			if tt.write != nil {
+				cluster.Write(tt.write)
+			}
+
+			if got := cluster.Replica(tt.read) == primary; got != tt.wantPrimary {
+				t.Errorf("Replica() is the primary: %v, want %v", got, tt.wantPrimary)
+			}
+		})
+	}
+}
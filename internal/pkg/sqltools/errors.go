@@ -0,0 +1,25 @@
+package sqltools
+
+import (
+	"github.com/jackc/pgx"
+	"github.com/pkg/errors"
+)
+
+const (
+	codeUniqueViolation     = "23505"
+	codeForeignKeyViolation = "23503"
+)
+
+func hasCode(err error, code string) bool {
+	var pgErr pgx.PgError
+
+	return errors.As(err, &pgErr) && pgErr.Code == code
+}
+
+func IsUniqueViolation(err error) bool {
+	return hasCode(err, codeUniqueViolation)
+}
+
+func IsForeignKeyViolation(err error) bool {
+	return hasCode(err, codeForeignKeyViolation)
+}
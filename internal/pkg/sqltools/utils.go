@@ -1,8 +1,12 @@
 package sqltools
 
 import (
+	"database/sql/driver"
 	"fmt"
 	"strings"
+	"time"
+
+	"github.com/lib/pq"
 )
 
 func CreatePlaceholders(countAttributes int, countValues int) string {
@@ -28,3 +32,17 @@ func CreateFullQuery(query string, countInserts int, countAttributes int) string
 
 	return insertStatement
 }
+
+// TimeArray passes times as a timestamptz[] parameter, nil giving NULL. The text keeps the microseconds.
+func TimeArray(times []time.Time) driver.Valuer {
+	if times == nil {
+		return pq.StringArray(nil)
+	}
+
+	res := make([]string, len(times))
+	for idx, value := range times {
+		res[idx] = value.UTC().Format("2006-01-02 15:04:05.999999Z07:00")
+	}
+
+	return pq.StringArray(res)
+}
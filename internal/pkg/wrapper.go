@@ -16,6 +16,11 @@ type ErrResponse struct {
 }
 
 func DefaultHandlerHTTPError(ctx context.Context, w http.ResponseWriter, err error) {
+	var retry *RetryError
+	if errors.As(err, &retry) {
+		w.Header().Set("Retry-After", retry.RetryAfter())
+	}
+
 	errCause := errors.Cause(err)
 
 	code, exist := GetErrorCodeHTTP(errCause)
@@ -57,6 +62,11 @@ func Response(ctx context.Context, w http.ResponseWriter, statusCode int, someSt
 
 	w.Header().Set("Content-Type", ContentTypeJSON)
 
+	if statusCode == http.StatusOK && notModified(ctx, w, out) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
 	w.WriteHeader(statusCode)
 
 	_, err = w.Write(out)
@@ -65,3 +75,22 @@ func Response(ctx context.Context, w http.ResponseWriter, statusCode int, someSt
 		return
 	}
 }
+
+// notModified gives successful reads a strong ETag, unless the handler has set a version one, and checks it and
+// Last-Modified against the conditional headers of the request.
+func notModified(ctx context.Context, w http.ResponseWriter, out []byte) bool {
+	params := GetConditionalParams(ctx)
+	if !params.Safe {
+		return false
+	}
+
+	etag := w.Header().Get("ETag")
+	if etag == "" {
+		etag = BodyETag(out)
+		w.Header().Set("ETag", etag)
+	}
+
+	modified, _ := http.ParseTime(w.Header().Get("Last-Modified"))
+
+	return params.NotModified(etag, modified)
+}
@@ -0,0 +1,47 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"project/internal/models"
+	"project/internal/pb"
+	"project/internal/pkg"
+	"project/internal/pkg/grpctools"
+	"project/internal/post/usecase"
+)
+
+type PostServer struct {
+	pb.UnimplementedPostServiceServer
+
+	postUsecase usecase.PostService
+}
+
+func (s *PostServer) GetPost(ctx context.Context, req *pb.GetPostRequest) (*pb.PostDetails, error) {
+	details, err := s.postUsecase.GetDetailsPost(ctx, &models.Post{ID: req.GetId()}, &pkg.PostDetailsParams{
+		Related: req.GetRelated(),
+	})
+	if err != nil {
+		return nil, grpctools.Error(err)
+	}
+
+	return pb.NewPostDetails(details), nil
+}
+
+func (s *PostServer) UpdatePost(ctx context.Context, req *pb.UpdatePostRequest) (*pb.Post, error) {
+	post, err := s.postUsecase.UpdatePost(ctx, &models.Post{ID: req.GetId(), Message: req.GetMessage(), Version: req.GetVersion()})
+	if err != nil {
+		if errors.Is(errors.Cause(err), pkg.ErrVersionConflict) {
+			return nil, grpctools.Error(err, pb.NewPost(post))
+		}
+
+		return nil, grpctools.Error(err)
+	}
+
+	return pb.NewPost(post), nil
+}
+
+func NewPostServer(postUsecase usecase.PostService) *PostServer {
+	return &PostServer{postUsecase: postUsecase}
+}
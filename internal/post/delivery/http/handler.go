@@ -2,6 +2,7 @@ package http
 
 import (
 	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
 	"net/http"
 	"project/internal/pkg"
 	"project/internal/post/delivery/models"
@@ -25,6 +26,11 @@ func (h *PostHandler) GetPostHandler(w http.ResponseWriter, r *http.Request) {
 
 	response := models.NewPostDetailsResponse(postDetails)
 
+	// Related entities change on their own, so only the bare post is versioned, the rest gets the ETag of the body
+	if postDetails.Author.Nickname == "" && postDetails.Thread.ID == 0 && postDetails.Forum.Slug == "" {
+		pkg.SetValidators(w, pkg.VersionETag(postDetails.Post.Modified), postDetails.Post.Modified)
+	}
+
 	pkg.Response(r.Context(), w, http.StatusOK, response)
 }
 
@@ -35,12 +41,22 @@ func (h *PostHandler) UpdatePostHandler(w http.ResponseWriter, r *http.Request)
 
 	post, err := h.postUsecase.UpdatePost(r.Context(), request.GetPost())
 	if err != nil {
+		if errors.Is(errors.Cause(err), pkg.ErrVersionConflict) {
+			response := models.NewPostUpdateResponse(post)
+
+			pkg.Response(r.Context(), w, http.StatusConflict, response)
+
+			return
+		}
+
 		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
 		return
 	}
 
 	response := models.NewPostUpdateResponse(post)
 
+	pkg.SetValidators(w, pkg.VersionETag(post.Modified), post.Modified)
+
 	pkg.Response(r.Context(), w, http.StatusOK, response)
 }
 
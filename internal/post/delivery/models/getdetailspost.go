@@ -7,6 +7,7 @@ import (
 
 	"github.com/gorilla/mux"
 
+	attachmentModels "project/internal/attachment/delivery/models"
 	"project/internal/models"
 	"project/internal/pkg"
 )
@@ -68,18 +69,21 @@ type PostGetDetailsAuthorResponse struct {
 	FullName string `json:"fullname,omitempty"`
 	About    string `json:"about,omitempty"`
 	Email    string `json:"email,omitempty"`
+	Version  int64  `json:"version,omitempty"`
 }
 
 //easyjson:json
 type PostGetDetailsPostResponse struct {
-	ID       int64  `json:"id,omitempty"`
-	Parent   int64  `json:"parent,omitempty"`
-	Author   string `json:"author,omitempty"`
-	Message  string `json:"message,omitempty"`
-	IsEdited bool   `json:"isEdited,omitempty"`
-	Forum    string `json:"forum,omitempty"`
-	Thread   int64  `json:"thread,omitempty"`
-	Created  string `json:"created,omitempty"`
+	ID          int64                            `json:"id,omitempty"`
+	Parent      int64                            `json:"parent,omitempty"`
+	Author      string                           `json:"author,omitempty"`
+	Message     string                           `json:"message,omitempty"`
+	IsEdited    bool                             `json:"isEdited,omitempty"`
+	Forum       string                           `json:"forum,omitempty"`
+	Thread      int64                            `json:"thread,omitempty"`
+	Created     string                           `json:"created,omitempty"`
+	Version     int64                            `json:"version,omitempty"`
+	Attachments attachmentModels.AttachmentsList `json:"attachments,omitempty"`
 }
 
 //easyjson:json
@@ -92,6 +96,7 @@ type PostGetDetailsThreadResponse struct {
 	Message string `json:"message"`
 	Created string `json:"created"`
 	Votes   int64  `json:"votes"`
+	Version int64  `json:"version,omitempty"`
 }
 
 //easyjson:json
@@ -101,6 +106,7 @@ type PostGetDetailsForumResponse struct {
 	Slug    string `json:"slug"`
 	Posts   int64  `json:"posts"`
 	Threads int64  `json:"threads"`
+	Version int64  `json:"version,omitempty"`
 }
 
 //easyjson:json
@@ -124,6 +130,9 @@ func NewPostDetailsResponse(postDetails *models.PostDetails) *PostGetDetailsResp
 			Message:  postDetails.Post.Message,
 			Created:  postDetails.Post.Created,
 			IsEdited: postDetails.Post.IsEdited,
+			Version:  postDetails.Post.Version,
+
+			Attachments: attachmentModels.NewAttachmentsList(postDetails.Post.Attachments),
 		}
 
 		res.Post = &post
@@ -135,6 +144,7 @@ func NewPostDetailsResponse(postDetails *models.PostDetails) *PostGetDetailsResp
 			FullName: postDetails.Author.FullName,
 			About:    postDetails.Author.About,
 			Email:    postDetails.Author.Email,
+			Version:  postDetails.Author.Version,
 		}
 
 		res.Author = &author
@@ -150,6 +160,7 @@ func NewPostDetailsResponse(postDetails *models.PostDetails) *PostGetDetailsResp
 			Message: postDetails.Thread.Message,
 			Created: postDetails.Thread.Created,
 			Votes:   postDetails.Thread.Votes,
+			Version: postDetails.Thread.Version,
 		}
 
 		res.Thread = &thread
@@ -162,6 +173,7 @@ func NewPostDetailsResponse(postDetails *models.PostDetails) *PostGetDetailsResp
 			Slug:    postDetails.Forum.Slug,
 			Posts:   postDetails.Forum.Posts,
 			Threads: postDetails.Forum.Threads,
+			Version: postDetails.Forum.Version,
 		}
 
 		res.Forum = &forum
@@ -17,7 +17,7 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjson5c9b8afcDecodeDbPerformanceProjectInternalPostDeliveryModels(in *jlexer.Lexer, out *PostGetDetailsThreadResponse) {
+func easyjson5c9b8afcDecodeProjectInternalPostDeliveryModels(in *jlexer.Lexer, out *PostGetDetailsThreadResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -52,6 +52,8 @@ func easyjson5c9b8afcDecodeDbPerformanceProjectInternalPostDeliveryModels(in *jl
 			out.Created = string(in.String())
 		case "votes":
 			out.Votes = int64(in.Int64())
+		case "version":
+			out.Version = int64(in.Int64())
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -66,7 +68,7 @@ func easyjson5c9b8afcDecodeDbPerformanceProjectInternalPostDeliveryModels(in *jl
 		in.Consumed()
 	}
 }
-func easyjson5c9b8afcEncodeDbPerformanceProjectInternalPostDeliveryModels(out *jwriter.Writer, in PostGetDetailsThreadResponse) {
+func easyjson5c9b8afcEncodeProjectInternalPostDeliveryModels(out *jwriter.Writer, in PostGetDetailsThreadResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -146,33 +148,43 @@ func easyjson5c9b8afcEncodeDbPerformanceProjectInternalPostDeliveryModels(out *j
 		}
 		out.Int64(int64(in.Votes))
 	}
+	if in.Version != 0 {
+		const prefix string = ",\"version\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Version))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v PostGetDetailsThreadResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson5c9b8afcEncodeDbPerformanceProjectInternalPostDeliveryModels(&w, v)
+	easyjson5c9b8afcEncodeProjectInternalPostDeliveryModels(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v PostGetDetailsThreadResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson5c9b8afcEncodeDbPerformanceProjectInternalPostDeliveryModels(w, v)
+	easyjson5c9b8afcEncodeProjectInternalPostDeliveryModels(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *PostGetDetailsThreadResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson5c9b8afcDecodeDbPerformanceProjectInternalPostDeliveryModels(&r, v)
+	easyjson5c9b8afcDecodeProjectInternalPostDeliveryModels(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *PostGetDetailsThreadResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson5c9b8afcDecodeDbPerformanceProjectInternalPostDeliveryModels(l, v)
+	easyjson5c9b8afcDecodeProjectInternalPostDeliveryModels(l, v)
 }
-func easyjson5c9b8afcDecodeDbPerformanceProjectInternalPostDeliveryModels1(in *jlexer.Lexer, out *PostGetDetailsResponse) {
+func easyjson5c9b8afcDecodeProjectInternalPostDeliveryModels1(in *jlexer.Lexer, out *PostGetDetailsResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -245,7 +257,7 @@ func easyjson5c9b8afcDecodeDbPerformanceProjectInternalPostDeliveryModels1(in *j
 		in.Consumed()
 	}
 }
-func easyjson5c9b8afcEncodeDbPerformanceProjectInternalPostDeliveryModels1(out *jwriter.Writer, in PostGetDetailsResponse) {
+func easyjson5c9b8afcEncodeProjectInternalPostDeliveryModels1(out *jwriter.Writer, in PostGetDetailsResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -291,27 +303,27 @@ func easyjson5c9b8afcEncodeDbPerformanceProjectInternalPostDeliveryModels1(out *
 // MarshalJSON supports json.Marshaler interface
 func (v PostGetDetailsResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson5c9b8afcEncodeDbPerformanceProjectInternalPostDeliveryModels1(&w, v)
+	easyjson5c9b8afcEncodeProjectInternalPostDeliveryModels1(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v PostGetDetailsResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson5c9b8afcEncodeDbPerformanceProjectInternalPostDeliveryModels1(w, v)
+	easyjson5c9b8afcEncodeProjectInternalPostDeliveryModels1(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *PostGetDetailsResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson5c9b8afcDecodeDbPerformanceProjectInternalPostDeliveryModels1(&r, v)
+	easyjson5c9b8afcDecodeProjectInternalPostDeliveryModels1(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *PostGetDetailsResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson5c9b8afcDecodeDbPerformanceProjectInternalPostDeliveryModels1(l, v)
+	easyjson5c9b8afcDecodeProjectInternalPostDeliveryModels1(l, v)
 }
-func easyjson5c9b8afcDecodeDbPerformanceProjectInternalPostDeliveryModels2(in *jlexer.Lexer, out *PostGetDetailsPostResponse) {
+func easyjson5c9b8afcDecodeProjectInternalPostDeliveryModels2(in *jlexer.Lexer, out *PostGetDetailsPostResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -346,6 +358,10 @@ func easyjson5c9b8afcDecodeDbPerformanceProjectInternalPostDeliveryModels2(in *j
 			out.Thread = int64(in.Int64())
 		case "created":
 			out.Created = string(in.String())
+		case "version":
+			out.Version = int64(in.Int64())
+		case "attachments":
+			(out.Attachments).UnmarshalEasyJSON(in)
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -360,7 +376,7 @@ func easyjson5c9b8afcDecodeDbPerformanceProjectInternalPostDeliveryModels2(in *j
 		in.Consumed()
 	}
 }
-func easyjson5c9b8afcEncodeDbPerformanceProjectInternalPostDeliveryModels2(out *jwriter.Writer, in PostGetDetailsPostResponse) {
+func easyjson5c9b8afcEncodeProjectInternalPostDeliveryModels2(out *jwriter.Writer, in PostGetDetailsPostResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -440,33 +456,53 @@ func easyjson5c9b8afcEncodeDbPerformanceProjectInternalPostDeliveryModels2(out *
 		}
 		out.String(string(in.Created))
 	}
+	if in.Version != 0 {
+		const prefix string = ",\"version\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Version))
+	}
+	if len(in.Attachments) != 0 {
+		const prefix string = ",\"attachments\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		(in.Attachments).MarshalEasyJSON(out)
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v PostGetDetailsPostResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson5c9b8afcEncodeDbPerformanceProjectInternalPostDeliveryModels2(&w, v)
+	easyjson5c9b8afcEncodeProjectInternalPostDeliveryModels2(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v PostGetDetailsPostResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson5c9b8afcEncodeDbPerformanceProjectInternalPostDeliveryModels2(w, v)
+	easyjson5c9b8afcEncodeProjectInternalPostDeliveryModels2(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *PostGetDetailsPostResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson5c9b8afcDecodeDbPerformanceProjectInternalPostDeliveryModels2(&r, v)
+	easyjson5c9b8afcDecodeProjectInternalPostDeliveryModels2(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *PostGetDetailsPostResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson5c9b8afcDecodeDbPerformanceProjectInternalPostDeliveryModels2(l, v)
+	easyjson5c9b8afcDecodeProjectInternalPostDeliveryModels2(l, v)
 }
-func easyjson5c9b8afcDecodeDbPerformanceProjectInternalPostDeliveryModels3(in *jlexer.Lexer, out *PostGetDetailsForumResponse) {
+func easyjson5c9b8afcDecodeProjectInternalPostDeliveryModels3(in *jlexer.Lexer, out *PostGetDetailsForumResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -495,6 +531,8 @@ func easyjson5c9b8afcDecodeDbPerformanceProjectInternalPostDeliveryModels3(in *j
 			out.Posts = int64(in.Int64())
 		case "threads":
 			out.Threads = int64(in.Int64())
+		case "version":
+			out.Version = int64(in.Int64())
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -509,7 +547,7 @@ func easyjson5c9b8afcDecodeDbPerformanceProjectInternalPostDeliveryModels3(in *j
 		in.Consumed()
 	}
 }
-func easyjson5c9b8afcEncodeDbPerformanceProjectInternalPostDeliveryModels3(out *jwriter.Writer, in PostGetDetailsForumResponse) {
+func easyjson5c9b8afcEncodeProjectInternalPostDeliveryModels3(out *jwriter.Writer, in PostGetDetailsForumResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -559,33 +597,43 @@ func easyjson5c9b8afcEncodeDbPerformanceProjectInternalPostDeliveryModels3(out *
 		}
 		out.Int64(int64(in.Threads))
 	}
+	if in.Version != 0 {
+		const prefix string = ",\"version\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Version))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v PostGetDetailsForumResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson5c9b8afcEncodeDbPerformanceProjectInternalPostDeliveryModels3(&w, v)
+	easyjson5c9b8afcEncodeProjectInternalPostDeliveryModels3(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v PostGetDetailsForumResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson5c9b8afcEncodeDbPerformanceProjectInternalPostDeliveryModels3(w, v)
+	easyjson5c9b8afcEncodeProjectInternalPostDeliveryModels3(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *PostGetDetailsForumResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson5c9b8afcDecodeDbPerformanceProjectInternalPostDeliveryModels3(&r, v)
+	easyjson5c9b8afcDecodeProjectInternalPostDeliveryModels3(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *PostGetDetailsForumResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson5c9b8afcDecodeDbPerformanceProjectInternalPostDeliveryModels3(l, v)
+	easyjson5c9b8afcDecodeProjectInternalPostDeliveryModels3(l, v)
 }
-func easyjson5c9b8afcDecodeDbPerformanceProjectInternalPostDeliveryModels4(in *jlexer.Lexer, out *PostGetDetailsAuthorResponse) {
+func easyjson5c9b8afcDecodeProjectInternalPostDeliveryModels4(in *jlexer.Lexer, out *PostGetDetailsAuthorResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -612,6 +660,8 @@ func easyjson5c9b8afcDecodeDbPerformanceProjectInternalPostDeliveryModels4(in *j
 			out.About = string(in.String())
 		case "email":
 			out.Email = string(in.String())
+		case "version":
+			out.Version = int64(in.Int64())
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -626,7 +676,7 @@ func easyjson5c9b8afcDecodeDbPerformanceProjectInternalPostDeliveryModels4(in *j
 		in.Consumed()
 	}
 }
-func easyjson5c9b8afcEncodeDbPerformanceProjectInternalPostDeliveryModels4(out *jwriter.Writer, in PostGetDetailsAuthorResponse) {
+func easyjson5c9b8afcEncodeProjectInternalPostDeliveryModels4(out *jwriter.Writer, in PostGetDetailsAuthorResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -666,29 +716,39 @@ func easyjson5c9b8afcEncodeDbPerformanceProjectInternalPostDeliveryModels4(out *
 		}
 		out.String(string(in.Email))
 	}
+	if in.Version != 0 {
+		const prefix string = ",\"version\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Version))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v PostGetDetailsAuthorResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson5c9b8afcEncodeDbPerformanceProjectInternalPostDeliveryModels4(&w, v)
+	easyjson5c9b8afcEncodeProjectInternalPostDeliveryModels4(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v PostGetDetailsAuthorResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson5c9b8afcEncodeDbPerformanceProjectInternalPostDeliveryModels4(w, v)
+	easyjson5c9b8afcEncodeProjectInternalPostDeliveryModels4(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *PostGetDetailsAuthorResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson5c9b8afcDecodeDbPerformanceProjectInternalPostDeliveryModels4(&r, v)
+	easyjson5c9b8afcDecodeProjectInternalPostDeliveryModels4(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *PostGetDetailsAuthorResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson5c9b8afcDecodeDbPerformanceProjectInternalPostDeliveryModels4(l, v)
+	easyjson5c9b8afcDecodeProjectInternalPostDeliveryModels4(l, v)
 }
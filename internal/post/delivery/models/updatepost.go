@@ -8,6 +8,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/mailru/easyjson"
 
+	attachmentModels "project/internal/attachment/delivery/models"
 	"project/internal/models"
 )
 
@@ -16,6 +17,7 @@ import (
 type PostUpdateRequest struct {
 	ID      int64
 	Message string `json:"message"`
+	Version int64  `json:"version"`
 }
 
 func NewPostUpdateRequest() *PostUpdateRequest {
@@ -70,18 +72,21 @@ func (req *PostUpdateRequest) GetPost() *models.Post {
 	return &models.Post{
 		ID:      req.ID,
 		Message: req.Message,
+		Version: req.Version,
 	}
 }
 
 type PostUpdateResponse struct {
-	ID       int64  `json:"id"`
-	Parent   int64  `json:"parent"`
-	Author   string `json:"author"`
-	Message  string `json:"message"`
-	IsEdited bool   `json:"isEdited"`
-	Forum    string `json:"forum"`
-	Thread   int64  `json:"thread"`
-	Created  string `json:"created"`
+	ID          int64                            `json:"id"`
+	Parent      int64                            `json:"parent"`
+	Author      string                           `json:"author"`
+	Message     string                           `json:"message"`
+	IsEdited    bool                             `json:"isEdited"`
+	Forum       string                           `json:"forum"`
+	Thread      int64                            `json:"thread"`
+	Created     string                           `json:"created"`
+	Version     int64                            `json:"version,omitempty"`
+	Attachments attachmentModels.AttachmentsList `json:"attachments,omitempty"`
 }
 
 func NewPostUpdateResponse(post *models.Post) *PostUpdateResponse {
@@ -94,5 +99,8 @@ func NewPostUpdateResponse(post *models.Post) *PostUpdateResponse {
 		Message:  post.Message,
 		Created:  post.Created,
 		IsEdited: post.IsEdited,
+		Version:  post.Version,
+
+		Attachments: attachmentModels.NewAttachmentsList(post.Attachments),
 	}
 }
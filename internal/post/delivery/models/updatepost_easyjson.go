@@ -17,7 +17,7 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjson5cae51c1DecodeDbPerformanceProjectInternalPostDeliveryModels(in *jlexer.Lexer, out *PostUpdateResponse) {
+func easyjson5cae51c1DecodeProjectInternalPostDeliveryModels(in *jlexer.Lexer, out *PostUpdateResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -52,6 +52,10 @@ func easyjson5cae51c1DecodeDbPerformanceProjectInternalPostDeliveryModels(in *jl
 			out.Thread = int64(in.Int64())
 		case "created":
 			out.Created = string(in.String())
+		case "version":
+			out.Version = int64(in.Int64())
+		case "attachments":
+			(out.Attachments).UnmarshalEasyJSON(in)
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -66,7 +70,7 @@ func easyjson5cae51c1DecodeDbPerformanceProjectInternalPostDeliveryModels(in *jl
 		in.Consumed()
 	}
 }
-func easyjson5cae51c1EncodeDbPerformanceProjectInternalPostDeliveryModels(out *jwriter.Writer, in PostUpdateResponse) {
+func easyjson5cae51c1EncodeProjectInternalPostDeliveryModels(out *jwriter.Writer, in PostUpdateResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -146,33 +150,53 @@ func easyjson5cae51c1EncodeDbPerformanceProjectInternalPostDeliveryModels(out *j
 		}
 		out.String(string(in.Created))
 	}
+	if in.Version != 0 {
+		const prefix string = ",\"version\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Version))
+	}
+	if len(in.Attachments) != 0 {
+		const prefix string = ",\"attachments\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		(in.Attachments).MarshalEasyJSON(out)
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v PostUpdateResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson5cae51c1EncodeDbPerformanceProjectInternalPostDeliveryModels(&w, v)
+	easyjson5cae51c1EncodeProjectInternalPostDeliveryModels(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v PostUpdateResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson5cae51c1EncodeDbPerformanceProjectInternalPostDeliveryModels(w, v)
+	easyjson5cae51c1EncodeProjectInternalPostDeliveryModels(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *PostUpdateResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson5cae51c1DecodeDbPerformanceProjectInternalPostDeliveryModels(&r, v)
+	easyjson5cae51c1DecodeProjectInternalPostDeliveryModels(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *PostUpdateResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson5cae51c1DecodeDbPerformanceProjectInternalPostDeliveryModels(l, v)
+	easyjson5cae51c1DecodeProjectInternalPostDeliveryModels(l, v)
 }
-func easyjson5cae51c1DecodeDbPerformanceProjectInternalPostDeliveryModels1(in *jlexer.Lexer, out *PostUpdateRequest) {
+func easyjson5cae51c1DecodeProjectInternalPostDeliveryModels1(in *jlexer.Lexer, out *PostUpdateRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -195,6 +219,8 @@ func easyjson5cae51c1DecodeDbPerformanceProjectInternalPostDeliveryModels1(in *j
 			out.ID = int64(in.Int64())
 		case "message":
 			out.Message = string(in.String())
+		case "version":
+			out.Version = int64(in.Int64())
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -209,7 +235,7 @@ func easyjson5cae51c1DecodeDbPerformanceProjectInternalPostDeliveryModels1(in *j
 		in.Consumed()
 	}
 }
-func easyjson5cae51c1EncodeDbPerformanceProjectInternalPostDeliveryModels1(out *jwriter.Writer, in PostUpdateRequest) {
+func easyjson5cae51c1EncodeProjectInternalPostDeliveryModels1(out *jwriter.Writer, in PostUpdateRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -229,29 +255,39 @@ func easyjson5cae51c1EncodeDbPerformanceProjectInternalPostDeliveryModels1(out *
 		}
 		out.String(string(in.Message))
 	}
+	if in.Version != 0 {
+		const prefix string = ",\"version\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Version))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v PostUpdateRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson5cae51c1EncodeDbPerformanceProjectInternalPostDeliveryModels1(&w, v)
+	easyjson5cae51c1EncodeProjectInternalPostDeliveryModels1(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v PostUpdateRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson5cae51c1EncodeDbPerformanceProjectInternalPostDeliveryModels1(w, v)
+	easyjson5cae51c1EncodeProjectInternalPostDeliveryModels1(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *PostUpdateRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson5cae51c1DecodeDbPerformanceProjectInternalPostDeliveryModels1(&r, v)
+	easyjson5cae51c1DecodeProjectInternalPostDeliveryModels1(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *PostUpdateRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson5cae51c1DecodeDbPerformanceProjectInternalPostDeliveryModels1(l, v)
+	easyjson5cae51c1DecodeProjectInternalPostDeliveryModels1(l, v)
 }
@@ -5,8 +5,10 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 
+	repoAttachment "project/internal/attachment/repository"
 	"project/internal/models"
 	"project/internal/pkg"
 	"project/internal/pkg/sqltools"
@@ -14,15 +16,17 @@ import (
 
 type PostRepository interface {
 	GetParentPost(ctx context.Context, post *models.Post) (*models.Post, error)
-	UpdatePost(ctx context.Context, post *models.Post) (*models.Post, error)
+	UpdatePost(ctx context.Context, post *models.Post, versions []time.Time) (*models.Post, error)
 	GetDetailsPost(ctx context.Context, post *models.Post, params *pkg.PostDetailsParams) (*models.PostDetails, error)
+	GetPostsByAuthor(ctx context.Context, user *models.User, viewer string, limit int64) ([]models.Post, error)
+	GetAttachments(ctx context.Context, posts []models.Post) error
 }
 
 type postPostgres struct {
-	conn *sql.DB
+	conn *sqltools.Cluster
 }
 
-func NewPostPostgres(conn *sql.DB) PostRepository {
+func NewPostPostgres(conn *sqltools.Cluster) PostRepository {
 	return &postPostgres{
 		conn,
 	}
@@ -31,7 +35,7 @@ func NewPostPostgres(conn *sql.DB) PostRepository {
 func (p postPostgres) GetParentPost(ctx context.Context, post *models.Post) (*models.Post, error) {
 	res := &models.Post{}
 
-	row := p.conn.QueryRowContext(ctx, `SELECT thread_id
+	row := p.conn.Primary(ctx).QueryRowContext(ctx, `SELECT thread_id
 		FROM posts
 		WHERE post_id = $1;`, post.Parent)
 	if row.Err() != nil {
@@ -54,10 +58,20 @@ func (p postPostgres) GetParentPost(ctx context.Context, post *models.Post) (*mo
 	return res, nil
 }
 
-func (p postPostgres) UpdatePost(ctx context.Context, post *models.Post) (*models.Post, error) {
+// postSnapshot selects the state of the post with the ID for the audit log
+const postSnapshot = `SELECT post_id::text, to_jsonb(p) FROM posts p WHERE post_id = $1`
+
+// UpdatePost updates the post if its modification time is one of versions and its version number is that of the
+// given post, nil and 0 respectively allowing any.
+func (p postPostgres) UpdatePost(ctx context.Context, post *models.Post, versions []time.Time) (*models.Post, error) {
 	res := &models.Post{}
 
-	err := sqltools.RunTxOnConn(ctx, pkg.TxInsertOptions, p.conn, func(ctx context.Context, tx *sql.Tx) error {
+	err := sqltools.RunTxOnConn(ctx, pkg.TxInsertOptions, p.conn.Write(ctx), func(ctx context.Context, tx *sql.Tx) error {
+		before, err := sqltools.Snapshot(ctx, tx, postSnapshot+` FOR UPDATE`, post.ID)
+		if err != nil {
+			return err
+		}
+
 		row := tx.QueryRowContext(ctx, `UPDATE posts
 		SET message   = COALESCE(NULLIF(TRIM($2), ''), message),
 			is_edited = CASE
@@ -65,22 +79,49 @@ func (p postPostgres) UpdatePost(ctx context.Context, post *models.Post) (*model
 					ELSE true
 				END
 		WHERE post_id = $1
-		RETURNING parent, author, forum, thread_id, created, message, is_edited;`, post.ID, post.Message)
+			AND ($3::timestamptz[] IS NULL OR modified = ANY ($3::timestamptz[]))
+			AND ($4::bigint = 0 OR version = $4)
+		RETURNING parent, author, forum, thread_id, created, message, is_edited, modified, version;`,
+			post.ID, post.Message, sqltools.TimeArray(versions), post.Version)
 		if row.Err() != nil {
 			return row.Err()
 		}
 
 		postTime := time.Time{}
 
-		err := row.Scan(
+		err = row.Scan(
 			&res.Parent,
 			&res.Author.Nickname,
 			&res.Forum,
 			&res.Thread,
 			&postTime,
 			&res.Message,
-			&res.IsEdited)
+			&res.IsEdited,
+			&res.Modified,
+			&res.Version)
 		if err != nil {
+			// The row is locked, so what failed is told from its state: If-Match first, as RFC 9110 evaluates
+			// the preconditions before the request
+			if errors.Is(err, sql.ErrNoRows) && versions != nil {
+				matches := false
+
+				err = tx.QueryRowContext(ctx, `SELECT modified = ANY ($2::timestamptz[]) FROM posts WHERE post_id = $1;`,
+					post.ID, sqltools.TimeArray(versions)).Scan(&matches)
+				if err != nil && !errors.Is(err, sql.ErrNoRows) {
+					return err
+				}
+
+				if err == nil && !matches {
+					return pkg.ErrPreconditionFailed
+				}
+
+				err = sql.ErrNoRows
+			}
+
+			if errors.Is(err, sql.ErrNoRows) && post.Version != 0 {
+				return pkg.ErrVersionConflict
+			}
+
 			if errors.Is(err, sql.ErrNoRows) {
 				return pkg.ErrSuchPostNotFound
 			}
@@ -92,7 +133,17 @@ func (p postPostgres) UpdatePost(ctx context.Context, post *models.Post) (*model
 
 		res.ID = post.ID
 
-		return nil
+		_, err = tx.ExecContext(ctx, `SELECT function_emit_event($1, $2, $3, $4);`, pkg.EventPostUpdated, res.Thread, res.ID, res.Author.Nickname)
+		if err != nil {
+			return err
+		}
+
+		after, err := sqltools.Snapshot(ctx, tx, postSnapshot, post.ID)
+		if err != nil {
+			return err
+		}
+
+		return sqltools.Audit(ctx, tx, pkg.AuditEntityPost, pkg.AuditActionUpdate, before, after)
 	})
 	if err != nil {
 		return nil, err
@@ -106,9 +157,11 @@ func (p postPostgres) GetDetailsPost(ctx context.Context, post *models.Post, par
 
 	res.Post.ID = post.ID
 
-	row := p.conn.QueryRowContext(ctx, `SELECT parent, author, message, is_edited, forum, thread_id, created
-		FROM posts
-		WHERE post_id = $1;`, post.ID)
+	row := p.conn.Replica(ctx).QueryRowContext(ctx, `SELECT p.parent, p.author, p.message, p.is_edited, p.forum, p.thread_id, p.created,
+			p.modified, p.version, f.visibility
+		FROM posts p
+			JOIN forums f ON f.slug = p.forum
+		WHERE p.post_id = $1;`, post.ID)
 	if row.Err() != nil {
 		if errors.Is(row.Err(), sql.ErrNoRows) {
 			return nil, pkg.ErrSuchPostNotFound
@@ -124,7 +177,10 @@ func (p postPostgres) GetDetailsPost(ctx context.Context, post *models.Post, par
 		&res.Post.IsEdited,
 		&res.Post.Forum,
 		&res.Post.Thread,
-		&res.Post.Created)
+		&res.Post.Created,
+		&res.Post.Modified,
+		&res.Post.Version,
+		&res.Post.Visibility)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, pkg.ErrSuchPostNotFound
@@ -136,7 +192,7 @@ func (p postPostgres) GetDetailsPost(ctx context.Context, post *models.Post, par
 	for _, value := range params.Related {
 		switch value {
 		case pkg.PostDetailForum:
-			row := p.conn.QueryRowContext(ctx, `SELECT title, users_nickname, slug, posts, threads
+			row := p.conn.Replica(ctx).QueryRowContext(ctx, `SELECT title, users_nickname, slug, posts, threads, version
 				FROM forums 
 				WHERE slug = $1;`, res.Post.Forum)
 			if row.Err() != nil {
@@ -152,7 +208,8 @@ func (p postPostgres) GetDetailsPost(ctx context.Context, post *models.Post, par
 				&res.Forum.User,
 				&res.Forum.Slug,
 				&res.Forum.Posts,
-				&res.Forum.Threads)
+				&res.Forum.Threads,
+				&res.Forum.Version)
 			if err != nil {
 				if errors.Is(err, sql.ErrNoRows) {
 					return nil, pkg.ErrSuchPostNotFound
@@ -161,7 +218,7 @@ func (p postPostgres) GetDetailsPost(ctx context.Context, post *models.Post, par
 				return nil, err
 			}
 		case pkg.PostDetailAuthor:
-			row := p.conn.QueryRowContext(ctx, `SELECT nickname, fullname, about, email
+			row := p.conn.Replica(ctx).QueryRowContext(ctx, `SELECT nickname, fullname, about, email, version
 				FROM users 
 				WHERE nickname = $1;`, res.Post.Author.Nickname)
 			if row.Err() != nil {
@@ -176,7 +233,8 @@ func (p postPostgres) GetDetailsPost(ctx context.Context, post *models.Post, par
 				&res.Author.Nickname,
 				&res.Author.FullName,
 				&res.Author.About,
-				&res.Author.Email)
+				&res.Author.Email,
+				&res.Author.Version)
 			if err != nil {
 				if errors.Is(err, sql.ErrNoRows) {
 					return nil, pkg.ErrSuchPostNotFound
@@ -185,7 +243,7 @@ func (p postPostgres) GetDetailsPost(ctx context.Context, post *models.Post, par
 				return nil, err
 			}
 		case pkg.PostDetailThread:
-			row := p.conn.QueryRowContext(ctx, `SELECT thread_id, title, author, forum, message, votes, slug, created
+			row := p.conn.Replica(ctx).QueryRowContext(ctx, `SELECT thread_id, title, author, forum, message, votes, slug, created, version
 				FROM threads
 				WHERE thread_id = $1;`, res.Post.Thread)
 			if row.Err() != nil {
@@ -204,7 +262,8 @@ func (p postPostgres) GetDetailsPost(ctx context.Context, post *models.Post, par
 				&res.Thread.Message,
 				&res.Thread.Votes,
 				&res.Thread.Slug,
-				&res.Thread.Created)
+				&res.Thread.Created,
+				&res.Thread.Version)
 			if err != nil {
 				if errors.Is(err, sql.ErrNoRows) {
 					return nil, pkg.ErrSuchPostNotFound
@@ -217,3 +276,83 @@ func (p postPostgres) GetDetailsPost(ctx context.Context, post *models.Post, par
 
 	return res, nil
 }
+
+// GetPostsByAuthor returns the latest posts of the user, leaving out private forums the viewer is not a member of.
+func (p postPostgres) GetPostsByAuthor(ctx context.Context, user *models.User, viewer string, limit int64) ([]models.Post, error) {
+	rows, err := p.conn.Replica(ctx).QueryContext(ctx, `SELECT p.post_id, p.parent, p.author, p.message, p.is_edited, p.forum, p.thread_id, p.created
+		FROM posts p
+			JOIN forums f ON f.slug = p.forum
+		WHERE p.author = $1
+		  AND (f.visibility <> 'private'
+			OR f.users_nickname = $2
+			OR EXISTS(SELECT 1 FROM forum_members m WHERE m.forum = f.slug AND m.nickname = $2 AND m.status = 'member'))
+		ORDER BY p.created DESC, p.post_id DESC
+		LIMIT $3;`, user.Nickname, viewer, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]models.Post, 0)
+
+	for rows.Next() {
+		post := models.Post{}
+
+		timeTmp := time.Time{}
+
+		err = rows.Scan(
+			&post.ID,
+			&post.Parent,
+			&post.Author.Nickname,
+			&post.Message,
+			&post.IsEdited,
+			&post.Forum,
+			&post.Thread,
+			&timeTmp)
+		if err != nil {
+			return nil, err
+		}
+
+		post.Created = timeTmp.Format(time.RFC3339)
+
+		res = append(res, post)
+	}
+
+	return res, nil
+}
+
+// GetAttachments fills the attachments of the posts, in the order they were uploaded.
+func (p postPostgres) GetAttachments(ctx context.Context, posts []models.Post) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(posts))
+	byID := make(map[int64]*models.Post, len(posts))
+
+	for idx := range posts {
+		ids[idx] = posts[idx].ID
+		byID[posts[idx].ID] = &posts[idx]
+	}
+
+	rows, err := p.conn.Replica(ctx).QueryContext(ctx, `SELECT `+repoAttachment.AttachmentColumns+`
+		FROM attachments a
+		WHERE a.post_id = ANY ($1::bigint[])
+		ORDER BY a.attachment_id;`, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		attachment, err := repoAttachment.ScanAttachment(rows)
+		if err != nil {
+			return err
+		}
+
+		post := byID[attachment.Post]
+		post.Attachments = append(post.Attachments, attachment)
+	}
+
+	return rows.Err()
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	repoForum "project/internal/forum/repository"
 	"project/internal/models"
 	"project/internal/pkg"
 	"project/internal/post/repository"
@@ -16,26 +17,103 @@ type PostService interface {
 }
 
 type postService struct {
-	postRepo repository.PostRepository
+	postRepo  repository.PostRepository
+	forumRepo repoForum.ForumRepository
 }
 
-func NewPostService(r repository.PostRepository) PostService {
+func NewPostService(r repository.PostRepository, rf repoForum.ForumRepository) PostService {
 	return &postService{
-		postRepo: r,
+		postRepo:  r,
+		forumRepo: rf,
 	}
 }
 
+// checkAccess hides posts of private forums from non-members and rejects edits in read-only forums. The visibility
+// read with the post spares the lookup of the membership in public forums.
+func (p postService) checkAccess(ctx context.Context, post *models.Post, write bool) error {
+	if post.Visibility == pkg.ForumVisibilityPublic {
+		return nil
+	}
+
+	access, err := p.forumRepo.GetAccessForum(ctx, &models.Forum{Slug: post.Forum}, pkg.GetNickname(ctx))
+	if err != nil {
+		return err
+	}
+
+	if !pkg.CanReadForum(access) {
+		return pkg.ErrSuchPostNotFound
+	}
+
+	if write && !pkg.CanWriteForum(access) {
+		return pkg.ErrForumReadOnly
+	}
+
+	return nil
+}
+
+// getAttachments fills the attachments of the post.
+func (p postService) getAttachments(ctx context.Context, post *models.Post) error {
+	posts := []models.Post{*post}
+
+	err := p.postRepo.GetAttachments(ctx, posts)
+	if err != nil {
+		return err
+	}
+
+	post.Attachments = posts[0].Attachments
+
+	return nil
+}
+
+// UpdatePost returns the current state of the post along with ErrVersionConflict when the expected version of the
+// post is given and outdated. If-Match is evaluated first, so ErrPreconditionFailed wins when both are stale.
 func (p postService) UpdatePost(ctx context.Context, post *models.Post) (*models.Post, error) {
+	// The version is checked against the latest state
+	primary := pkg.WithPrimaryReads(ctx)
+
+	current, err := p.postRepo.GetDetailsPost(primary, post, &pkg.PostDetailsParams{})
+	if err != nil {
+		return nil, errors.Wrap(err, "GetDetailsPost")
+	}
+
+	err = p.checkAccess(ctx, &current.Post, post.Message != "")
+	if err != nil {
+		return nil, errors.Wrap(err, "UpdatePost")
+	}
+
+	conditional := pkg.GetConditionalParams(ctx)
+
+	if !conditional.MatchesVersion(current.Post.Modified) {
+		return nil, errors.Wrap(pkg.ErrPreconditionFailed, "UpdatePost")
+	}
+
+	if post.Version != 0 && post.Version != current.Post.Version {
+		return &current.Post, errors.Wrap(pkg.ErrVersionConflict, "UpdatePost")
+	}
+
 	if post.Message == "" {
-		res, err := p.postRepo.GetDetailsPost(ctx, post, &pkg.PostDetailsParams{})
+		err = p.getAttachments(ctx, &current.Post)
+		if err != nil {
+			return nil, errors.Wrap(err, "UpdatePost")
+		}
+
+		return &current.Post, nil
+	}
+
+	res, err := p.postRepo.UpdatePost(ctx, post, conditional.IfMatchVersions())
+	if errors.Is(err, pkg.ErrVersionConflict) {
+		current, err = p.postRepo.GetDetailsPost(primary, post, &pkg.PostDetailsParams{})
 		if err != nil {
-			return nil, errors.Wrap(err, "GetDetailsPost")
+			return nil, errors.Wrap(err, "UpdatePost")
 		}
 
-		return &res.Post, nil
+		return &current.Post, errors.Wrap(pkg.ErrVersionConflict, "UpdatePost")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "UpdatePost")
 	}
 
-	res, err := p.postRepo.UpdatePost(ctx, post)
+	err = p.getAttachments(ctx, res)
 	if err != nil {
 		return nil, errors.Wrap(err, "UpdatePost")
 	}
@@ -49,5 +127,15 @@ func (p postService) GetDetailsPost(ctx context.Context, post *models.Post, para
 		return nil, errors.Wrap(err, "GetDetailsPost")
 	}
 
+	err = p.checkAccess(ctx, &res.Post, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetDetailsPost")
+	}
+
+	err = p.getAttachments(ctx, &res.Post)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetDetailsPost")
+	}
+
 	return res, nil
 }
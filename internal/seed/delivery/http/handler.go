@@ -0,0 +1,40 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"project/internal/pkg"
+	"project/internal/seed/delivery/models"
+	"project/internal/seed/usecase"
+)
+
+type SeedHandler struct {
+	seedUsecase usecase.SeedService
+}
+
+func (h *SeedHandler) SeedHandler(w http.ResponseWriter, r *http.Request) {
+	request := models.NewSeedRequest()
+
+	err := request.Bind(r)
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	result, err := h.seedUsecase.Seed(r.Context(), request.Profile, request.Seed)
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	response := models.NewSeedResponse(result)
+
+	pkg.Response(r.Context(), w, http.StatusCreated, response)
+}
+
+func NewSeedHandler(seedUsecase usecase.SeedService, r *mux.Router) *SeedHandler {
+	h := &SeedHandler{seedUsecase: seedUsecase}
+	return h
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"net/http"
+	"strconv"
+
+	"project/internal/models"
+	"project/internal/pkg"
+)
+
+//go:generate easyjson -disallow_unknown_fields -omit_empty seed.go
+
+type SeedRequest struct {
+	Profile string
+	Seed    int64
+}
+
+func NewSeedRequest() *SeedRequest {
+	return &SeedRequest{}
+}
+
+func (req *SeedRequest) Bind(r *http.Request) error {
+	req.Profile = r.FormValue("profile")
+	if req.Profile == "" {
+		req.Profile = pkg.SeedProfileSmall
+	}
+
+	req.Seed = 1
+
+	param := r.FormValue("seed")
+	if param != "" {
+		value, err := strconv.ParseInt(param, 10, 64)
+		if err != nil {
+			return pkg.ErrConvertQueryType
+		}
+
+		req.Seed = value
+	}
+
+	return nil
+}
+
+//easyjson:json
+type SeedResponse struct {
+	Profile string `json:"profile"`
+	Seed    int64  `json:"seed"`
+	Prefix  string `json:"prefix"`
+	Users   int64  `json:"users"`
+	Forums  int64  `json:"forums"`
+	Threads int64  `json:"threads"`
+	Posts   int64  `json:"posts"`
+	Votes   int64  `json:"votes"`
+}
+
+func NewSeedResponse(result *models.SeedResult) *SeedResponse {
+	return &SeedResponse{
+		Profile: result.Profile,
+		Seed:    result.Seed,
+		Prefix:  result.Prefix,
+		Users:   result.Users,
+		Forums:  result.Forums,
+		Threads: result.Threads,
+		Posts:   result.Posts,
+		Votes:   result.Votes,
+	}
+}
@@ -0,0 +1,174 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package models
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson7e047053DecodeProjectInternalSeedDeliveryModels(in *jlexer.Lexer, out *SeedResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "profile":
+			out.Profile = string(in.String())
+		case "seed":
+			out.Seed = int64(in.Int64())
+		case "prefix":
+			out.Prefix = string(in.String())
+		case "users":
+			out.Users = int64(in.Int64())
+		case "forums":
+			out.Forums = int64(in.Int64())
+		case "threads":
+			out.Threads = int64(in.Int64())
+		case "posts":
+			out.Posts = int64(in.Int64())
+		case "votes":
+			out.Votes = int64(in.Int64())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson7e047053EncodeProjectInternalSeedDeliveryModels(out *jwriter.Writer, in SeedResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Profile != "" {
+		const prefix string = ",\"profile\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Profile))
+	}
+	if in.Seed != 0 {
+		const prefix string = ",\"seed\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Seed))
+	}
+	if in.Prefix != "" {
+		const prefix string = ",\"prefix\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Prefix))
+	}
+	if in.Users != 0 {
+		const prefix string = ",\"users\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Users))
+	}
+	if in.Forums != 0 {
+		const prefix string = ",\"forums\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Forums))
+	}
+	if in.Threads != 0 {
+		const prefix string = ",\"threads\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Threads))
+	}
+	if in.Posts != 0 {
+		const prefix string = ",\"posts\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Posts))
+	}
+	if in.Votes != 0 {
+		const prefix string = ",\"votes\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Votes))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SeedResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson7e047053EncodeProjectInternalSeedDeliveryModels(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v SeedResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson7e047053EncodeProjectInternalSeedDeliveryModels(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SeedResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson7e047053DecodeProjectInternalSeedDeliveryModels(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *SeedResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson7e047053DecodeProjectInternalSeedDeliveryModels(l, v)
+}
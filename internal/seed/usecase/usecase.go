@@ -0,0 +1,268 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/pkg/errors"
+
+	usecaseForum "project/internal/forum/usecase"
+	"project/internal/models"
+	"project/internal/pkg"
+	"project/internal/pkg/cache"
+	usecaseThread "project/internal/thread/usecase"
+	usecaseUser "project/internal/user/usecase"
+	usecaseVote "project/internal/vote/usecase"
+)
+
+// profile sizes a dataset. Roots is the percentage of posts starting a new tree, chain the percentage continuing one
+// of the latest posts of the thread, which makes deep trees, and hot the percentage of threads going to the first
+// forum.
+type profile struct {
+	users    int
+	forums   int
+	threads  int
+	posts    int
+	votes    int
+	batch    int
+	maxDepth int
+	roots    int
+	chain    int
+	hot      int
+}
+
+var profiles = map[string]profile{
+	pkg.SeedProfileSmall:    {users: 20, forums: 3, threads: 20, posts: 200, votes: 50, batch: 10, maxDepth: 5, roots: 10, chain: 30},
+	pkg.SeedProfileMedium:   {users: 500, forums: 10, threads: 500, posts: 10000, votes: 2000, batch: 10, maxDepth: 20, roots: 10, chain: 50},
+	pkg.SeedProfileDeepTree: {users: 20, forums: 1, threads: 3, posts: 3000, votes: 20, batch: 1, maxDepth: 500, roots: 0, chain: 97},
+	pkg.SeedProfileHotForum: {users: 300, forums: 5, threads: 600, posts: 8000, votes: 3000, batch: 20, maxDepth: 10, roots: 10, chain: 30, hot: 80},
+}
+
+// seedEpoch is when the seeded threads start, so that their dates do not depend on the day of the seeding.
+var seedEpoch = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+type SeedService interface {
+	Seed(ctx context.Context, profile string, seed int64) (*models.SeedResult, error)
+}
+
+type seedService struct {
+	userService   usecaseUser.UserService
+	forumService  usecaseForum.ForumService
+	threadService usecaseThread.ThreadService
+	voteService   usecaseVote.VoteService
+	caches        *cache.Set
+}
+
+// NewSeedService seeds through the usecases, for the triggers and counters to be exercised as by real traffic.
+// The thread usecase should have no flood control, seeded authors post in quick succession. Caches are purged
+// once seeding is over, as the counters of the seeded forums and users moved many times over.
+func NewSeedService(us usecaseUser.UserService, fs usecaseForum.ForumService, ts usecaseThread.ThreadService, vs usecaseVote.VoteService,
+	caches *cache.Set) SeedService {
+	return &seedService{
+		userService:   us,
+		forumService:  fs,
+		threadService: ts,
+		voteService:   vs,
+		caches:        caches,
+	}
+}
+
+// Seed creates the dataset of the profile. The same profile and seed always make the same users, forums, threads,
+// post trees and votes; only the IDs and the dates of the posts depend on the database. Seeding them twice fails
+// on the users which exist already.
+func (s seedService) Seed(ctx context.Context, name string, seed int64) (*models.SeedResult, error) {
+	p, ok := profiles[name]
+	if !ok {
+		return nil, errors.Wrap(pkg.ErrSeedProfileUnknown, "Seed")
+	}
+
+	defer s.caches.Purge()
+
+	r := rand.New(rand.NewSource(seed))
+
+	res := &models.SeedResult{
+		Profile: name,
+		Seed:    seed,
+		Prefix:  fmt.Sprintf("s%d-%s", seed, name),
+	}
+
+	users := make([]string, p.users)
+	for idx := range users {
+		users[idx] = fmt.Sprintf("%s.u%d", res.Prefix, idx)
+
+		_, err := s.userService.CreateUser(ctx, &models.User{
+			Nickname: users[idx],
+			FullName: fmt.Sprintf("Seeded User %d", idx),
+			About:    sentence(r),
+			Email:    users[idx] + "@seed.test",
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "Seed")
+		}
+
+		res.Users++
+	}
+
+	forums := make([]string, p.forums)
+	for idx := range forums {
+		forums[idx] = fmt.Sprintf("%s-f%d", res.Prefix, idx)
+
+		_, err := s.forumService.CreateForum(ctx, &models.Forum{
+			Title: fmt.Sprintf("Seeded Forum %d", idx),
+			User:  users[r.Intn(len(users))],
+			Slug:  forums[idx],
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "Seed")
+		}
+
+		res.Forums++
+	}
+
+	threads := make([]int64, p.threads)
+	for idx := range threads {
+		thread, err := s.threadService.CreateThread(ctx, &models.Thread{
+			Title:   fmt.Sprintf("Seeded Thread %d", idx),
+			Author:  users[r.Intn(len(users))],
+			Forum:   forums[p.pickForum(r)],
+			Message: sentence(r),
+			Created: seedEpoch.Add(time.Duration(idx) * time.Hour).Format(time.RFC3339),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "Seed")
+		}
+
+		threads[idx] = thread.ID
+		res.Threads++
+	}
+
+	// The posts are shared out first, for the trees of a thread not to depend on those of the others
+	sizes := make([]int, len(threads))
+	for idx := 0; idx < p.posts; idx++ {
+		sizes[pickThread(r, len(threads))]++
+	}
+
+	for idx, thread := range threads {
+		count, err := s.seedPosts(ctx, p, r, users, thread, sizes[idx])
+		res.Posts += count
+
+		if err != nil {
+			return nil, errors.Wrap(err, "Seed")
+		}
+	}
+
+	for idx := 0; idx < p.votes; idx++ {
+		voice := int64(1)
+		if r.Intn(4) == 0 {
+			voice = -1
+		}
+
+		_, err := s.voteService.Vote(ctx, &models.Thread{ID: threads[pickThread(r, len(threads))]}, &pkg.VoteParams{
+			Nickname: users[r.Intn(len(users))],
+			Voice:    voice,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "Seed")
+		}
+
+		res.Votes++
+	}
+
+	return res, nil
+}
+
+// seedPosts creates the posts of a thread batch after batch, each post replying to a post of the batches before.
+func (s seedService) seedPosts(ctx context.Context, p profile, r *rand.Rand, users []string, thread int64, size int) (int64, error) {
+	var res int64
+
+	created := make([]int64, 0, size)
+	latest := make([]int64, 0, p.batch)
+	depths := make(map[int64]int, size)
+
+	for len(created) < size {
+		count := p.batch
+		if size-len(created) < count {
+			count = size - len(created)
+		}
+
+		batch := make([]*models.Post, count)
+
+		for idx := range batch {
+			var parent int64
+
+			switch dice := r.Intn(100); {
+			case len(created) == 0 || dice < p.roots:
+			case dice < p.roots+p.chain:
+				parent = latest[r.Intn(len(latest))]
+			default:
+				parent = created[r.Intn(len(created))]
+			}
+
+			if depths[parent] >= p.maxDepth {
+				parent = 0
+			}
+
+			batch[idx] = &models.Post{
+				Parent:  parent,
+				Author:  models.User{Nickname: users[r.Intn(len(users))]},
+				Message: sentence(r),
+			}
+		}
+
+		posts, err := s.threadService.CreatePosts(ctx, &models.Thread{ID: thread}, batch)
+		if err != nil {
+			return res, err
+		}
+
+		latest = latest[:0]
+
+		for _, post := range posts {
+			depths[post.ID] = depths[post.Parent] + 1
+			latest = append(latest, post.ID)
+			created = append(created, post.ID)
+		}
+
+		res += int64(len(posts))
+	}
+
+	return res, nil
+}
+
+// pickForum sends the share of the hot forum to the first one and spreads the rest evenly.
+func (p profile) pickForum(r *rand.Rand) int {
+	if p.forums == 1 || r.Intn(100) < p.hot {
+		return 0
+	}
+
+	return r.Intn(p.forums)
+}
+
+// pickThread picks half of the time among the first tenth of the threads, which become the busy ones. The hot
+// forum holding most threads, it gets most posts and votes too.
+func pickThread(r *rand.Rand, threads int) int {
+	if threads < 10 || r.Intn(2) == 0 {
+		return r.Intn(threads)
+	}
+
+	return r.Intn(threads / 10)
+}
+
+var words = []string{"forum", "thread", "post", "reply", "vote", "tree", "seed", "data", "test", "demo", "user",
+	"value", "answer", "question", "topic", "idea", "point", "case", "note", "issue"}
+
+func sentence(r *rand.Rand) string {
+	size := 4 + r.Intn(16)
+	buf := make([]byte, 0, size*7)
+
+	for idx := 0; idx < size; idx++ {
+		if idx > 0 {
+			buf = append(buf, ' ')
+		}
+
+		buf = append(buf, words[r.Intn(len(words))]...)
+	}
+
+	return string(buf)
+}
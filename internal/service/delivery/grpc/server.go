@@ -0,0 +1,28 @@
+package grpc
+
+import (
+	"context"
+
+	"project/internal/pb"
+	"project/internal/pkg/grpctools"
+	"project/internal/service/usecase"
+)
+
+type StatusServer struct {
+	pb.UnimplementedStatusServiceServer
+
+	serviceUsecase usecase.Service
+}
+
+func (s *StatusServer) GetStatus(ctx context.Context, req *pb.GetStatusRequest) (*pb.Status, error) {
+	status, err := s.serviceUsecase.GetStatus(ctx)
+	if err != nil {
+		return nil, grpctools.Error(err)
+	}
+
+	return pb.NewStatus(status), nil
+}
+
+func NewStatusServer(serviceUsecase usecase.Service) *StatusServer {
+	return &StatusServer{serviceUsecase: serviceUsecase}
+}
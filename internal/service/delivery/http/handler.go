@@ -34,6 +34,18 @@ func (h *ServiceHandler) ServiceStatusHandler(w http.ResponseWriter, r *http.Req
 	pkg.Response(r.Context(), w, http.StatusOK, response)
 }
 
+func (h *ServiceHandler) ServiceCacheStatsHandler(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.serviceUsecase.GetCacheStats(r.Context())
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	response := models.NewServiceGetCacheStatsResponse(stats)
+
+	pkg.Response(r.Context(), w, http.StatusOK, response)
+}
+
 func NewServiceHandler(serviceUsecase usecase.Service, r *mux.Router) *ServiceHandler {
 	h := &ServiceHandler{serviceUsecase: serviceUsecase}
 	return h
@@ -0,0 +1,42 @@
+package models
+
+import "project/internal/pkg/cache"
+
+//go:generate easyjson -disallow_unknown_fields -omit_empty getcachestats.go
+
+//easyjson:json
+type CacheStatsResponse struct {
+	Hits      uint64  `json:"hits"`
+	Misses    uint64  `json:"misses"`
+	HitRatio  float64 `json:"hit_ratio"`
+	Evictions uint64  `json:"evictions"`
+	Expired   uint64  `json:"expired"`
+	Size      int     `json:"size"`
+	Capacity  int     `json:"capacity"`
+}
+
+//easyjson:json
+type ServiceGetCacheStatsResponse map[string]CacheStatsResponse
+
+func NewServiceGetCacheStatsResponse(stats map[string]cache.Stats) ServiceGetCacheStatsResponse {
+	res := make(ServiceGetCacheStatsResponse, len(stats))
+
+	for name, value := range stats {
+		item := CacheStatsResponse{
+			Hits:      value.Hits,
+			Misses:    value.Misses,
+			Evictions: value.Evictions,
+			Expired:   value.Expired,
+			Size:      value.Size,
+			Capacity:  value.Capacity,
+		}
+
+		if value.Hits+value.Misses != 0 {
+			item.HitRatio = float64(value.Hits) / float64(value.Hits+value.Misses)
+		}
+
+		res[name] = item
+	}
+
+	return res
+}
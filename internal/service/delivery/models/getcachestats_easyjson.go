@@ -0,0 +1,230 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package models
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonFb085889DecodeProjectInternalServiceDeliveryModels(in *jlexer.Lexer, out *ServiceGetCacheStatsResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+	} else {
+		in.Delim('{')
+		if !in.IsDelim('}') {
+			*out = make(ServiceGetCacheStatsResponse)
+		} else {
+			*out = nil
+		}
+		for !in.IsDelim('}') {
+			key := string(in.String())
+			in.WantColon()
+			var v1 CacheStatsResponse
+			(v1).UnmarshalEasyJSON(in)
+			(*out)[key] = v1
+			in.WantComma()
+		}
+		in.Delim('}')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonFb085889EncodeProjectInternalServiceDeliveryModels(out *jwriter.Writer, in ServiceGetCacheStatsResponse) {
+	if in == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+		out.RawString(`null`)
+	} else {
+		out.RawByte('{')
+		v2First := true
+		for v2Name, v2Value := range in {
+			if v2First {
+				v2First = false
+			} else {
+				out.RawByte(',')
+			}
+			out.String(string(v2Name))
+			out.RawByte(':')
+			(v2Value).MarshalEasyJSON(out)
+		}
+		out.RawByte('}')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ServiceGetCacheStatsResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonFb085889EncodeProjectInternalServiceDeliveryModels(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ServiceGetCacheStatsResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonFb085889EncodeProjectInternalServiceDeliveryModels(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ServiceGetCacheStatsResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonFb085889DecodeProjectInternalServiceDeliveryModels(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ServiceGetCacheStatsResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonFb085889DecodeProjectInternalServiceDeliveryModels(l, v)
+}
+func easyjsonFb085889DecodeProjectInternalServiceDeliveryModels1(in *jlexer.Lexer, out *CacheStatsResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "hits":
+			out.Hits = uint64(in.Uint64())
+		case "misses":
+			out.Misses = uint64(in.Uint64())
+		case "hit_ratio":
+			out.HitRatio = float64(in.Float64())
+		case "evictions":
+			out.Evictions = uint64(in.Uint64())
+		case "expired":
+			out.Expired = uint64(in.Uint64())
+		case "size":
+			out.Size = int(in.Int())
+		case "capacity":
+			out.Capacity = int(in.Int())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonFb085889EncodeProjectInternalServiceDeliveryModels1(out *jwriter.Writer, in CacheStatsResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Hits != 0 {
+		const prefix string = ",\"hits\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.Hits))
+	}
+	if in.Misses != 0 {
+		const prefix string = ",\"misses\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(in.Misses))
+	}
+	if in.HitRatio != 0 {
+		const prefix string = ",\"hit_ratio\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Float64(float64(in.HitRatio))
+	}
+	if in.Evictions != 0 {
+		const prefix string = ",\"evictions\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(in.Evictions))
+	}
+	if in.Expired != 0 {
+		const prefix string = ",\"expired\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(in.Expired))
+	}
+	if in.Size != 0 {
+		const prefix string = ",\"size\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Size))
+	}
+	if in.Capacity != 0 {
+		const prefix string = ",\"capacity\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Capacity))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CacheStatsResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonFb085889EncodeProjectInternalServiceDeliveryModels1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CacheStatsResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonFb085889EncodeProjectInternalServiceDeliveryModels1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CacheStatsResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonFb085889DecodeProjectInternalServiceDeliveryModels1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CacheStatsResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonFb085889DecodeProjectInternalServiceDeliveryModels1(l, v)
+}
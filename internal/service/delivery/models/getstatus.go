@@ -5,17 +5,76 @@ import "project/internal/models"
 //go:generate easyjson -all -disallow_unknown_fields -omit_empty getstatus.go
 
 type ServiceGetStatusResponse struct {
-	User   int64 `json:"user"`
-	Forum  int64 `json:"forum"`
-	Thread int64 `json:"thread"`
-	Post   int64 `json:"post"`
+	User        int64                      `json:"user"`
+	Forum       int64                      `json:"forum"`
+	Thread      int64                      `json:"thread"`
+	Post        int64                      `json:"post"`
+	Consistency *ConsistencyReportResponse `json:"consistency,omitempty"`
+}
+
+type ConsistencyReportResponse struct {
+	Started  string                     `json:"started"`
+	Finished string                     `json:"finished"`
+	Repair   bool                       `json:"repair"`
+	Checks   []ConsistencyCheckResponse `json:"checks"`
+}
+
+type ConsistencyCheckResponse struct {
+	Name     string                     `json:"name"`
+	Found    int64                      `json:"found"`
+	Repaired int64                      `json:"repaired"`
+	Samples  []ConsistencyIssueResponse `json:"samples,omitempty"`
+}
+
+type ConsistencyIssueResponse struct {
+	Key      string `json:"key"`
+	Field    string `json:"field"`
+	Stored   string `json:"stored"`
+	Expected string `json:"expected"`
 }
 
 func NewServiceGetStatusResponse(service *models.StatusService) *ServiceGetStatusResponse {
-	return &ServiceGetStatusResponse{
+	res := &ServiceGetStatusResponse{
 		User:   service.User,
 		Forum:  service.Forum,
 		Thread: service.Thread,
 		Post:   service.Post,
 	}
+
+	if service.Consistency != nil {
+		res.Consistency = NewConsistencyReportResponse(service.Consistency)
+	}
+
+	return res
+}
+
+func NewConsistencyReportResponse(report *models.ConsistencyReport) *ConsistencyReportResponse {
+	res := &ConsistencyReportResponse{
+		Started:  report.Started,
+		Finished: report.Finished,
+		Repair:   report.Repair,
+		Checks:   make([]ConsistencyCheckResponse, 0, len(report.Checks)),
+	}
+
+	for _, check := range report.Checks {
+		samples := make([]ConsistencyIssueResponse, 0, len(check.Samples))
+
+		for _, issue := range check.Samples {
+			samples = append(samples, ConsistencyIssueResponse{
+				Key:      issue.Key,
+				Field:    issue.Field,
+				Stored:   issue.Stored,
+				Expected: issue.Expected,
+			})
+		}
+
+		res.Checks = append(res.Checks, ConsistencyCheckResponse{
+			Name:     check.Name,
+			Found:    check.Found,
+			Repaired: check.Repaired,
+			Samples:  samples,
+		})
+	}
+
+	return res
 }
@@ -17,7 +17,7 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjsonB703cb64DecodeDbPerformanceProjectInternalServiceDeliveryModels(in *jlexer.Lexer, out *ServiceGetStatusResponse) {
+func easyjsonB703cb64DecodeProjectInternalServiceDeliveryModels(in *jlexer.Lexer, out *ServiceGetStatusResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -44,6 +44,16 @@ func easyjsonB703cb64DecodeDbPerformanceProjectInternalServiceDeliveryModels(in
 			out.Thread = int64(in.Int64())
 		case "post":
 			out.Post = int64(in.Int64())
+		case "consistency":
+			if in.IsNull() {
+				in.Skip()
+				out.Consistency = nil
+			} else {
+				if out.Consistency == nil {
+					out.Consistency = new(ConsistencyReportResponse)
+				}
+				(*out.Consistency).UnmarshalEasyJSON(in)
+			}
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -58,7 +68,7 @@ func easyjsonB703cb64DecodeDbPerformanceProjectInternalServiceDeliveryModels(in
 		in.Consumed()
 	}
 }
-func easyjsonB703cb64EncodeDbPerformanceProjectInternalServiceDeliveryModels(out *jwriter.Writer, in ServiceGetStatusResponse) {
+func easyjsonB703cb64EncodeProjectInternalServiceDeliveryModels(out *jwriter.Writer, in ServiceGetStatusResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -98,29 +108,420 @@ func easyjsonB703cb64EncodeDbPerformanceProjectInternalServiceDeliveryModels(out
 		}
 		out.Int64(int64(in.Post))
 	}
+	if in.Consistency != nil {
+		const prefix string = ",\"consistency\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		(*in.Consistency).MarshalEasyJSON(out)
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v ServiceGetStatusResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonB703cb64EncodeDbPerformanceProjectInternalServiceDeliveryModels(&w, v)
+	easyjsonB703cb64EncodeProjectInternalServiceDeliveryModels(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ServiceGetStatusResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonB703cb64EncodeDbPerformanceProjectInternalServiceDeliveryModels(w, v)
+	easyjsonB703cb64EncodeProjectInternalServiceDeliveryModels(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ServiceGetStatusResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonB703cb64DecodeDbPerformanceProjectInternalServiceDeliveryModels(&r, v)
+	easyjsonB703cb64DecodeProjectInternalServiceDeliveryModels(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ServiceGetStatusResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonB703cb64DecodeDbPerformanceProjectInternalServiceDeliveryModels(l, v)
+	easyjsonB703cb64DecodeProjectInternalServiceDeliveryModels(l, v)
+}
+func easyjsonB703cb64DecodeProjectInternalServiceDeliveryModels1(in *jlexer.Lexer, out *ConsistencyReportResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "started":
+			out.Started = string(in.String())
+		case "finished":
+			out.Finished = string(in.String())
+		case "repair":
+			out.Repair = bool(in.Bool())
+		case "checks":
+			if in.IsNull() {
+				in.Skip()
+				out.Checks = nil
+			} else {
+				in.Delim('[')
+				if out.Checks == nil {
+					if !in.IsDelim(']') {
+						out.Checks = make([]ConsistencyCheckResponse, 0, 1)
+					} else {
+						out.Checks = []ConsistencyCheckResponse{}
+					}
+				} else {
+					out.Checks = (out.Checks)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 ConsistencyCheckResponse
+					(v1).UnmarshalEasyJSON(in)
+					out.Checks = append(out.Checks, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonB703cb64EncodeProjectInternalServiceDeliveryModels1(out *jwriter.Writer, in ConsistencyReportResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Started != "" {
+		const prefix string = ",\"started\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Started))
+	}
+	if in.Finished != "" {
+		const prefix string = ",\"finished\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Finished))
+	}
+	if in.Repair {
+		const prefix string = ",\"repair\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.Repair))
+	}
+	if len(in.Checks) != 0 {
+		const prefix string = ",\"checks\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v2, v3 := range in.Checks {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ConsistencyReportResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonB703cb64EncodeProjectInternalServiceDeliveryModels1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ConsistencyReportResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonB703cb64EncodeProjectInternalServiceDeliveryModels1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ConsistencyReportResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonB703cb64DecodeProjectInternalServiceDeliveryModels1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ConsistencyReportResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonB703cb64DecodeProjectInternalServiceDeliveryModels1(l, v)
+}
+func easyjsonB703cb64DecodeProjectInternalServiceDeliveryModels2(in *jlexer.Lexer, out *ConsistencyIssueResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "key":
+			out.Key = string(in.String())
+		case "field":
+			out.Field = string(in.String())
+		case "stored":
+			out.Stored = string(in.String())
+		case "expected":
+			out.Expected = string(in.String())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonB703cb64EncodeProjectInternalServiceDeliveryModels2(out *jwriter.Writer, in ConsistencyIssueResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Key != "" {
+		const prefix string = ",\"key\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Key))
+	}
+	if in.Field != "" {
+		const prefix string = ",\"field\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Field))
+	}
+	if in.Stored != "" {
+		const prefix string = ",\"stored\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Stored))
+	}
+	if in.Expected != "" {
+		const prefix string = ",\"expected\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Expected))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ConsistencyIssueResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonB703cb64EncodeProjectInternalServiceDeliveryModels2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ConsistencyIssueResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonB703cb64EncodeProjectInternalServiceDeliveryModels2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ConsistencyIssueResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonB703cb64DecodeProjectInternalServiceDeliveryModels2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ConsistencyIssueResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonB703cb64DecodeProjectInternalServiceDeliveryModels2(l, v)
+}
+func easyjsonB703cb64DecodeProjectInternalServiceDeliveryModels3(in *jlexer.Lexer, out *ConsistencyCheckResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "found":
+			out.Found = int64(in.Int64())
+		case "repaired":
+			out.Repaired = int64(in.Int64())
+		case "samples":
+			if in.IsNull() {
+				in.Skip()
+				out.Samples = nil
+			} else {
+				in.Delim('[')
+				if out.Samples == nil {
+					if !in.IsDelim(']') {
+						out.Samples = make([]ConsistencyIssueResponse, 0, 1)
+					} else {
+						out.Samples = []ConsistencyIssueResponse{}
+					}
+				} else {
+					out.Samples = (out.Samples)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 ConsistencyIssueResponse
+					(v4).UnmarshalEasyJSON(in)
+					out.Samples = append(out.Samples, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonB703cb64EncodeProjectInternalServiceDeliveryModels3(out *jwriter.Writer, in ConsistencyCheckResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Name != "" {
+		const prefix string = ",\"name\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	if in.Found != 0 {
+		const prefix string = ",\"found\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Found))
+	}
+	if in.Repaired != 0 {
+		const prefix string = ",\"repaired\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Repaired))
+	}
+	if len(in.Samples) != 0 {
+		const prefix string = ",\"samples\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v5, v6 := range in.Samples {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				(v6).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ConsistencyCheckResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonB703cb64EncodeProjectInternalServiceDeliveryModels3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ConsistencyCheckResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonB703cb64EncodeProjectInternalServiceDeliveryModels3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ConsistencyCheckResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonB703cb64DecodeProjectInternalServiceDeliveryModels3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ConsistencyCheckResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonB703cb64DecodeProjectInternalServiceDeliveryModels3(l, v)
 }
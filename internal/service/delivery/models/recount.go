@@ -0,0 +1,21 @@
+package models
+
+import "project/internal/models"
+
+//go:generate easyjson -all -disallow_unknown_fields -omit_empty recount.go
+
+type ServiceRecountResponse struct {
+	Forums     int64 `json:"forums"`
+	Threads    int64 `json:"threads"`
+	UserForums int64 `json:"userForums"`
+	Rollups    int64 `json:"rollups"`
+}
+
+func NewServiceRecountResponse(recount *models.RecountService) *ServiceRecountResponse {
+	return &ServiceRecountResponse{
+		Forums:     recount.Forums,
+		Threads:    recount.Threads,
+		UserForums: recount.UserForums,
+		Rollups:    recount.Rollups,
+	}
+}
@@ -0,0 +1,126 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package models
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson620128c6DecodeProjectInternalServiceDeliveryModels(in *jlexer.Lexer, out *ServiceRecountResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "forums":
+			out.Forums = int64(in.Int64())
+		case "threads":
+			out.Threads = int64(in.Int64())
+		case "userForums":
+			out.UserForums = int64(in.Int64())
+		case "rollups":
+			out.Rollups = int64(in.Int64())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson620128c6EncodeProjectInternalServiceDeliveryModels(out *jwriter.Writer, in ServiceRecountResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Forums != 0 {
+		const prefix string = ",\"forums\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.Forums))
+	}
+	if in.Threads != 0 {
+		const prefix string = ",\"threads\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Threads))
+	}
+	if in.UserForums != 0 {
+		const prefix string = ",\"userForums\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.UserForums))
+	}
+	if in.Rollups != 0 {
+		const prefix string = ",\"rollups\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Rollups))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ServiceRecountResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson620128c6EncodeProjectInternalServiceDeliveryModels(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ServiceRecountResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson620128c6EncodeProjectInternalServiceDeliveryModels(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ServiceRecountResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson620128c6DecodeProjectInternalServiceDeliveryModels(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ServiceRecountResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson620128c6DecodeProjectInternalServiceDeliveryModels(l, v)
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 
 	"project/internal/models"
 	"project/internal/pkg"
@@ -12,26 +13,38 @@ import (
 type ServiceRepository interface {
 	Clear(ctx context.Context) error
 	GetStatus(ctx context.Context) (*models.StatusService, error)
+	Recount(ctx context.Context) (*models.RecountService, error)
 }
 
 type servicePostgres struct {
-	conn *sql.DB
+	conn *sqltools.Cluster
 }
 
-func NewServicePostgres(conn *sql.DB) ServiceRepository {
+func NewServicePostgres(conn *sqltools.Cluster) ServiceRepository {
 	return &servicePostgres{
 		conn,
 	}
 }
 
 func (s servicePostgres) Clear(ctx context.Context) error {
-	err := sqltools.RunTxOnConn(ctx, pkg.TxInsertOptions, s.conn, func(ctx context.Context, tx *sql.Tx) error {
-		row := tx.QueryRowContext(ctx, `TRUNCATE TABLE forums, posts, threads, user_forums, users, user_votes CASCADE;`)
-		if row.Err() != nil {
-			return row.Err()
+	err := sqltools.RunTxOnConn(ctx, pkg.TxInsertOptions, s.conn.Write(ctx), func(ctx context.Context, tx *sql.Tx) error {
+		// What was cleared is recorded by the counts, the audit log itself is kept
+		before, err := sqltools.Snapshot(ctx, tx, `SELECT '', jsonb_build_object(
+			'forums', (SELECT count(*) FROM forums),
+			'posts', (SELECT count(*) FROM posts),
+			'threads', (SELECT count(*) FROM threads),
+			'users', (SELECT count(*) FROM users));`)
+		if err != nil {
+			return err
 		}
 
-		return nil
+		_, err = tx.ExecContext(ctx, `TRUNCATE TABLE forums, forum_members, posts, threads, events, events_horizon, webhooks, webhook_deliveries, user_forums, users, user_votes,
+			forum_activity, forum_active_users, forum_authors, thread_depths, forum_depths, thread_reads, attachments CASCADE;`)
+		if err != nil {
+			return err
+		}
+
+		return sqltools.Audit(ctx, tx, pkg.AuditEntityService, pkg.AuditActionClear, before, nil)
 	})
 
 	return err
@@ -40,7 +53,7 @@ func (s servicePostgres) Clear(ctx context.Context) error {
 func (s servicePostgres) GetStatus(ctx context.Context) (*models.StatusService, error) {
 	res := &models.StatusService{}
 
-	row := s.conn.QueryRowContext(ctx, `SELECT (SELECT count(*) FROM forums) AS forums,
+	row := s.conn.Replica(ctx).QueryRowContext(ctx, `SELECT (SELECT count(*) FROM forums) AS forums,
        (SELECT count(*) FROM posts)  AS posts,
        (SELECT count(*) FROM threads) AS threads,
        (SELECT count(*) FROM users)  AS users`)
@@ -59,3 +72,161 @@ func (s servicePostgres) GetStatus(ctx context.Context) (*models.StatusService,
 
 	return res, nil
 }
+
+// recountForums sums the posts and threads of each forum with those of the sub-forums rolled up into it, the way
+// function_count_posts and function_count_threads count them.
+const recountForums = `WITH RECURSIVE own AS (
+		SELECT forum AS slug, count(*) AS posts, 0 AS threads FROM posts GROUP BY forum
+		UNION ALL
+		SELECT forum, 0, count(*) FROM threads GROUP BY forum
+	), chain(source, target, parent, roll_up) AS (
+		SELECT slug, slug, parent, roll_up FROM forums
+		UNION ALL
+		SELECT c.source, f.slug, f.parent, f.roll_up FROM chain c JOIN forums f ON f.slug = c.parent WHERE c.roll_up
+	), totals AS (
+		SELECT c.target AS slug, coalesce(sum(o.posts), 0) AS posts, coalesce(sum(o.threads), 0) AS threads
+		FROM chain c LEFT JOIN own o ON o.slug = c.source
+		GROUP BY c.target
+	)
+	UPDATE forums f
+	SET posts = t.posts, threads = t.threads
+	FROM totals t
+	WHERE f.slug = t.slug
+	  AND (f.posts, f.threads) IS DISTINCT FROM (t.posts::int, t.threads::int);`
+
+const recountThreads = `UPDATE threads t
+	SET votes = r.votes, replies = r.replies, last_post_at = r.last_post_at, last_post_author = r.last_post_author
+	FROM (SELECT x.thread_id,
+				 coalesce(v.votes, 0)::int       AS votes,
+				 coalesce(p.replies, 0)::int     AS replies,
+				 coalesce(l.created, x.created) AS last_post_at,
+				 l.author                       AS last_post_author
+		  FROM threads x
+			  LEFT JOIN (SELECT thread_id, sum(voice) AS votes FROM user_votes GROUP BY thread_id) v
+						ON v.thread_id = x.thread_id
+			  LEFT JOIN (SELECT thread_id, count(*) AS replies FROM posts GROUP BY thread_id) p
+						ON p.thread_id = x.thread_id
+			  LEFT JOIN (SELECT DISTINCT ON (thread_id) thread_id, created, author
+						 FROM posts
+						 ORDER BY thread_id, created DESC, post_id DESC) l ON l.thread_id = x.thread_id) r
+	WHERE t.thread_id = r.thread_id
+	  AND (t.votes, t.replies, t.last_post_at, t.last_post_author)
+		IS DISTINCT FROM (r.votes, r.replies, r.last_post_at, r.last_post_author);`
+
+// recountUserForums drops the users who no longer wrote in a forum, refreshes the copied profiles and adds those
+// missing.
+var recountUserForums = []string{
+	`DELETE FROM user_forums uf
+	WHERE NOT EXISTS(SELECT 1 FROM threads t WHERE t.forum = uf.forum AND t.author = uf.nickname)
+	  AND NOT EXISTS(SELECT 1 FROM posts p WHERE p.forum = uf.forum AND p.author = uf.nickname);`,
+	`UPDATE user_forums uf
+	SET fullname = u.fullname, about = u.about, email = u.email
+	FROM users u
+	WHERE u.nickname = uf.nickname
+	  AND (uf.fullname, uf.about, uf.email) IS DISTINCT FROM (u.fullname, u.about, u.email);`,
+	`INSERT INTO user_forums (nickname, fullname, about, email, forum)
+	SELECT u.nickname, u.fullname, u.about, u.email, a.forum
+	FROM (SELECT author, forum FROM threads UNION SELECT author, forum FROM posts WHERE forum IS NOT NULL) a
+		JOIN users u ON u.nickname = a.author
+	ON CONFLICT DO NOTHING;`,
+}
+
+// clearRollups empties the rollups of the forum stats for recountRollups to rebuild them from scratch.
+var clearRollups = []string{
+	`DELETE FROM forum_depths;`,
+	`DELETE FROM thread_depths;`,
+	`DELETE FROM forum_authors;`,
+	`DELETE FROM forum_active_users;`,
+	`DELETE FROM forum_activity;`,
+}
+
+var recountRollups = []string{
+	`INSERT INTO forum_activity (forum, day, posts, threads)
+	SELECT forum, day, sum(posts), sum(threads)
+	FROM (SELECT forum, (created AT TIME ZONE 'UTC')::date AS day, 1 AS posts, 0 AS threads FROM posts WHERE forum IS NOT NULL
+		  UNION ALL
+		  SELECT forum, (created AT TIME ZONE 'UTC')::date, 0, 1 FROM threads) a
+	GROUP BY forum, day;`,
+	`INSERT INTO forum_active_users (forum, day, nickname)
+	SELECT forum, (created AT TIME ZONE 'UTC')::date, author FROM posts WHERE forum IS NOT NULL
+	UNION
+	SELECT forum, (created AT TIME ZONE 'UTC')::date, author FROM threads;`,
+	`INSERT INTO forum_authors (forum, nickname, posts, votes)
+	SELECT forum, author, sum(posts), sum(votes)
+	FROM (SELECT forum, author, 1 AS posts, 0 AS votes FROM posts WHERE forum IS NOT NULL
+		  UNION ALL
+		  SELECT forum, author, 0, votes FROM threads) a
+	GROUP BY forum, author;`,
+	`INSERT INTO thread_depths (thread_id, depth)
+	SELECT t.thread_id, coalesce(max(array_length(p.path, 1)), 0)
+	FROM threads t
+		LEFT JOIN posts p ON p.thread_id = t.thread_id
+	GROUP BY t.thread_id;`,
+	`INSERT INTO forum_depths (forum, threads, depth)
+	SELECT t.forum, count(*), sum(d.depth)
+	FROM threads t
+		JOIN thread_depths d ON d.thread_id = t.thread_id
+	GROUP BY t.forum;`,
+}
+
+// Recount recomputes from scratch what the triggers maintain. Writers are locked out meanwhile, so the counts
+// cannot drift while they are taken.
+func (s servicePostgres) Recount(ctx context.Context) (*models.RecountService, error) {
+	res := &models.RecountService{}
+
+	err := sqltools.RunTxOnConn(ctx, pkg.TxInsertOptions, s.conn.Write(ctx), func(ctx context.Context, tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `LOCK TABLE forums, threads, posts, user_votes, users IN SHARE ROW EXCLUSIVE MODE;`)
+		if err != nil {
+			return err
+		}
+
+		for _, query := range clearRollups {
+			_, err = tx.ExecContext(ctx, query)
+			if err != nil {
+				return err
+			}
+		}
+
+		steps := []struct {
+			count   *int64
+			queries []string
+		}{
+			{&res.Forums, []string{recountForums}},
+			{&res.Threads, []string{recountThreads}},
+			{&res.UserForums, recountUserForums},
+			{&res.Rollups, recountRollups},
+		}
+
+		for _, step := range steps {
+			for _, query := range step.queries {
+				var result sql.Result
+
+				result, err = tx.ExecContext(ctx, query)
+				if err != nil {
+					return err
+				}
+
+				var affected int64
+
+				affected, err = result.RowsAffected()
+				if err != nil {
+					return err
+				}
+
+				*step.count += affected
+			}
+		}
+
+		state, err := json.Marshal(res)
+		if err != nil {
+			return err
+		}
+
+		return sqltools.Audit(ctx, tx, pkg.AuditEntityService, pkg.AuditActionRecount, nil, map[string][]byte{"": state})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
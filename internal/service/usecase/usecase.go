@@ -5,22 +5,30 @@ import (
 
 	"github.com/pkg/errors"
 
+	repoConsistency "project/internal/consistency/repository"
 	"project/internal/models"
+	"project/internal/pkg/cache"
 	"project/internal/service/repository"
 )
 
 type Service interface {
 	Clear(ctx context.Context) error
 	GetStatus(ctx context.Context) (*models.StatusService, error)
+	GetCacheStats(ctx context.Context) (map[string]cache.Stats, error)
+	Recount(ctx context.Context) (*models.RecountService, error)
 }
 
 type service struct {
-	serviceRepo repository.ServiceRepository
+	serviceRepo     repository.ServiceRepository
+	consistencyRepo repoConsistency.ConsistencyRepository
+	caches          *cache.Set
 }
 
-func NewService(r repository.ServiceRepository) Service {
+func NewService(r repository.ServiceRepository, rc repoConsistency.ConsistencyRepository, caches *cache.Set) Service {
 	return &service{
-		serviceRepo: r,
+		serviceRepo:     r,
+		consistencyRepo: rc,
+		caches:          caches,
 	}
 }
 
@@ -30,6 +38,8 @@ func (s service) Clear(ctx context.Context) error {
 		return errors.Wrap(err, "Clear")
 	}
 
+	s.caches.Purge()
+
 	return err
 }
 
@@ -39,5 +49,26 @@ func (s service) GetStatus(ctx context.Context) (*models.StatusService, error) {
 		return nil, errors.Wrap(err, "GetStatus")
 	}
 
+	res.Consistency, err = s.consistencyRepo.GetLastReport(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetStatus")
+	}
+
+	return res, nil
+}
+
+func (s service) GetCacheStats(ctx context.Context) (map[string]cache.Stats, error) {
+	return s.caches.Stats(), nil
+}
+
+// Recount purges the caches of this process only, others serve their stale counters until they expire.
+func (s service) Recount(ctx context.Context) (*models.RecountService, error) {
+	res, err := s.serviceRepo.Recount(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "Recount")
+	}
+
+	s.caches.Purge()
+
 	return res, nil
 }
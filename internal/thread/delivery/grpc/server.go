@@ -0,0 +1,117 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"project/internal/models"
+	"project/internal/pb"
+	"project/internal/pkg"
+	"project/internal/pkg/grpctools"
+	"project/internal/thread/usecase"
+)
+
+const defaultLimit = 100
+
+type ThreadServer struct {
+	pb.UnimplementedThreadServiceServer
+
+	threadUsecase usecase.ThreadService
+}
+
+func (s *ThreadServer) CreateThread(ctx context.Context, req *pb.CreateThreadRequest) (*pb.Thread, error) {
+	thread, err := s.threadUsecase.CreateThread(ctx, req.GetThread().GetModel())
+	if err != nil {
+		if errors.Is(errors.Cause(err), pkg.ErrSuchThreadExist) {
+			return nil, grpctools.Error(err, pb.NewThread(&thread))
+		}
+
+		return nil, grpctools.Error(err)
+	}
+
+	return pb.NewThread(&thread), nil
+}
+
+func (s *ThreadServer) GetThread(ctx context.Context, req *pb.GetThreadRequest) (*pb.Thread, error) {
+	thread, err := s.threadUsecase.GetDetailsThread(ctx, pb.NewThreadSlugOrID(req.GetSlugOrId()))
+	if err != nil {
+		return nil, grpctools.Error(err)
+	}
+
+	return pb.NewThread(&thread), nil
+}
+
+func (s *ThreadServer) UpdateThread(ctx context.Context, req *pb.UpdateThreadRequest) (*pb.Thread, error) {
+	thread := pb.NewThreadSlugOrID(req.GetSlugOrId())
+	thread.Title = req.GetTitle()
+	thread.Message = req.GetMessage()
+	thread.Version = req.GetVersion()
+
+	res, err := s.threadUsecase.UpdateThread(ctx, thread)
+	if err != nil {
+		if errors.Is(errors.Cause(err), pkg.ErrVersionConflict) {
+			return nil, grpctools.Error(err, pb.NewThread(&res))
+		}
+
+		return nil, grpctools.Error(err)
+	}
+
+	return pb.NewThread(&res), nil
+}
+
+func (s *ThreadServer) CreatePosts(ctx context.Context, req *pb.CreatePostsRequest) (*pb.CreatePostsResponse, error) {
+	posts := make([]*models.Post, len(req.GetPosts()))
+	for idx, post := range req.GetPosts() {
+		posts[idx] = post.GetModel()
+	}
+
+	created, err := s.threadUsecase.CreatePosts(ctx, pb.NewThreadSlugOrID(req.GetSlugOrId()), posts)
+	if err != nil {
+		return nil, grpctools.Error(err)
+	}
+
+	res := &pb.CreatePostsResponse{Posts: make([]*pb.Post, len(created))}
+	for idx := range created {
+		res.Posts[idx] = pb.NewPost(&created[idx])
+	}
+
+	return res, nil
+}
+
+// StreamPosts sends the page of posts one message at a time, stopping as soon as the client goes away.
+func (s *ThreadServer) StreamPosts(req *pb.StreamPostsRequest, stream pb.ThreadService_StreamPostsServer) error {
+	params := &pkg.GetPostsParams{
+		Limit: req.GetLimit(),
+		Since: -1,
+		Desc:  req.GetDesc(),
+		Sort:  req.GetSort(),
+	}
+	if params.Limit <= 0 {
+		params.Limit = defaultLimit
+	}
+	if req.Since != nil {
+		params.Since = req.GetSince()
+	}
+	if params.Sort == "" {
+		params.Sort = pkg.TypeSortFlat
+	}
+
+	posts, err := s.threadUsecase.GetPosts(stream.Context(), pb.NewThreadSlugOrID(req.GetSlugOrId()), params)
+	if err != nil {
+		return grpctools.Error(err)
+	}
+
+	for idx := range posts {
+		err = stream.Send(pb.NewPost(&posts[idx]))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func NewThreadServer(threadUsecase usecase.ThreadService) *ThreadServer {
+	return &ThreadServer{threadUsecase: threadUsecase}
+}
@@ -0,0 +1,279 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"project/internal/models"
+	"project/internal/pb"
+	"project/internal/pkg"
+	"project/internal/pkg/grpctools"
+	"project/internal/thread/usecase"
+)
+
+// memoryThreads is a ThreadService over a map, enough for the calls the server makes.
+type memoryThreads struct {
+	usecase.ThreadService
+
+	mu      sync.Mutex
+	threads []models.Thread
+	posts   map[int64][]models.Post
+	authors []string
+}
+
+func newMemoryThreads() *memoryThreads {
+	return &memoryThreads{posts: make(map[int64][]models.Post)}
+}
+
+func (m *memoryThreads) find(thread *models.Thread) (int, error) {
+	for idx := range m.threads {
+		if thread.ID == m.threads[idx].ID || thread.Slug != "" && strings.EqualFold(thread.Slug, m.threads[idx].Slug) {
+			return idx, nil
+		}
+	}
+
+	return 0, pkg.ErrSuchThreadNotFound
+}
+
+func (m *memoryThreads) CreateThread(ctx context.Context, thread *models.Thread) (models.Thread, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.authors = append(m.authors, pkg.GetNickname(ctx))
+
+	if thread.Slug != "" {
+		idx, err := m.find(&models.Thread{Slug: thread.Slug})
+		if err == nil {
+			return m.threads[idx], pkg.ErrSuchThreadExist
+		}
+	}
+
+	res := *thread
+	res.ID = int64(len(m.threads) + 1)
+	res.Version = 1
+	m.threads = append(m.threads, res)
+
+	return res, nil
+}
+
+func (m *memoryThreads) GetDetailsThread(ctx context.Context, thread *models.Thread) (models.Thread, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	idx, err := m.find(thread)
+	if err != nil {
+		return models.Thread{}, err
+	}
+
+	return m.threads[idx], nil
+}
+
+func (m *memoryThreads) CreatePosts(ctx context.Context, thread *models.Thread, posts []*models.Post) ([]models.Post, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	idx, err := m.find(thread)
+	if err != nil {
+		return nil, err
+	}
+
+	id := m.threads[idx].ID
+
+	res := make([]models.Post, len(posts))
+	for i, post := range posts {
+		res[i] = *post
+		res[i].ID = int64(len(m.posts[id]) + 1)
+		res[i].Thread = id
+		m.posts[id] = append(m.posts[id], res[i])
+	}
+
+	return res, nil
+}
+
+func (m *memoryThreads) GetPosts(ctx context.Context, thread *models.Thread, params *pkg.GetPostsParams) ([]models.Post, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	idx, err := m.find(thread)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]models.Post, 0)
+
+	posts := m.posts[m.threads[idx].ID]
+	for i := range posts {
+		post := posts[i]
+		if params.Desc {
+			post = posts[len(posts)-1-i]
+		}
+
+		if params.Since >= 0 && (!params.Desc && post.ID <= params.Since || params.Desc && post.ID >= params.Since) {
+			continue
+		}
+
+		if int64(len(res)) == params.Limit {
+			break
+		}
+
+		res = append(res, post)
+	}
+
+	return res, nil
+}
+
+func newClient(t *testing.T, threads usecase.ThreadService) pb.ThreadServiceClient {
+	t.Helper()
+
+	server := grpctools.NewServer()
+	pb.RegisterThreadServiceServer(server, NewThreadServer(threads))
+
+	conn, err := grpctools.NewInProcess(server)
+	if err != nil {
+		t.Fatalf("NewInProcess() error = %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return pb.NewThreadServiceClient(conn.Conn)
+}
+
+func TestThreadServerCreateGet(t *testing.T) {
+	t.Setenv(pkg.EnvIdentitySecret, "")
+	t.Setenv(pkg.EnvIdentityTrusted, "1")
+
+	threads := newMemoryThreads()
+	client := newClient(t, threads)
+
+	ctx := grpctools.WithNickname(context.Background(), "alice")
+
+	created, err := client.CreateThread(ctx, &pb.CreateThreadRequest{
+		Thread: &pb.Thread{Title: "Title", Author: "alice", Forum: "go", Slug: "intro", Message: "Hello"},
+	})
+	if err != nil {
+		t.Fatalf("CreateThread() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(threads.authors, []string{"alice"}) {
+		t.Errorf("CreateThread() acted as %v, want alice", threads.authors)
+	}
+
+	tests := []struct {
+		name     string
+		slugOrID string
+		want     *pb.Thread
+		wantCode codes.Code
+	}{
+		{name: "by id", slugOrID: "1", want: created, wantCode: codes.OK},
+		{name: "by slug", slugOrID: "INTRO", want: created, wantCode: codes.OK},
+		{name: "unknown id", slugOrID: "2", wantCode: codes.NotFound},
+		{name: "unknown slug", slugOrID: "outro", wantCode: codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.GetThread(ctx, &pb.GetThreadRequest{SlugOrId: tt.slugOrID})
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("GetThread() error = %v, want code %v", err, tt.wantCode)
+			}
+
+			if tt.want != nil && !proto.Equal(got, tt.want) {
+				t.Errorf("GetThread() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	_, err = client.CreateThread(ctx, &pb.CreateThreadRequest{
+		Thread: &pb.Thread{Title: "Again", Author: "alice", Forum: "go", Slug: "intro", Message: "Hello"},
+	})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("CreateThread() of a taken slug error = %v, want code %v", err, codes.AlreadyExists)
+	}
+}
+
+func TestThreadServerStreamPosts(t *testing.T) {
+	threads := newMemoryThreads()
+	client := newClient(t, threads)
+
+	ctx := context.Background()
+
+	_, err := client.CreateThread(ctx, &pb.CreateThreadRequest{
+		Thread: &pb.Thread{Title: "Title", Author: "alice", Forum: "go", Message: "Hello"},
+	})
+	if err != nil {
+		t.Fatalf("CreateThread() error = %v", err)
+	}
+
+	_, err = client.CreatePosts(ctx, &pb.CreatePostsRequest{
+		SlugOrId: "1",
+		Posts: []*pb.Post{
+			{Author: "alice", Message: "one"},
+			{Author: "bob", Message: "two"},
+			{Author: "alice", Message: "three"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreatePosts() error = %v", err)
+	}
+
+	since := int64(1)
+
+	tests := []struct {
+		name     string
+		req      *pb.StreamPostsRequest
+		want     []int64
+		wantCode codes.Code
+	}{
+		{name: "all", req: &pb.StreamPostsRequest{SlugOrId: "1"}, want: []int64{1, 2, 3}},
+		{name: "limited", req: &pb.StreamPostsRequest{SlugOrId: "1", Limit: 2}, want: []int64{1, 2}},
+		{name: "descending", req: &pb.StreamPostsRequest{SlugOrId: "1", Desc: true}, want: []int64{3, 2, 1}},
+		{name: "since", req: &pb.StreamPostsRequest{SlugOrId: "1", Since: &since}, want: []int64{2, 3}},
+		{name: "unknown thread", req: &pb.StreamPostsRequest{SlugOrId: "9"}, wantCode: codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := client.StreamPosts(ctx, tt.req)
+			if err != nil {
+				t.Fatalf("StreamPosts() error = %v", err)
+			}
+
+			got := make([]int64, 0)
+
+			for {
+				post, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+
+				if err != nil {
+					if status.Code(err) != tt.wantCode {
+						t.Fatalf("Recv() error = %v, want code %v", err, tt.wantCode)
+					}
+
+					return
+				}
+
+				got = append(got, post.GetId())
+			}
+
+			if tt.wantCode != codes.OK {
+				t.Fatalf("StreamPosts() ended without error, want code %v", tt.wantCode)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StreamPosts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
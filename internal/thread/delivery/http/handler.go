@@ -67,6 +67,8 @@ func (h *ThreadHandler) GetThreadHandler(w http.ResponseWriter, r *http.Request)
 
 	response := models.NewThreadGetDetailsResponse(&thread)
 
+	pkg.SetValidators(w, pkg.VersionETag(thread.Modified), thread.Modified)
+
 	pkg.Response(r.Context(), w, http.StatusOK, response)
 }
 
@@ -93,12 +95,58 @@ func (h *ThreadHandler) UpdateThreadHandler(w http.ResponseWriter, r *http.Reque
 
 	thread, err := h.threadUsecase.UpdateThread(r.Context(), request.GetThread())
 	if err != nil {
+		if errors.Is(errors.Cause(err), pkg.ErrVersionConflict) {
+			response := models.NewThreadUpdateDetailsResponse(&thread)
+
+			pkg.Response(r.Context(), w, http.StatusConflict, response)
+
+			return
+		}
+
 		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
 		return
 	}
 
 	response := models.NewThreadUpdateDetailsResponse(&thread)
 
+	pkg.SetValidators(w, pkg.VersionETag(thread.Modified), thread.Modified)
+
+	pkg.Response(r.Context(), w, http.StatusOK, response)
+}
+
+func (h *ThreadHandler) GetTrendingHandler(w http.ResponseWriter, r *http.Request) {
+	request := models.NewThreadGetTrendingRequest()
+
+	err := request.Bind(r)
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	threads, err := h.threadUsecase.GetTrending(r.Context(), request.Limit)
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	response := models.NewThreadGetTrendingResponse(threads)
+
+	pkg.Response(r.Context(), w, http.StatusOK, response)
+}
+
+func (h *ThreadHandler) MarkReadHandler(w http.ResponseWriter, r *http.Request) {
+	request := models.NewThreadGetDetailsRequest()
+
+	request.Bind(r)
+
+	read, err := h.threadUsecase.MarkRead(r.Context(), request.GetThread())
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	response := models.NewThreadMarkReadResponse(&read)
+
 	pkg.Response(r.Context(), w, http.StatusOK, response)
 }
 
@@ -8,6 +8,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/mailru/easyjson"
 
+	attachmentModels "project/internal/attachment/delivery/models"
 	"project/internal/models"
 )
 
@@ -15,9 +16,10 @@ import (
 
 //easyjson:json
 type PostRequest struct {
-	Parent  int64  `json:"parent"`
-	Author  string `json:"author"`
-	Message string `json:"message"`
+	Parent      int64   `json:"parent"`
+	Author      string  `json:"author"`
+	Message     string  `json:"message"`
+	Attachments []int64 `json:"attachments"`
 }
 
 //easyjson:json
@@ -80,6 +82,10 @@ func (req *ThreadCreatePostsRequest) GetPosts() []*models.Post {
 				Nickname: value.Author,
 			},
 		}
+
+		for _, id := range value.Attachments {
+			res[idx].Attachments = append(res[idx].Attachments, models.Attachment{ID: id})
+		}
 	}
 
 	return res
@@ -100,14 +106,15 @@ func (req *ThreadCreatePostsRequest) GetThread() *models.Thread {
 
 //easyjson:json
 type PostResponse struct {
-	ID       int64  `json:"id"`
-	Parent   int64  `json:"parent"`
-	Author   string `json:"author"`
-	Message  string `json:"message"`
-	IsEdited bool   `json:"isEdited"`
-	Forum    string `json:"forum"`
-	Thread   int64  `json:"thread"`
-	Created  string `json:"created"`
+	ID          int64                            `json:"id"`
+	Parent      int64                            `json:"parent"`
+	Author      string                           `json:"author"`
+	Message     string                           `json:"message"`
+	IsEdited    bool                             `json:"isEdited"`
+	Forum       string                           `json:"forum"`
+	Thread      int64                            `json:"thread"`
+	Created     string                           `json:"created"`
+	Attachments attachmentModels.AttachmentsList `json:"attachments,omitempty"`
 }
 
 //easyjson:json
@@ -126,6 +133,8 @@ func NewThreadCreatePostsResponse(posts []models.Post) PostsResponseList {
 			Message:  value.Message,
 			Created:  value.Created,
 			Thread:   value.Thread,
+
+			Attachments: attachmentModels.NewAttachmentsList(value.Attachments),
 		}
 	}
 
@@ -17,7 +17,7 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjson16fc04efDecodeDbPerformanceProjectInternalThreadDeliveryModels(in *jlexer.Lexer, out *PostsResponseList) {
+func easyjson16fc04efDecodeProjectInternalThreadDeliveryModels(in *jlexer.Lexer, out *PostsResponseList) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		in.Skip()
@@ -45,7 +45,7 @@ func easyjson16fc04efDecodeDbPerformanceProjectInternalThreadDeliveryModels(in *
 		in.Consumed()
 	}
 }
-func easyjson16fc04efEncodeDbPerformanceProjectInternalThreadDeliveryModels(out *jwriter.Writer, in PostsResponseList) {
+func easyjson16fc04efEncodeProjectInternalThreadDeliveryModels(out *jwriter.Writer, in PostsResponseList) {
 	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 		out.RawString("null")
 	} else {
@@ -63,27 +63,27 @@ func easyjson16fc04efEncodeDbPerformanceProjectInternalThreadDeliveryModels(out
 // MarshalJSON supports json.Marshaler interface
 func (v PostsResponseList) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson16fc04efEncodeDbPerformanceProjectInternalThreadDeliveryModels(&w, v)
+	easyjson16fc04efEncodeProjectInternalThreadDeliveryModels(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v PostsResponseList) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson16fc04efEncodeDbPerformanceProjectInternalThreadDeliveryModels(w, v)
+	easyjson16fc04efEncodeProjectInternalThreadDeliveryModels(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *PostsResponseList) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson16fc04efDecodeDbPerformanceProjectInternalThreadDeliveryModels(&r, v)
+	easyjson16fc04efDecodeProjectInternalThreadDeliveryModels(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *PostsResponseList) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson16fc04efDecodeDbPerformanceProjectInternalThreadDeliveryModels(l, v)
+	easyjson16fc04efDecodeProjectInternalThreadDeliveryModels(l, v)
 }
-func easyjson16fc04efDecodeDbPerformanceProjectInternalThreadDeliveryModels1(in *jlexer.Lexer, out *PostsRequestList) {
+func easyjson16fc04efDecodeProjectInternalThreadDeliveryModels1(in *jlexer.Lexer, out *PostsRequestList) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		in.Skip()
@@ -111,7 +111,7 @@ func easyjson16fc04efDecodeDbPerformanceProjectInternalThreadDeliveryModels1(in
 		in.Consumed()
 	}
 }
-func easyjson16fc04efEncodeDbPerformanceProjectInternalThreadDeliveryModels1(out *jwriter.Writer, in PostsRequestList) {
+func easyjson16fc04efEncodeProjectInternalThreadDeliveryModels1(out *jwriter.Writer, in PostsRequestList) {
 	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 		out.RawString("null")
 	} else {
@@ -129,27 +129,27 @@ func easyjson16fc04efEncodeDbPerformanceProjectInternalThreadDeliveryModels1(out
 // MarshalJSON supports json.Marshaler interface
 func (v PostsRequestList) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson16fc04efEncodeDbPerformanceProjectInternalThreadDeliveryModels1(&w, v)
+	easyjson16fc04efEncodeProjectInternalThreadDeliveryModels1(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v PostsRequestList) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson16fc04efEncodeDbPerformanceProjectInternalThreadDeliveryModels1(w, v)
+	easyjson16fc04efEncodeProjectInternalThreadDeliveryModels1(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *PostsRequestList) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson16fc04efDecodeDbPerformanceProjectInternalThreadDeliveryModels1(&r, v)
+	easyjson16fc04efDecodeProjectInternalThreadDeliveryModels1(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *PostsRequestList) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson16fc04efDecodeDbPerformanceProjectInternalThreadDeliveryModels1(l, v)
+	easyjson16fc04efDecodeProjectInternalThreadDeliveryModels1(l, v)
 }
-func easyjson16fc04efDecodeDbPerformanceProjectInternalThreadDeliveryModels2(in *jlexer.Lexer, out *PostResponse) {
+func easyjson16fc04efDecodeProjectInternalThreadDeliveryModels2(in *jlexer.Lexer, out *PostResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -184,6 +184,8 @@ func easyjson16fc04efDecodeDbPerformanceProjectInternalThreadDeliveryModels2(in
 			out.Thread = int64(in.Int64())
 		case "created":
 			out.Created = string(in.String())
+		case "attachments":
+			(out.Attachments).UnmarshalEasyJSON(in)
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -198,7 +200,7 @@ func easyjson16fc04efDecodeDbPerformanceProjectInternalThreadDeliveryModels2(in
 		in.Consumed()
 	}
 }
-func easyjson16fc04efEncodeDbPerformanceProjectInternalThreadDeliveryModels2(out *jwriter.Writer, in PostResponse) {
+func easyjson16fc04efEncodeProjectInternalThreadDeliveryModels2(out *jwriter.Writer, in PostResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -278,33 +280,43 @@ func easyjson16fc04efEncodeDbPerformanceProjectInternalThreadDeliveryModels2(out
 		}
 		out.String(string(in.Created))
 	}
+	if len(in.Attachments) != 0 {
+		const prefix string = ",\"attachments\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		(in.Attachments).MarshalEasyJSON(out)
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v PostResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson16fc04efEncodeDbPerformanceProjectInternalThreadDeliveryModels2(&w, v)
+	easyjson16fc04efEncodeProjectInternalThreadDeliveryModels2(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v PostResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson16fc04efEncodeDbPerformanceProjectInternalThreadDeliveryModels2(w, v)
+	easyjson16fc04efEncodeProjectInternalThreadDeliveryModels2(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *PostResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson16fc04efDecodeDbPerformanceProjectInternalThreadDeliveryModels2(&r, v)
+	easyjson16fc04efDecodeProjectInternalThreadDeliveryModels2(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *PostResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson16fc04efDecodeDbPerformanceProjectInternalThreadDeliveryModels2(l, v)
+	easyjson16fc04efDecodeProjectInternalThreadDeliveryModels2(l, v)
 }
-func easyjson16fc04efDecodeDbPerformanceProjectInternalThreadDeliveryModels3(in *jlexer.Lexer, out *PostRequest) {
+func easyjson16fc04efDecodeProjectInternalThreadDeliveryModels3(in *jlexer.Lexer, out *PostRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -329,6 +341,29 @@ func easyjson16fc04efDecodeDbPerformanceProjectInternalThreadDeliveryModels3(in
 			out.Author = string(in.String())
 		case "message":
 			out.Message = string(in.String())
+		case "attachments":
+			if in.IsNull() {
+				in.Skip()
+				out.Attachments = nil
+			} else {
+				in.Delim('[')
+				if out.Attachments == nil {
+					if !in.IsDelim(']') {
+						out.Attachments = make([]int64, 0, 8)
+					} else {
+						out.Attachments = []int64{}
+					}
+				} else {
+					out.Attachments = (out.Attachments)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 int64
+					v7 = int64(in.Int64())
+					out.Attachments = append(out.Attachments, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -343,7 +378,7 @@ func easyjson16fc04efDecodeDbPerformanceProjectInternalThreadDeliveryModels3(in
 		in.Consumed()
 	}
 }
-func easyjson16fc04efEncodeDbPerformanceProjectInternalThreadDeliveryModels3(out *jwriter.Writer, in PostRequest) {
+func easyjson16fc04efEncodeProjectInternalThreadDeliveryModels3(out *jwriter.Writer, in PostRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -373,29 +408,48 @@ func easyjson16fc04efEncodeDbPerformanceProjectInternalThreadDeliveryModels3(out
 		}
 		out.String(string(in.Message))
 	}
+	if len(in.Attachments) != 0 {
+		const prefix string = ",\"attachments\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v8, v9 := range in.Attachments {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				out.Int64(int64(v9))
+			}
+			out.RawByte(']')
+		}
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v PostRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson16fc04efEncodeDbPerformanceProjectInternalThreadDeliveryModels3(&w, v)
+	easyjson16fc04efEncodeProjectInternalThreadDeliveryModels3(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v PostRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson16fc04efEncodeDbPerformanceProjectInternalThreadDeliveryModels3(w, v)
+	easyjson16fc04efEncodeProjectInternalThreadDeliveryModels3(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *PostRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson16fc04efDecodeDbPerformanceProjectInternalThreadDeliveryModels3(&r, v)
+	easyjson16fc04efDecodeProjectInternalThreadDeliveryModels3(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *PostRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson16fc04efDecodeDbPerformanceProjectInternalThreadDeliveryModels3(l, v)
+	easyjson16fc04efDecodeProjectInternalThreadDeliveryModels3(l, v)
 }
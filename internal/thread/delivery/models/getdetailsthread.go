@@ -54,6 +54,7 @@ type ThreadGetDetailsResponse struct {
 	Message string `json:"message"`
 	Created string `json:"created"`
 	Votes   int64  `json:"votes"`
+	Version int64  `json:"version,omitempty"`
 }
 
 func NewThreadGetDetailsResponse(thread *models.Thread) *ThreadGetDetailsResponse {
@@ -66,5 +67,6 @@ func NewThreadGetDetailsResponse(thread *models.Thread) *ThreadGetDetailsRespons
 		Message: thread.Message,
 		Created: thread.Created,
 		Votes:   thread.Votes,
+		Version: thread.Version,
 	}
 }
@@ -17,7 +17,7 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjson2c177166DecodeDbPerformanceProjectInternalThreadDeliveryModels(in *jlexer.Lexer, out *ThreadGetDetailsResponse) {
+func easyjson2c177166DecodeProjectInternalThreadDeliveryModels(in *jlexer.Lexer, out *ThreadGetDetailsResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -52,6 +52,8 @@ func easyjson2c177166DecodeDbPerformanceProjectInternalThreadDeliveryModels(in *
 			out.Created = string(in.String())
 		case "votes":
 			out.Votes = int64(in.Int64())
+		case "version":
+			out.Version = int64(in.Int64())
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -66,7 +68,7 @@ func easyjson2c177166DecodeDbPerformanceProjectInternalThreadDeliveryModels(in *
 		in.Consumed()
 	}
 }
-func easyjson2c177166EncodeDbPerformanceProjectInternalThreadDeliveryModels(out *jwriter.Writer, in ThreadGetDetailsResponse) {
+func easyjson2c177166EncodeProjectInternalThreadDeliveryModels(out *jwriter.Writer, in ThreadGetDetailsResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -146,29 +148,39 @@ func easyjson2c177166EncodeDbPerformanceProjectInternalThreadDeliveryModels(out
 		}
 		out.Int64(int64(in.Votes))
 	}
+	if in.Version != 0 {
+		const prefix string = ",\"version\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Version))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v ThreadGetDetailsResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson2c177166EncodeDbPerformanceProjectInternalThreadDeliveryModels(&w, v)
+	easyjson2c177166EncodeProjectInternalThreadDeliveryModels(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ThreadGetDetailsResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson2c177166EncodeDbPerformanceProjectInternalThreadDeliveryModels(w, v)
+	easyjson2c177166EncodeProjectInternalThreadDeliveryModels(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ThreadGetDetailsResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson2c177166DecodeDbPerformanceProjectInternalThreadDeliveryModels(&r, v)
+	easyjson2c177166DecodeProjectInternalThreadDeliveryModels(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ThreadGetDetailsResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson2c177166DecodeDbPerformanceProjectInternalThreadDeliveryModels(l, v)
+	easyjson2c177166DecodeProjectInternalThreadDeliveryModels(l, v)
 }
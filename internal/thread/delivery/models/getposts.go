@@ -6,6 +6,7 @@ import (
 
 	"github.com/gorilla/mux"
 
+	attachmentModels "project/internal/attachment/delivery/models"
 	"project/internal/models"
 	"project/internal/pkg"
 )
@@ -113,14 +114,15 @@ func (req *ThreadGetPostsRequest) GetParams() *pkg.GetPostsParams {
 
 //easyjson:json
 type ThreadGetPostsResponse struct {
-	ID       int64  `json:"id"`
-	Parent   int64  `json:"parent"`
-	Author   string `json:"author"`
-	Message  string `json:"message"`
-	IsEdited bool   `json:"isEdited"`
-	Forum    string `json:"forum"`
-	Thread   int64  `json:"thread"`
-	Created  string `json:"created"`
+	ID          int64                            `json:"id"`
+	Parent      int64                            `json:"parent"`
+	Author      string                           `json:"author"`
+	Message     string                           `json:"message"`
+	IsEdited    bool                             `json:"isEdited"`
+	Forum       string                           `json:"forum"`
+	Thread      int64                            `json:"thread"`
+	Created     string                           `json:"created"`
+	Attachments attachmentModels.AttachmentsList `json:"attachments,omitempty"`
 }
 
 //easyjson:json
@@ -139,6 +141,8 @@ func NewThreadGetPostsResponse(posts []models.Post) PostsList {
 			Message:  value.Message,
 			Created:  value.Created,
 			IsEdited: value.IsEdited,
+
+			Attachments: attachmentModels.NewAttachmentsList(value.Attachments),
 		}
 	}
 
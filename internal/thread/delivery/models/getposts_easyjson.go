@@ -17,7 +17,7 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjsonA5c41979DecodeDbPerformanceProjectInternalThreadDeliveryModels(in *jlexer.Lexer, out *ThreadGetPostsResponse) {
+func easyjsonA5c41979DecodeProjectInternalThreadDeliveryModels(in *jlexer.Lexer, out *ThreadGetPostsResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -52,6 +52,8 @@ func easyjsonA5c41979DecodeDbPerformanceProjectInternalThreadDeliveryModels(in *
 			out.Thread = int64(in.Int64())
 		case "created":
 			out.Created = string(in.String())
+		case "attachments":
+			(out.Attachments).UnmarshalEasyJSON(in)
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -66,7 +68,7 @@ func easyjsonA5c41979DecodeDbPerformanceProjectInternalThreadDeliveryModels(in *
 		in.Consumed()
 	}
 }
-func easyjsonA5c41979EncodeDbPerformanceProjectInternalThreadDeliveryModels(out *jwriter.Writer, in ThreadGetPostsResponse) {
+func easyjsonA5c41979EncodeProjectInternalThreadDeliveryModels(out *jwriter.Writer, in ThreadGetPostsResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -146,33 +148,43 @@ func easyjsonA5c41979EncodeDbPerformanceProjectInternalThreadDeliveryModels(out
 		}
 		out.String(string(in.Created))
 	}
+	if len(in.Attachments) != 0 {
+		const prefix string = ",\"attachments\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		(in.Attachments).MarshalEasyJSON(out)
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v ThreadGetPostsResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonA5c41979EncodeDbPerformanceProjectInternalThreadDeliveryModels(&w, v)
+	easyjsonA5c41979EncodeProjectInternalThreadDeliveryModels(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ThreadGetPostsResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonA5c41979EncodeDbPerformanceProjectInternalThreadDeliveryModels(w, v)
+	easyjsonA5c41979EncodeProjectInternalThreadDeliveryModels(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ThreadGetPostsResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonA5c41979DecodeDbPerformanceProjectInternalThreadDeliveryModels(&r, v)
+	easyjsonA5c41979DecodeProjectInternalThreadDeliveryModels(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ThreadGetPostsResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonA5c41979DecodeDbPerformanceProjectInternalThreadDeliveryModels(l, v)
+	easyjsonA5c41979DecodeProjectInternalThreadDeliveryModels(l, v)
 }
-func easyjsonA5c41979DecodeDbPerformanceProjectInternalThreadDeliveryModels1(in *jlexer.Lexer, out *PostsList) {
+func easyjsonA5c41979DecodeProjectInternalThreadDeliveryModels1(in *jlexer.Lexer, out *PostsList) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		in.Skip()
@@ -200,7 +212,7 @@ func easyjsonA5c41979DecodeDbPerformanceProjectInternalThreadDeliveryModels1(in
 		in.Consumed()
 	}
 }
-func easyjsonA5c41979EncodeDbPerformanceProjectInternalThreadDeliveryModels1(out *jwriter.Writer, in PostsList) {
+func easyjsonA5c41979EncodeProjectInternalThreadDeliveryModels1(out *jwriter.Writer, in PostsList) {
 	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 		out.RawString("null")
 	} else {
@@ -218,23 +230,23 @@ func easyjsonA5c41979EncodeDbPerformanceProjectInternalThreadDeliveryModels1(out
 // MarshalJSON supports json.Marshaler interface
 func (v PostsList) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonA5c41979EncodeDbPerformanceProjectInternalThreadDeliveryModels1(&w, v)
+	easyjsonA5c41979EncodeProjectInternalThreadDeliveryModels1(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v PostsList) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonA5c41979EncodeDbPerformanceProjectInternalThreadDeliveryModels1(w, v)
+	easyjsonA5c41979EncodeProjectInternalThreadDeliveryModels1(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *PostsList) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonA5c41979DecodeDbPerformanceProjectInternalThreadDeliveryModels1(&r, v)
+	easyjsonA5c41979DecodeProjectInternalThreadDeliveryModels1(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *PostsList) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonA5c41979DecodeDbPerformanceProjectInternalThreadDeliveryModels1(l, v)
+	easyjsonA5c41979DecodeProjectInternalThreadDeliveryModels1(l, v)
 }
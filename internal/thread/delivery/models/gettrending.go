@@ -0,0 +1,79 @@
+package models
+
+import (
+	"net/http"
+	"strconv"
+
+	"project/internal/models"
+	"project/internal/pkg"
+)
+
+//go:generate easyjson -disallow_unknown_fields -omit_empty gettrending.go
+
+type ThreadGetTrendingRequest struct {
+	Limit int64
+}
+
+func NewThreadGetTrendingRequest() *ThreadGetTrendingRequest {
+	return &ThreadGetTrendingRequest{}
+}
+
+func (req *ThreadGetTrendingRequest) Bind(r *http.Request) error {
+	req.Limit = 100
+
+	param := r.FormValue("limit")
+	if param != "" {
+		var err error
+
+		req.Limit, err = strconv.ParseInt(param, 10, 64)
+		if err != nil {
+			return pkg.ErrConvertQueryType
+		}
+	}
+
+	return nil
+}
+
+//easyjson:json
+type ThreadGetTrendingResponse struct {
+	ID      int64  `json:"id"`
+	Title   string `json:"title"`
+	Author  string `json:"author"`
+	Forum   string `json:"forum"`
+	Slug    string `json:"slug"`
+	Message string `json:"message"`
+	Created string `json:"created"`
+	Votes   int64  `json:"votes"`
+
+	LastPostAt     string `json:"lastPostAt,omitempty"`
+	LastPostAuthor string `json:"lastPostAuthor,omitempty"`
+	Replies        int64  `json:"replies,omitempty"`
+	UnreadCount    int64  `json:"unreadCount,omitempty"`
+}
+
+//easyjson:json
+type TrendingList []ThreadGetTrendingResponse
+
+func NewThreadGetTrendingResponse(threads []models.Thread) TrendingList {
+	res := make([]ThreadGetTrendingResponse, len(threads))
+
+	for idx, value := range threads {
+		res[idx] = ThreadGetTrendingResponse{
+			ID:      value.ID,
+			Title:   value.Title,
+			Author:  value.Author,
+			Forum:   value.Forum,
+			Slug:    value.Slug,
+			Message: value.Message,
+			Created: value.Created,
+			Votes:   value.Votes,
+
+			LastPostAt:     value.LastPostAt,
+			LastPostAuthor: value.LastPostAuthor,
+			Replies:        value.Replies,
+			UnreadCount:    value.Unread,
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"project/internal/models"
+)
+
+//go:generate easyjson -disallow_unknown_fields -omit_empty markread.go
+
+//easyjson:json
+type ThreadMarkReadResponse struct {
+	Thread   int64  `json:"thread"`
+	Nickname string `json:"nickname"`
+	Replies  int64  `json:"replies"`
+	Updated  string `json:"updated"`
+}
+
+func NewThreadMarkReadResponse(read *models.ThreadRead) *ThreadMarkReadResponse {
+	return &ThreadMarkReadResponse{
+		Thread:   read.Thread,
+		Nickname: read.Nickname,
+		Replies:  read.Replies,
+		Updated:  read.Updated,
+	}
+}
@@ -0,0 +1,126 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package models
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson4ae484f3DecodeProjectInternalThreadDeliveryModels(in *jlexer.Lexer, out *ThreadMarkReadResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "thread":
+			out.Thread = int64(in.Int64())
+		case "nickname":
+			out.Nickname = string(in.String())
+		case "replies":
+			out.Replies = int64(in.Int64())
+		case "updated":
+			out.Updated = string(in.String())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4ae484f3EncodeProjectInternalThreadDeliveryModels(out *jwriter.Writer, in ThreadMarkReadResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Thread != 0 {
+		const prefix string = ",\"thread\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.Thread))
+	}
+	if in.Nickname != "" {
+		const prefix string = ",\"nickname\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Nickname))
+	}
+	if in.Replies != 0 {
+		const prefix string = ",\"replies\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Replies))
+	}
+	if in.Updated != "" {
+		const prefix string = ",\"updated\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Updated))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ThreadMarkReadResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4ae484f3EncodeProjectInternalThreadDeliveryModels(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ThreadMarkReadResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4ae484f3EncodeProjectInternalThreadDeliveryModels(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ThreadMarkReadResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4ae484f3DecodeProjectInternalThreadDeliveryModels(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ThreadMarkReadResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4ae484f3DecodeProjectInternalThreadDeliveryModels(l, v)
+}
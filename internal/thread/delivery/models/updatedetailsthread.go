@@ -17,6 +17,7 @@ type ThreadUpdateDetailsRequest struct {
 	SlugOrID string
 	Title    string `json:"title"`
 	Message  string `json:"message"`
+	Version  int64  `json:"version"`
 }
 
 func NewThreadUpdateDetailsRequest() *ThreadUpdateDetailsRequest {
@@ -67,6 +68,7 @@ func (req *ThreadUpdateDetailsRequest) GetThread() *models.Thread {
 			ID:      int64(id),
 			Message: req.Message,
 			Title:   req.Title,
+			Version: req.Version,
 		}
 	}
 
@@ -74,6 +76,7 @@ func (req *ThreadUpdateDetailsRequest) GetThread() *models.Thread {
 		Slug:    req.SlugOrID,
 		Message: req.Message,
 		Title:   req.Title,
+		Version: req.Version,
 	}
 }
 
@@ -87,6 +90,7 @@ type ThreadUpdateDetailsResponse struct {
 	Message string `json:"message"`
 	Created string `json:"created"`
 	Votes   int64  `json:"votes"`
+	Version int64  `json:"version,omitempty"`
 }
 
 func NewThreadUpdateDetailsResponse(thread *models.Thread) *ThreadUpdateDetailsResponse {
@@ -99,5 +103,6 @@ func NewThreadUpdateDetailsResponse(thread *models.Thread) *ThreadUpdateDetailsR
 		Message: thread.Message,
 		Created: thread.Created,
 		Votes:   thread.Votes,
+		Version: thread.Version,
 	}
 }
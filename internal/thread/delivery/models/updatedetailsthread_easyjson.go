@@ -17,7 +17,7 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjson25f0fa7dDecodeDbPerformanceProjectInternalThreadDeliveryModels(in *jlexer.Lexer, out *ThreadUpdateDetailsResponse) {
+func easyjson25f0fa7dDecodeProjectInternalThreadDeliveryModels(in *jlexer.Lexer, out *ThreadUpdateDetailsResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -52,6 +52,8 @@ func easyjson25f0fa7dDecodeDbPerformanceProjectInternalThreadDeliveryModels(in *
 			out.Created = string(in.String())
 		case "votes":
 			out.Votes = int64(in.Int64())
+		case "version":
+			out.Version = int64(in.Int64())
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -66,7 +68,7 @@ func easyjson25f0fa7dDecodeDbPerformanceProjectInternalThreadDeliveryModels(in *
 		in.Consumed()
 	}
 }
-func easyjson25f0fa7dEncodeDbPerformanceProjectInternalThreadDeliveryModels(out *jwriter.Writer, in ThreadUpdateDetailsResponse) {
+func easyjson25f0fa7dEncodeProjectInternalThreadDeliveryModels(out *jwriter.Writer, in ThreadUpdateDetailsResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -146,33 +148,43 @@ func easyjson25f0fa7dEncodeDbPerformanceProjectInternalThreadDeliveryModels(out
 		}
 		out.Int64(int64(in.Votes))
 	}
+	if in.Version != 0 {
+		const prefix string = ",\"version\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Version))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v ThreadUpdateDetailsResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson25f0fa7dEncodeDbPerformanceProjectInternalThreadDeliveryModels(&w, v)
+	easyjson25f0fa7dEncodeProjectInternalThreadDeliveryModels(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ThreadUpdateDetailsResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson25f0fa7dEncodeDbPerformanceProjectInternalThreadDeliveryModels(w, v)
+	easyjson25f0fa7dEncodeProjectInternalThreadDeliveryModels(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ThreadUpdateDetailsResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson25f0fa7dDecodeDbPerformanceProjectInternalThreadDeliveryModels(&r, v)
+	easyjson25f0fa7dDecodeProjectInternalThreadDeliveryModels(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ThreadUpdateDetailsResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson25f0fa7dDecodeDbPerformanceProjectInternalThreadDeliveryModels(l, v)
+	easyjson25f0fa7dDecodeProjectInternalThreadDeliveryModels(l, v)
 }
-func easyjson25f0fa7dDecodeDbPerformanceProjectInternalThreadDeliveryModels1(in *jlexer.Lexer, out *ThreadUpdateDetailsRequest) {
+func easyjson25f0fa7dDecodeProjectInternalThreadDeliveryModels1(in *jlexer.Lexer, out *ThreadUpdateDetailsRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -197,6 +209,8 @@ func easyjson25f0fa7dDecodeDbPerformanceProjectInternalThreadDeliveryModels1(in
 			out.Title = string(in.String())
 		case "message":
 			out.Message = string(in.String())
+		case "version":
+			out.Version = int64(in.Int64())
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -211,7 +225,7 @@ func easyjson25f0fa7dDecodeDbPerformanceProjectInternalThreadDeliveryModels1(in
 		in.Consumed()
 	}
 }
-func easyjson25f0fa7dEncodeDbPerformanceProjectInternalThreadDeliveryModels1(out *jwriter.Writer, in ThreadUpdateDetailsRequest) {
+func easyjson25f0fa7dEncodeProjectInternalThreadDeliveryModels1(out *jwriter.Writer, in ThreadUpdateDetailsRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -241,29 +255,39 @@ func easyjson25f0fa7dEncodeDbPerformanceProjectInternalThreadDeliveryModels1(out
 		}
 		out.String(string(in.Message))
 	}
+	if in.Version != 0 {
+		const prefix string = ",\"version\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Version))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v ThreadUpdateDetailsRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson25f0fa7dEncodeDbPerformanceProjectInternalThreadDeliveryModels1(&w, v)
+	easyjson25f0fa7dEncodeProjectInternalThreadDeliveryModels1(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ThreadUpdateDetailsRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson25f0fa7dEncodeDbPerformanceProjectInternalThreadDeliveryModels1(w, v)
+	easyjson25f0fa7dEncodeProjectInternalThreadDeliveryModels1(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ThreadUpdateDetailsRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson25f0fa7dDecodeDbPerformanceProjectInternalThreadDeliveryModels1(&r, v)
+	easyjson25f0fa7dDecodeProjectInternalThreadDeliveryModels1(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ThreadUpdateDetailsRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson25f0fa7dDecodeDbPerformanceProjectInternalThreadDeliveryModels1(l, v)
+	easyjson25f0fa7dDecodeProjectInternalThreadDeliveryModels1(l, v)
 }
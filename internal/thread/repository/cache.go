@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	forumRepo "project/internal/forum/repository"
+	"project/internal/models"
+	"project/internal/pkg"
+	"project/internal/pkg/cache"
+)
+
+// Invalidator drops cached threads whose votes were changed by writes of other repositories.
+type Invalidator interface {
+	InvalidateThread(ctx context.Context, thread *models.Thread)
+}
+
+// ThreadCache serves the details of threads from the cache, each thread being kept both by id and by slug. New
+// threads and posts change the counters of their forum, so they invalidate it. Reads which must see the latest
+// state skip the cache. Misses are read from the primary: a lagging replica would put back what was invalidated.
+type ThreadCache struct {
+	ThreadRepository
+
+	threads cache.Cache[models.Thread]
+	forums  forumRepo.Invalidator
+}
+
+func NewThreadCache(repo ThreadRepository, threads cache.Cache[models.Thread], forums forumRepo.Invalidator) *ThreadCache {
+	return &ThreadCache{
+		ThreadRepository: repo,
+		threads:          threads,
+		forums:           forums,
+	}
+}
+
+func threadKeyID(id int64) string {
+	return "id:" + strconv.FormatInt(id, 10)
+}
+
+func threadKeySlug(slug string) string {
+	return "slug:" + strings.ToLower(slug)
+}
+
+func (t *ThreadCache) set(thread *models.Thread) {
+	t.threads.Set(threadKeyID(thread.ID), *thread)
+
+	if thread.Slug != "" {
+		t.threads.Set(threadKeySlug(thread.Slug), *thread)
+	}
+}
+
+func (t *ThreadCache) CreateThread(ctx context.Context, thread *models.Thread) (models.Thread, error) {
+	res, err := t.ThreadRepository.CreateThread(ctx, thread)
+	if err != nil {
+		return res, err
+	}
+
+	t.forums.InvalidateForum(ctx, res.Forum)
+
+	return res, nil
+}
+
+func (t *ThreadCache) CreatePostsByID(ctx context.Context, thread *models.Thread, posts []*models.Post) ([]models.Post, error) {
+	res, err := t.ThreadRepository.CreatePostsByID(ctx, thread, posts)
+	if err != nil {
+		return res, err
+	}
+
+	if len(res) != 0 {
+		t.forums.InvalidateForum(ctx, thread.Forum)
+	}
+
+	return res, nil
+}
+
+func (t *ThreadCache) GetDetailsThreadByID(ctx context.Context, thread *models.Thread) (models.Thread, error) {
+	if !pkg.ReadsPrimary(ctx) {
+		cached, ok := t.threads.Get(threadKeyID(thread.ID))
+		if ok {
+			return cached, nil
+		}
+	}
+
+	res, err := t.ThreadRepository.GetDetailsThreadByID(pkg.WithPrimaryReads(ctx), thread)
+	if err != nil {
+		return res, err
+	}
+
+	t.set(&res)
+
+	return res, nil
+}
+
+func (t *ThreadCache) GetDetailsThreadBySlug(ctx context.Context, thread *models.Thread) (models.Thread, error) {
+	if !pkg.ReadsPrimary(ctx) {
+		cached, ok := t.threads.Get(threadKeySlug(thread.Slug))
+		if ok {
+			return cached, nil
+		}
+	}
+
+	res, err := t.ThreadRepository.GetDetailsThreadBySlug(pkg.WithPrimaryReads(ctx), thread)
+	if err != nil {
+		return res, err
+	}
+
+	t.set(&res)
+
+	return res, nil
+}
+
+func (t *ThreadCache) UpdateThreadByID(ctx context.Context, thread *models.Thread, versions []time.Time) (models.Thread, error) {
+	res, err := t.ThreadRepository.UpdateThreadByID(ctx, thread, versions)
+	if err != nil {
+		return res, err
+	}
+
+	t.InvalidateThread(ctx, &res)
+
+	return res, nil
+}
+
+// InvalidateThread drops both keys of the thread, so it needs the slug when the thread has one.
+func (t *ThreadCache) InvalidateThread(ctx context.Context, thread *models.Thread) {
+	t.threads.Delete(threadKeyID(thread.ID))
+
+	if thread.Slug != "" {
+		t.threads.Delete(threadKeySlug(thread.Slug))
+	}
+}
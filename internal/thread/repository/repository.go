@@ -4,11 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 
+	repoAttachment "project/internal/attachment/repository"
 	"project/internal/models"
 	"project/internal/pkg"
 	"project/internal/pkg/sqltools"
@@ -19,28 +22,33 @@ type ThreadRepository interface {
 	CreatePostsByID(ctx context.Context, thread *models.Thread, posts []*models.Post) ([]models.Post, error)
 	GetDetailsThreadByID(ctx context.Context, thread *models.Thread) (models.Thread, error)
 	GetDetailsThreadBySlug(ctx context.Context, thread *models.Thread) (models.Thread, error)
-	UpdateThreadByID(ctx context.Context, thread *models.Thread) (models.Thread, error)
+	UpdateThreadByID(ctx context.Context, thread *models.Thread, versions []time.Time) (models.Thread, error)
 	GetPostsByIDFlat(ctx context.Context, thread *models.Thread, params *pkg.GetPostsParams) ([]models.Post, error)
 	GetPostsByIDTree(ctx context.Context, thread *models.Thread, params *pkg.GetPostsParams) ([]models.Post, error)
 	GetPostsByIDParentTree(ctx context.Context, thread *models.Thread, params *pkg.GetPostsParams) ([]models.Post, error)
+	GetTrendingThreads(ctx context.Context, limit int64, nickname string) ([]models.Thread, error)
+	MarkReadByID(ctx context.Context, thread *models.Thread, nickname string) (models.ThreadRead, error)
 }
 
 type threadPostgres struct {
-	conn *sql.DB
+	conn *sqltools.Cluster
 }
 
-func NewThreadPostgres(conn *sql.DB) ThreadRepository {
+func NewThreadPostgres(conn *sqltools.Cluster) ThreadRepository {
 	return &threadPostgres{
 		conn,
 	}
 }
 
+// threadSnapshot selects the state of the thread with the ID for the audit log
+const threadSnapshot = `SELECT thread_id::text, to_jsonb(t) FROM threads t WHERE thread_id = $1`
+
 func (t threadPostgres) CreateThread(ctx context.Context, thread *models.Thread) (models.Thread, error) {
 	if thread.Created == "" {
 		thread.Created = time.Now().Format(time.RFC3339)
 	}
 
-	err := sqltools.RunTxOnConn(ctx, pkg.TxInsertOptions, t.conn, func(ctx context.Context, tx *sql.Tx) error {
+	err := sqltools.RunTxOnConn(ctx, pkg.TxInsertOptions, t.conn.Write(ctx), func(ctx context.Context, tx *sql.Tx) error {
 		row := tx.QueryRowContext(ctx, `INSERT INTO threads(title, author, forum, message, slug, created)
 			VALUES ($1, $2, $3, $4, $5, $6) RETURNING thread_id;`, thread.Title, thread.Author, thread.Forum, thread.Message, thread.Slug, thread.Created)
 		if row.Err() != nil {
@@ -52,7 +60,17 @@ func (t threadPostgres) CreateThread(ctx context.Context, thread *models.Thread)
 			return err
 		}
 
-		return nil
+		_, err = tx.ExecContext(ctx, `SELECT function_emit_event($1, $2, $2, $3);`, pkg.EventThreadCreated, thread.ID, thread.Author)
+		if err != nil {
+			return err
+		}
+
+		after, err := sqltools.Snapshot(ctx, tx, threadSnapshot, thread.ID)
+		if err != nil {
+			return err
+		}
+
+		return sqltools.Audit(ctx, tx, pkg.AuditEntityThread, pkg.AuditActionCreate, nil, after)
 	})
 
 	if err != nil {
@@ -96,41 +114,133 @@ func (t threadPostgres) CreatePostsByID(ctx context.Context, thread *models.Thre
 
 	insertStatement := sqltools.CreateFullQuery(query, countInserts, countAttributes)
 
-	insertStatement += " RETURNING post_id;"
+	// Every new post is announced to the thread event stream in the same statement. The statement cannot read
+	// the new rows back, so the webhook snapshot is passed along.
+	insertStatement = `WITH inserted AS (` + insertStatement + ` RETURNING *)
+		SELECT post_id, function_emit_event('` + pkg.EventPostCreated + `', thread_id, post_id, author, to_jsonb(inserted))
+		FROM inserted
+		ORDER BY post_id;`
+
+	res := make([]models.Post, len(posts))
+
+	err := sqltools.RunTxOnConn(ctx, pkg.TxInsertOptions, t.conn.Write(ctx), func(ctx context.Context, tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, insertStatement, values...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		var eventID int64
+
+		ids := make([]int64, 0, len(posts))
+
+		i := 0
+		for rows.Next() {
+			err = rows.Scan(&res[i].ID, &eventID)
+			if err != nil {
+				return err
+			}
+
+			res[i].Created = insertTimeString
+			res[i].Parent = posts[i].Parent
+			res[i].Author.Nickname = posts[i].Author.Nickname
+			res[i].Message = posts[i].Message
+			res[i].Forum = thread.Forum
+			res[i].Thread = thread.ID
+
+			ids = append(ids, res[i].ID)
+
+			i++
+		}
+
+		err = rows.Err()
+		if err != nil {
+			return err
+		}
+
+		rows.Close()
+
+		for i := range res {
+			res[i].Attachments, err = linkAttachments(ctx, tx, &res[i], posts[i].Attachments)
+			if err != nil {
+				return err
+			}
+		}
 
-	rows, err := sqltools.InsertBatch(ctx, t.conn, insertStatement, values)
+		after, err := sqltools.Snapshot(ctx, tx, `SELECT post_id::text, to_jsonb(p) FROM posts p WHERE post_id = ANY ($1::bigint[]);`, pq.Array(ids))
+		if err != nil {
+			return err
+		}
+
+		return sqltools.Audit(ctx, tx, pkg.AuditEntityPost, pkg.AuditActionCreate, nil, after)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	res := make([]models.Post, len(posts))
+	return res, nil
+}
+
+// linkAttachments gives the post the attachments with the IDs of the requested ones and returns them in the order
+// they were uploaded, as the posts list them. Each must be uploaded by the author of the post and not be taken by
+// another post, even concurrently.
+func linkAttachments(ctx context.Context, tx *sql.Tx, post *models.Post, requested []models.Attachment) ([]models.Attachment, error) {
+	if len(requested) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int64, len(requested))
+	for idx, attachment := range requested {
+		ids[idx] = attachment.ID
+	}
+
+	rows, err := tx.QueryContext(ctx, `UPDATE attachments AS a
+		SET post_id = $1
+		WHERE a.attachment_id = ANY ($2::bigint[])
+			AND a.post_id IS NULL
+			AND a.author = $3
+		RETURNING `+repoAttachment.AttachmentColumns+`;`, post.ID, pq.Array(ids), post.Author.Nickname)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]models.Attachment, 0, len(ids))
 
-	i := 0
 	for rows.Next() {
-		err = rows.Scan(&res[i].ID)
+		attachment, err := repoAttachment.ScanAttachment(rows)
 		if err != nil {
 			return nil, err
 		}
 
-		res[i].Created = insertTimeString
-		res[i].Parent = posts[i].Parent
-		res[i].Author.Nickname = posts[i].Author.Nickname
-		res[i].Message = posts[i].Message
-		res[i].Forum = thread.Forum
-		res[i].Thread = thread.ID
+		res = append(res, attachment)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
 
-		i++
+	// A repeated ID links once, which is caught here too
+	if len(res) != len(ids) {
+		return nil, pkg.ErrAttachmentUnavailable
 	}
 
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ID < res[j].ID
+	})
+
 	return res, nil
 }
 
 func (t threadPostgres) GetDetailsThreadByID(ctx context.Context, thread *models.Thread) (models.Thread, error) {
 	res := models.Thread{}
 
-	row := t.conn.QueryRowContext(ctx, `SELECT title, author, forum, message, votes, slug, created
-		FROM threads
-		WHERE thread_id = $1;`, thread.ID)
+	row := t.conn.Replica(ctx).QueryRowContext(ctx, `SELECT t.title, t.author, t.forum, t.message, t.votes, t.slug, t.created,
+			t.modified, t.version, f.visibility
+		FROM threads t
+			JOIN forums f ON f.slug = t.forum
+		WHERE t.thread_id = $1;`, thread.ID)
 	if row.Err() != nil {
 		if errors.Is(row.Err(), sql.ErrNoRows) {
 			return models.Thread{}, pkg.ErrSuchThreadNotFound
@@ -146,7 +256,10 @@ func (t threadPostgres) GetDetailsThreadByID(ctx context.Context, thread *models
 		&res.Message,
 		&res.Votes,
 		&res.Slug,
-		&res.Created)
+		&res.Created,
+		&res.Modified,
+		&res.Version,
+		&res.Visibility)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return models.Thread{}, pkg.ErrSuchThreadNotFound
@@ -163,9 +276,11 @@ func (t threadPostgres) GetDetailsThreadByID(ctx context.Context, thread *models
 func (t threadPostgres) GetDetailsThreadBySlug(ctx context.Context, thread *models.Thread) (models.Thread, error) {
 	res := models.Thread{}
 
-	row := t.conn.QueryRowContext(ctx, `SELECT thread_id, title, author, forum, message, votes, slug, created
-		FROM threads
-		WHERE slug = $1;`, thread.Slug)
+	row := t.conn.Replica(ctx).QueryRowContext(ctx, `SELECT t.thread_id, t.title, t.author, t.forum, t.message, t.votes, t.slug,
+			t.created, t.modified, t.version, f.visibility
+		FROM threads t
+			JOIN forums f ON f.slug = t.forum
+		WHERE t.slug = $1;`, thread.Slug)
 	if row.Err() != nil {
 		if errors.Is(row.Err(), sql.ErrNoRows) {
 			return models.Thread{}, pkg.ErrSuchThreadNotFound
@@ -182,7 +297,10 @@ func (t threadPostgres) GetDetailsThreadBySlug(ctx context.Context, thread *mode
 		&res.Message,
 		&res.Votes,
 		&res.Slug,
-		&res.Created)
+		&res.Created,
+		&res.Modified,
+		&res.Version,
+		&res.Visibility)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return models.Thread{}, pkg.ErrSuchThreadNotFound
@@ -194,34 +312,73 @@ func (t threadPostgres) GetDetailsThreadBySlug(ctx context.Context, thread *mode
 	return res, nil
 }
 
-func (t threadPostgres) UpdateThreadByID(ctx context.Context, thread *models.Thread) (models.Thread, error) {
+// UpdateThreadByID updates the thread if its modification time is one of versions and its version number is that
+// of the given thread, nil and 0 respectively allowing any.
+func (t threadPostgres) UpdateThreadByID(ctx context.Context, thread *models.Thread, versions []time.Time) (models.Thread, error) {
 	res := models.Thread{}
 
-	err := sqltools.RunTxOnConn(ctx, pkg.TxInsertOptions, t.conn, func(ctx context.Context, tx *sql.Tx) error {
+	err := sqltools.RunTxOnConn(ctx, pkg.TxInsertOptions, t.conn.Write(ctx), func(ctx context.Context, tx *sql.Tx) error {
+		before, err := sqltools.Snapshot(ctx, tx, threadSnapshot+` FOR UPDATE`, thread.ID)
+		if err != nil {
+			return err
+		}
+
 		row := tx.QueryRowContext(ctx, `UPDATE threads
 		SET title   = COALESCE(NULLIF(TRIM($2), ''), title),
 			message = COALESCE(NULLIF(TRIM($3), ''), message)
 		WHERE thread_id = $1
-		RETURNING author, forum, votes, slug, created, title, message;`, thread.ID, thread.Title, thread.Message)
+			AND ($4::timestamptz[] IS NULL OR modified = ANY ($4::timestamptz[]))
+			AND ($5::bigint = 0 OR version = $5)
+		RETURNING author, forum, votes, slug, created, title, message, modified, version;`,
+			thread.ID, thread.Title, thread.Message, sqltools.TimeArray(versions), thread.Version)
 		if row.Err() != nil {
 			return row.Err()
 		}
 
-		err := row.Scan(
+		err = row.Scan(
 			&res.Author,
 			&res.Forum,
 			&res.Votes,
 			&res.Slug,
 			&res.Created,
 			&res.Title,
-			&res.Message)
+			&res.Message,
+			&res.Modified,
+			&res.Version)
 		if err != nil {
+			// The row is locked, so what failed is told from its state: If-Match first, as RFC 9110 evaluates
+			// the preconditions before the request
+			if errors.Is(err, sql.ErrNoRows) && versions != nil {
+				matches := false
+
+				err = tx.QueryRowContext(ctx, `SELECT modified = ANY ($2::timestamptz[]) FROM threads WHERE thread_id = $1;`,
+					thread.ID, sqltools.TimeArray(versions)).Scan(&matches)
+				if err != nil && !errors.Is(err, sql.ErrNoRows) {
+					return err
+				}
+
+				if err == nil && !matches {
+					return pkg.ErrPreconditionFailed
+				}
+
+				err = sql.ErrNoRows
+			}
+
+			if errors.Is(err, sql.ErrNoRows) && thread.Version != 0 {
+				return pkg.ErrVersionConflict
+			}
+
 			return err
 		}
 
 		res.ID = thread.ID
 
-		return nil
+		after, err := sqltools.Snapshot(ctx, tx, threadSnapshot, thread.ID)
+		if err != nil {
+			return err
+		}
+
+		return sqltools.Audit(ctx, tx, pkg.AuditEntityThread, pkg.AuditActionUpdate, before, after)
 	})
 
 	if err != nil {
@@ -267,7 +424,7 @@ func (t threadPostgres) GetPostsByIDFlat(ctx context.Context, thread *models.Thr
 
 	res := make([]models.Post, 0)
 
-	rows, err = t.conn.QueryContext(ctx, query, values...)
+	rows, err = t.conn.Replica(ctx).QueryContext(ctx, query, values...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, pkg.ErrSuchPostNotFound
@@ -336,7 +493,7 @@ func (t threadPostgres) GetPostsByIDTree(ctx context.Context, thread *models.Thr
 
 	res := make([]models.Post, 0)
 
-	rows, err = t.conn.QueryContext(ctx, query, thread.ID)
+	rows, err = t.conn.Replica(ctx).QueryContext(ctx, query, thread.ID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, pkg.ErrSuchPostNotFound
@@ -415,7 +572,7 @@ func (t threadPostgres) GetPostsByIDParentTree(ctx context.Context, thread *mode
 
 	res := make([]models.Post, 0)
 
-	rows, err = t.conn.QueryContext(ctx, query, values...)
+	rows, err = t.conn.Replica(ctx).QueryContext(ctx, query, values...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, pkg.ErrSuchPostNotFound
@@ -451,3 +608,81 @@ func (t threadPostgres) GetPostsByIDParentTree(ctx context.Context, thread *mode
 
 	return res, nil
 }
+
+// GetTrendingThreads ranks the threads of all forums by hot score, leaving out private forums. Replies unread by
+// the nickname are counted when it is given.
+func (t threadPostgres) GetTrendingThreads(ctx context.Context, limit int64, nickname string) ([]models.Thread, error) {
+	res := make([]models.Thread, 0)
+
+	rows, err := t.conn.Replica(ctx).QueryContext(ctx, `SELECT t.thread_id, t.title, t.author, t.forum, t.message, t.votes, t.slug, t.created,
+			t.last_post_at, coalesce(t.last_post_author, ''), t.replies,
+			CASE WHEN $2 = '' THEN 0 ELSE t.replies - coalesce(r.replies, 0) END
+		FROM threads t
+			JOIN forums f ON f.slug = t.forum
+			LEFT JOIN thread_reads r ON r.thread_id = t.thread_id AND r.nickname = $2
+		WHERE f.visibility <> 'private'
+		ORDER BY t.hot_score DESC
+		LIMIT $1;`, limit, nickname)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		thread := models.Thread{}
+
+		err = rows.Scan(
+			&thread.ID,
+			&thread.Title,
+			&thread.Author,
+			&thread.Forum,
+			&thread.Message,
+			&thread.Votes,
+			&thread.Slug,
+			&thread.Created,
+			&thread.LastPostAt,
+			&thread.LastPostAuthor,
+			&thread.Replies,
+			&thread.Unread)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, thread)
+	}
+
+	return res, rows.Err()
+}
+
+// MarkReadByID moves the read position of the user to the replies the thread has now. The position never moves
+// back, so a stale replica of the thread cannot unread anything.
+func (t threadPostgres) MarkReadByID(ctx context.Context, thread *models.Thread, nickname string) (models.ThreadRead, error) {
+	res := models.ThreadRead{}
+
+	row := t.conn.Write(ctx).QueryRowContext(ctx, `INSERT INTO thread_reads(nickname, thread_id, replies)
+		SELECT $1, thread_id, replies
+		FROM threads
+		WHERE thread_id = $2
+		ON CONFLICT (nickname, thread_id) DO UPDATE
+			SET replies = greatest(thread_reads.replies, EXCLUDED.replies),
+				updated = now()
+		RETURNING thread_id, nickname, replies, updated;`, nickname, thread.ID)
+	if row.Err() != nil {
+		return res, row.Err()
+	}
+
+	err := row.Scan(
+		&res.Thread,
+		&res.Nickname,
+		&res.Replies,
+		&res.Updated)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return res, pkg.ErrSuchThreadNotFound
+		}
+
+		return res, err
+	}
+
+	return res, nil
+}
@@ -2,12 +2,14 @@ package usecase
 
 import (
 	"context"
+	"strings"
 
 	"github.com/pkg/errors"
 
 	repoForum "project/internal/forum/repository"
 	"project/internal/models"
 	"project/internal/pkg"
+	"project/internal/pkg/ratelimit"
 	repoPost "project/internal/post/repository"
 	repoThread "project/internal/thread/repository"
 	repoUser "project/internal/user/repository"
@@ -19,6 +21,8 @@ type ThreadService interface {
 	GetDetailsThread(ctx context.Context, thread *models.Thread) (models.Thread, error)
 	GetPosts(ctx context.Context, thread *models.Thread, params *pkg.GetPostsParams) ([]models.Post, error)
 	UpdateThread(ctx context.Context, thread *models.Thread) (models.Thread, error)
+	GetTrending(ctx context.Context, limit int64) ([]models.Thread, error)
+	MarkRead(ctx context.Context, thread *models.Thread) (models.ThreadRead, error)
 }
 
 type threadService struct {
@@ -26,17 +30,42 @@ type threadService struct {
 	forumRepo  repoForum.ForumRepository
 	userRepo   repoUser.UserRepository
 	postRepo   repoPost.PostRepository
+	flood      *ratelimit.Flood
 }
 
-func NewThreadService(rt repoThread.ThreadRepository, rf repoForum.ForumRepository, ru repoUser.UserRepository, rp repoPost.PostRepository) ThreadService {
+func NewThreadService(rt repoThread.ThreadRepository, rf repoForum.ForumRepository, ru repoUser.UserRepository, rp repoPost.PostRepository, flood *ratelimit.Flood) ThreadService {
 	return &threadService{
 		threadRepo: rt,
 		forumRepo:  rf,
 		userRepo:   ru,
 		postRepo:   rp,
+		flood:      flood,
 	}
 }
 
+// checkAccess hides threads of private forums from non-members and rejects writes into read-only forums. The
+// visibility read with the thread spares the lookup of the membership in public forums.
+func (t threadService) checkAccess(ctx context.Context, thread *models.Thread, write bool) error {
+	if thread.Visibility == pkg.ForumVisibilityPublic {
+		return nil
+	}
+
+	access, err := t.forumRepo.GetAccessForum(ctx, &models.Forum{Slug: thread.Forum}, pkg.GetNickname(ctx))
+	if err != nil {
+		return err
+	}
+
+	if !pkg.CanReadForum(access) {
+		return pkg.ErrSuchThreadNotFound
+	}
+
+	if write && !pkg.CanWriteForum(access) {
+		return pkg.ErrForumReadOnly
+	}
+
+	return nil
+}
+
 func (t threadService) CreateThread(ctx context.Context, thread *models.Thread) (models.Thread, error) {
 	// CheckAuthor
 	resUser, err := t.userRepo.GetUserByNickname(ctx, &models.User{Nickname: thread.Author})
@@ -52,6 +81,22 @@ func (t threadService) CreateThread(ctx context.Context, thread *models.Thread)
 	}
 	thread.Forum = resForum.Slug
 
+	// CheckAccess, public forums being open to everybody
+	if resForum.Visibility != pkg.ForumVisibilityPublic {
+		access, err := t.forumRepo.GetAccessForum(ctx, resForum, pkg.GetNickname(ctx))
+		if err != nil {
+			return models.Thread{}, errors.Wrap(err, "CreateForum")
+		}
+
+		if !pkg.CanReadForum(access) {
+			return models.Thread{}, errors.Wrap(pkg.ErrSuchForumNotFound, "CreateForum")
+		}
+
+		if !pkg.CanWriteForum(access) {
+			return models.Thread{}, errors.Wrap(pkg.ErrForumReadOnly, "CreateForum")
+		}
+	}
+
 	// CheckThread
 	if thread.Slug != "" {
 		var existThread models.Thread
@@ -86,6 +131,11 @@ func (t threadService) CreatePosts(ctx context.Context, thread *models.Thread, p
 		return []models.Post{}, errors.Wrap(err, "CreatePosts")
 	}
 
+	err = t.checkAccess(ctx, &resThread, true)
+	if err != nil {
+		return []models.Post{}, errors.Wrap(err, "CreatePosts")
+	}
+
 	if len(posts) == 0 {
 		return []models.Post{}, nil
 	}
@@ -112,6 +162,22 @@ func (t threadService) CreatePosts(ctx context.Context, thread *models.Thread, p
 		}
 	}
 
+	// CheckFlood, once per author of the batch, last so that rejected batches do not take turns
+	authors := make(map[string]bool)
+	for _, post := range posts {
+		author := strings.ToLower(post.Author.Nickname)
+		if authors[author] {
+			continue
+		}
+
+		authors[author] = true
+
+		err = t.flood.Check(ctx, resThread.ID, author)
+		if err != nil {
+			return nil, errors.Wrap(err, "CreatePosts")
+		}
+	}
+
 	res, err := t.threadRepo.CreatePostsByID(ctx, &resThread, posts)
 	if err != nil {
 		return []models.Post{}, errors.Wrap(err, "CreatePosts")
@@ -135,28 +201,67 @@ func (t threadService) GetDetailsThread(ctx context.Context, thread *models.Thre
 		return models.Thread{}, errors.Wrap(err, "GetDetailsThread")
 	}
 
+	err = t.checkAccess(ctx, &resThread, false)
+	if err != nil {
+		return models.Thread{}, errors.Wrap(err, "GetDetailsThread")
+	}
+
 	return resThread, nil
 }
 
+// UpdateThread returns the current state of the thread along with ErrVersionConflict when the expected version of
+// the thread is given and outdated. If-Match is evaluated first, as HTTP preconditions come before the request
+// itself, so ErrPreconditionFailed wins when both are stale.
 func (t threadService) UpdateThread(ctx context.Context, thread *models.Thread) (models.Thread, error) {
 	var err error
 
 	resThread := models.Thread{}
 
+	// The version is checked against the latest state
+	primary := pkg.WithPrimaryReads(ctx)
+
 	// CheckAndGetThread
 	if thread.Slug != "" {
-		resThread, err = t.threadRepo.GetDetailsThreadBySlug(ctx, thread)
+		resThread, err = t.threadRepo.GetDetailsThreadBySlug(primary, thread)
 	} else {
-		resThread, err = t.threadRepo.GetDetailsThreadByID(ctx, thread)
+		resThread, err = t.threadRepo.GetDetailsThreadByID(primary, thread)
 	}
 	if err != nil {
 		return models.Thread{}, errors.Wrap(err, "UpdateThread")
 	}
 
+	err = t.checkAccess(ctx, &resThread, true)
+	if err != nil {
+		return models.Thread{}, errors.Wrap(err, "UpdateThread")
+	}
+
+	conditional := pkg.GetConditionalParams(ctx)
+
+	if !conditional.MatchesVersion(resThread.Modified) {
+		return models.Thread{}, errors.Wrap(pkg.ErrPreconditionFailed, "UpdateThread")
+	}
+
+	if thread.Version != 0 && thread.Version != resThread.Version {
+		return resThread, errors.Wrap(pkg.ErrVersionConflict, "UpdateThread")
+	}
+
+	current := resThread
+
 	resThread.Title = thread.Title
 	resThread.Message = thread.Message
+	resThread.Version = thread.Version
+
+	versions := conditional.IfMatchVersions()
+
+	res, err := t.threadRepo.UpdateThreadByID(ctx, &resThread, versions)
+	if errors.Is(err, pkg.ErrVersionConflict) {
+		current, err = t.threadRepo.GetDetailsThreadByID(primary, &current)
+		if err != nil {
+			return models.Thread{}, errors.Wrap(err, "UpdateThread")
+		}
 
-	res, err := t.threadRepo.UpdateThreadByID(ctx, &resThread)
+		return current, errors.Wrap(pkg.ErrVersionConflict, "UpdateThread")
+	}
 	if err != nil {
 		return models.Thread{}, errors.Wrap(err, "UpdateThread")
 	}
@@ -180,6 +285,11 @@ func (t threadService) GetPosts(ctx context.Context, thread *models.Thread, para
 		return []models.Post{}, errors.Wrap(err, "GetPosts")
 	}
 
+	err = t.checkAccess(ctx, &resThread, false)
+	if err != nil {
+		return []models.Post{}, errors.Wrap(err, "GetPosts")
+	}
+
 	switch params.Sort {
 	case pkg.TypeSortFlat:
 		res, err = t.threadRepo.GetPostsByIDFlat(ctx, &resThread, params)
@@ -194,5 +304,42 @@ func (t threadService) GetPosts(ctx context.Context, thread *models.Thread, para
 		return nil, errors.Wrap(err, "GetPosts")
 	}
 
+	err = t.postRepo.GetAttachments(ctx, res)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetPosts")
+	}
+
+	return res, nil
+}
+
+func (t threadService) GetTrending(ctx context.Context, limit int64) ([]models.Thread, error) {
+	if limit <= 0 {
+		return nil, errors.Wrap(pkg.ErrBadRequestParams, "GetTrending")
+	}
+
+	res, err := t.threadRepo.GetTrendingThreads(ctx, limit, pkg.GetNickname(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "GetTrending")
+	}
+
+	return res, nil
+}
+
+func (t threadService) MarkRead(ctx context.Context, thread *models.Thread) (models.ThreadRead, error) {
+	nickname := pkg.GetNickname(ctx)
+	if nickname == "" {
+		return models.ThreadRead{}, errors.Wrap(pkg.ErrAuthRequired, "MarkRead")
+	}
+
+	resThread, err := t.GetDetailsThread(ctx, thread)
+	if err != nil {
+		return models.ThreadRead{}, errors.Wrap(err, "MarkRead")
+	}
+
+	res, err := t.threadRepo.MarkReadByID(ctx, &resThread, nickname)
+	if err != nil {
+		return models.ThreadRead{}, errors.Wrap(err, "MarkRead")
+	}
+
 	return res, nil
 }
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"project/internal/models"
+	"project/internal/pkg"
+	repoThread "project/internal/thread/repository"
+)
+
+// storedThread keeps one thread of a public forum, counting the updates which reach it.
+type storedThread struct {
+	repoThread.ThreadRepository
+
+	thread  models.Thread
+	updates int
+}
+
+func (s *storedThread) GetDetailsThreadByID(ctx context.Context, thread *models.Thread) (models.Thread, error) {
+	if thread.ID != s.thread.ID {
+		return models.Thread{}, pkg.ErrSuchThreadNotFound
+	}
+
+	return s.thread, nil
+}
+
+func (s *storedThread) UpdateThreadByID(ctx context.Context, thread *models.Thread, versions []time.Time) (models.Thread, error) {
+	s.updates++
+
+	res := s.thread
+	res.Title = thread.Title
+	res.Version++
+
+	return res, nil
+}
+
+func TestUpdateThreadPreconditions(t *testing.T) {
+	modified := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	stale := modified.Add(-time.Minute)
+
+	tests := []struct {
+		name    string
+		version int64
+		ifMatch string
+		wantErr error
+	}{
+		{name: "both current", version: 3, ifMatch: pkg.VersionETag(modified)},
+		{name: "no conditions"},
+		{name: "stale version", version: 2, ifMatch: pkg.VersionETag(modified), wantErr: pkg.ErrVersionConflict},
+		{name: "stale If-Match", version: 3, ifMatch: pkg.VersionETag(stale), wantErr: pkg.ErrPreconditionFailed},
+		{name: "both stale", version: 2, ifMatch: pkg.VersionETag(stale), wantErr: pkg.ErrPreconditionFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &storedThread{thread: models.Thread{
+				ID:         7,
+				Title:      "title",
+				Modified:   modified,
+				Version:    3,
+				Visibility: pkg.ForumVisibilityPublic,
+			}}
+
+			service := NewThreadService(repo, nil, nil, nil, nil)
+
+			ctx := context.WithValue(context.Background(), pkg.ConditionalKey, &pkg.ConditionalParams{IfMatch: tt.ifMatch})
+
+			_, err := service.UpdateThread(ctx, &models.Thread{ID: 7, Title: "new", Version: tt.version})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateThread() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil && repo.updates != 0 {
+				t.Errorf("refused update reached the repository")
+			}
+		})
+	}
+}
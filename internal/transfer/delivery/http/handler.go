@@ -0,0 +1,76 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	coreModels "project/internal/models"
+	"project/internal/pkg"
+	"project/internal/transfer/delivery/models"
+	"project/internal/transfer/usecase"
+)
+
+type TransferHandler struct {
+	transferUsecase usecase.TransferService
+}
+
+func (h *TransferHandler) ExportHandler(w http.ResponseWriter, r *http.Request) {
+	request := models.NewTransferRequest()
+
+	request.Bind(r)
+
+	writer := models.NewTransferWriter(w)
+
+	started := false
+
+	// The status is sent with the first record, so that errors found before it are still reported properly
+	err := h.transferUsecase.Export(r.Context(), request.GetForum(), func(record *coreModels.TransferRecord) error {
+		if !started {
+			w.Header().Set("Content-Type", pkg.ContentTypeNDJSON)
+			w.Header().Set("Content-Disposition", `attachment; filename="export.ndjson"`)
+			w.WriteHeader(http.StatusOK)
+
+			started = true
+		}
+
+		return writer.Write(record)
+	})
+	if err != nil && !started {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+	if err != nil {
+		// The export lacks its end record, which importers check
+		logrus.Error(err)
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		logrus.Error(err)
+	}
+}
+
+func (h *TransferHandler) ImportHandler(w http.ResponseWriter, r *http.Request) {
+	request := models.NewTransferRequest()
+
+	request.Bind(r)
+
+	reader := models.NewTransferReader(r.Body)
+
+	stats, err := h.transferUsecase.Import(r.Context(), reader.Next, request.IDs)
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	response := models.NewTransferStatsResponse(stats)
+
+	pkg.Response(r.Context(), w, http.StatusCreated, response)
+}
+
+func NewTransferHandler(transferUsecase usecase.TransferService, r *mux.Router) *TransferHandler {
+	h := &TransferHandler{transferUsecase: transferUsecase}
+	return h
+}
@@ -0,0 +1,341 @@
+package models
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/mailru/easyjson"
+	"github.com/pkg/errors"
+
+	"project/internal/models"
+	"project/internal/pkg"
+)
+
+//go:generate easyjson -disallow_unknown_fields -omit_empty transfer.go
+
+type TransferRequest struct {
+	Forum string
+	IDs   string
+}
+
+func NewTransferRequest() *TransferRequest {
+	return &TransferRequest{}
+}
+
+func (req *TransferRequest) Bind(r *http.Request) error {
+	req.Forum = r.URL.Query().Get("forum")
+	req.IDs = r.URL.Query().Get("ids")
+
+	return nil
+}
+
+func (req *TransferRequest) GetForum() *models.Forum {
+	return &models.Forum{
+		Slug: req.Forum,
+	}
+}
+
+// TransferRecord is a line of an NDJSON export: the kind of the entity and the entity itself.
+//
+//easyjson:json
+type TransferRecord struct {
+	Type  string              `json:"type"`
+	Data  easyjson.RawMessage `json:"data,omitempty"`
+	Count int64               `json:"count,omitempty"`
+}
+
+//easyjson:json
+type TransferUser struct {
+	Nickname string `json:"nickname"`
+	FullName string `json:"fullname"`
+	About    string `json:"about,omitempty"`
+	Email    string `json:"email"`
+}
+
+//easyjson:json
+type TransferForum struct {
+	Slug       string `json:"slug"`
+	Title      string `json:"title"`
+	User       string `json:"user"`
+	Parent     string `json:"parent,omitempty"`
+	RollUp     bool   `json:"rollup,omitempty"`
+	Visibility string `json:"visibility"`
+}
+
+//easyjson:json
+type TransferMember struct {
+	Forum     string `json:"forum"`
+	Nickname  string `json:"nickname"`
+	Status    string `json:"status"`
+	InvitedBy string `json:"invitedBy,omitempty"`
+}
+
+//easyjson:json
+type TransferThread struct {
+	ID      int64  `json:"id"`
+	Title   string `json:"title"`
+	Author  string `json:"author"`
+	Forum   string `json:"forum"`
+	Slug    string `json:"slug,omitempty"`
+	Message string `json:"message"`
+	Created string `json:"created"`
+}
+
+//easyjson:json
+type TransferPost struct {
+	ID       int64  `json:"id"`
+	Parent   int64  `json:"parent,omitempty"`
+	Author   string `json:"author"`
+	Message  string `json:"message"`
+	IsEdited bool   `json:"isEdited,omitempty"`
+	Forum    string `json:"forum"`
+	Thread   int64  `json:"thread"`
+	Created  string `json:"created"`
+}
+
+//easyjson:json
+type TransferVote struct {
+	Nickname string `json:"nickname"`
+	Thread   int64  `json:"thread"`
+	Voice    int64  `json:"voice"`
+}
+
+func newTransferData(record *models.TransferRecord) easyjson.Marshaler {
+	switch record.Kind {
+	case pkg.TransferUser:
+		return &TransferUser{
+			Nickname: record.User.Nickname,
+			FullName: record.User.FullName,
+			About:    record.User.About,
+			Email:    record.User.Email,
+		}
+	case pkg.TransferForum:
+		return &TransferForum{
+			Slug:       record.Forum.Slug,
+			Title:      record.Forum.Title,
+			User:       record.Forum.User,
+			Parent:     record.Forum.Parent,
+			RollUp:     record.Forum.RollUp,
+			Visibility: record.Forum.Visibility,
+		}
+	case pkg.TransferMember:
+		return &TransferMember{
+			Forum:     record.Member.Forum,
+			Nickname:  record.Member.Nickname,
+			Status:    record.Member.Status,
+			InvitedBy: record.Member.InvitedBy,
+		}
+	case pkg.TransferThread:
+		return &TransferThread{
+			ID:      record.Thread.ID,
+			Title:   record.Thread.Title,
+			Author:  record.Thread.Author,
+			Forum:   record.Thread.Forum,
+			Slug:    record.Thread.Slug,
+			Message: record.Thread.Message,
+			Created: record.Thread.Created,
+		}
+	case pkg.TransferPost:
+		return &TransferPost{
+			ID:       record.Post.ID,
+			Parent:   record.Post.Parent,
+			Author:   record.Post.Author.Nickname,
+			Message:  record.Post.Message,
+			IsEdited: record.Post.IsEdited,
+			Forum:    record.Post.Forum,
+			Thread:   record.Post.Thread,
+			Created:  record.Post.Created,
+		}
+	case pkg.TransferVote:
+		return &TransferVote{
+			Nickname: record.Vote.Nickname,
+			Thread:   record.Vote.Thread,
+			Voice:    record.Vote.Voice,
+		}
+	default:
+		return nil
+	}
+}
+
+// TransferWriter writes records as NDJSON.
+type TransferWriter struct {
+	w *bufio.Writer
+}
+
+func NewTransferWriter(w io.Writer) *TransferWriter {
+	return &TransferWriter{
+		w: bufio.NewWriterSize(w, 64*1024),
+	}
+}
+
+func (t *TransferWriter) Write(record *models.TransferRecord) error {
+	line := &TransferRecord{
+		Type:  record.Kind,
+		Count: record.Count,
+	}
+
+	data := newTransferData(record)
+	if data != nil {
+		raw, err := easyjson.Marshal(data)
+		if err != nil {
+			return err
+		}
+
+		line.Data = raw
+	}
+
+	_, err := easyjson.MarshalToWriter(line, t.w)
+	if err != nil {
+		return err
+	}
+
+	return t.w.WriteByte('\n')
+}
+
+func (t *TransferWriter) Flush() error {
+	return t.w.Flush()
+}
+
+// TransferReader reads NDJSON records, skipping blank lines. Lines have no length limit.
+type TransferReader struct {
+	r    *bufio.Reader
+	line int64
+}
+
+func NewTransferReader(r io.Reader) *TransferReader {
+	return &TransferReader{
+		r: bufio.NewReaderSize(r, 64*1024),
+	}
+}
+
+func (t *TransferReader) Next() (*models.TransferRecord, error) {
+	for {
+		data, err := t.r.ReadBytes('\n')
+		if err != nil && !(errors.Is(err, io.EOF) && len(data) > 0) {
+			return nil, err
+		}
+
+		t.line++
+
+		data = bytes.TrimSpace(data)
+		if len(data) == 0 {
+			continue
+		}
+
+		record, err := t.decode(data)
+		if err != nil {
+			return nil, errors.Wrapf(pkg.ErrImportInvalid, "line %d", t.line)
+		}
+
+		return record, nil
+	}
+}
+
+func (t *TransferReader) decode(data []byte) (*models.TransferRecord, error) {
+	line := &TransferRecord{}
+
+	err := easyjson.Unmarshal(data, line)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &models.TransferRecord{
+		Kind:  line.Type,
+		Count: line.Count,
+	}
+
+	switch line.Type {
+	case pkg.TransferUser:
+		value := &TransferUser{}
+		err = easyjson.Unmarshal(line.Data, value)
+		res.User = &models.User{
+			Nickname: value.Nickname,
+			FullName: value.FullName,
+			About:    value.About,
+			Email:    value.Email,
+		}
+	case pkg.TransferForum:
+		value := &TransferForum{}
+		err = easyjson.Unmarshal(line.Data, value)
+		res.Forum = &models.Forum{
+			Slug:       value.Slug,
+			Title:      value.Title,
+			User:       value.User,
+			Parent:     value.Parent,
+			RollUp:     value.RollUp,
+			Visibility: value.Visibility,
+		}
+	case pkg.TransferMember:
+		value := &TransferMember{}
+		err = easyjson.Unmarshal(line.Data, value)
+		res.Member = &models.ForumMember{
+			Forum:     value.Forum,
+			Nickname:  value.Nickname,
+			Status:    value.Status,
+			InvitedBy: value.InvitedBy,
+		}
+	case pkg.TransferThread:
+		value := &TransferThread{}
+		err = easyjson.Unmarshal(line.Data, value)
+		res.Thread = &models.Thread{
+			ID:      value.ID,
+			Title:   value.Title,
+			Author:  value.Author,
+			Forum:   value.Forum,
+			Slug:    value.Slug,
+			Message: value.Message,
+			Created: value.Created,
+		}
+	case pkg.TransferPost:
+		value := &TransferPost{}
+		err = easyjson.Unmarshal(line.Data, value)
+		res.Post = &models.Post{
+			ID:       value.ID,
+			Parent:   value.Parent,
+			Author:   models.User{Nickname: value.Author},
+			Message:  value.Message,
+			IsEdited: value.IsEdited,
+			Forum:    value.Forum,
+			Thread:   value.Thread,
+			Created:  value.Created,
+		}
+	case pkg.TransferVote:
+		value := &TransferVote{}
+		err = easyjson.Unmarshal(line.Data, value)
+		res.Vote = &models.Vote{
+			Nickname: value.Nickname,
+			Thread:   value.Thread,
+			Voice:    value.Voice,
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+//easyjson:json
+type TransferStatsResponse struct {
+	Users        int64 `json:"users"`
+	SkippedUsers int64 `json:"skippedUsers"`
+	Forums       int64 `json:"forums"`
+	Members      int64 `json:"members"`
+	Threads      int64 `json:"threads"`
+	Posts        int64 `json:"posts"`
+	Votes        int64 `json:"votes"`
+}
+
+func NewTransferStatsResponse(stats *models.TransferStats) *TransferStatsResponse {
+	return &TransferStatsResponse{
+		Users:        stats.Users,
+		SkippedUsers: stats.SkippedUsers,
+		Forums:       stats.Forums,
+		Members:      stats.Members,
+		Threads:      stats.Threads,
+		Posts:        stats.Posts,
+		Votes:        stats.Votes,
+	}
+}
@@ -0,0 +1,995 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package models
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonD0c14475DecodeProjectInternalTransferDeliveryModels(in *jlexer.Lexer, out *TransferVote) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "nickname":
+			out.Nickname = string(in.String())
+		case "thread":
+			out.Thread = int64(in.Int64())
+		case "voice":
+			out.Voice = int64(in.Int64())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD0c14475EncodeProjectInternalTransferDeliveryModels(out *jwriter.Writer, in TransferVote) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Nickname != "" {
+		const prefix string = ",\"nickname\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Nickname))
+	}
+	if in.Thread != 0 {
+		const prefix string = ",\"thread\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Thread))
+	}
+	if in.Voice != 0 {
+		const prefix string = ",\"voice\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Voice))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TransferVote) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD0c14475EncodeProjectInternalTransferDeliveryModels(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TransferVote) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD0c14475EncodeProjectInternalTransferDeliveryModels(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TransferVote) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD0c14475DecodeProjectInternalTransferDeliveryModels(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TransferVote) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD0c14475DecodeProjectInternalTransferDeliveryModels(l, v)
+}
+func easyjsonD0c14475DecodeProjectInternalTransferDeliveryModels1(in *jlexer.Lexer, out *TransferUser) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "nickname":
+			out.Nickname = string(in.String())
+		case "fullname":
+			out.FullName = string(in.String())
+		case "about":
+			out.About = string(in.String())
+		case "email":
+			out.Email = string(in.String())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD0c14475EncodeProjectInternalTransferDeliveryModels1(out *jwriter.Writer, in TransferUser) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Nickname != "" {
+		const prefix string = ",\"nickname\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Nickname))
+	}
+	if in.FullName != "" {
+		const prefix string = ",\"fullname\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.FullName))
+	}
+	if in.About != "" {
+		const prefix string = ",\"about\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.About))
+	}
+	if in.Email != "" {
+		const prefix string = ",\"email\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Email))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TransferUser) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD0c14475EncodeProjectInternalTransferDeliveryModels1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TransferUser) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD0c14475EncodeProjectInternalTransferDeliveryModels1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TransferUser) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD0c14475DecodeProjectInternalTransferDeliveryModels1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TransferUser) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD0c14475DecodeProjectInternalTransferDeliveryModels1(l, v)
+}
+func easyjsonD0c14475DecodeProjectInternalTransferDeliveryModels2(in *jlexer.Lexer, out *TransferThread) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int64(in.Int64())
+		case "title":
+			out.Title = string(in.String())
+		case "author":
+			out.Author = string(in.String())
+		case "forum":
+			out.Forum = string(in.String())
+		case "slug":
+			out.Slug = string(in.String())
+		case "message":
+			out.Message = string(in.String())
+		case "created":
+			out.Created = string(in.String())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD0c14475EncodeProjectInternalTransferDeliveryModels2(out *jwriter.Writer, in TransferThread) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.ID != 0 {
+		const prefix string = ",\"id\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.ID))
+	}
+	if in.Title != "" {
+		const prefix string = ",\"title\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Title))
+	}
+	if in.Author != "" {
+		const prefix string = ",\"author\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Author))
+	}
+	if in.Forum != "" {
+		const prefix string = ",\"forum\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Forum))
+	}
+	if in.Slug != "" {
+		const prefix string = ",\"slug\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Slug))
+	}
+	if in.Message != "" {
+		const prefix string = ",\"message\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Message))
+	}
+	if in.Created != "" {
+		const prefix string = ",\"created\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Created))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TransferThread) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD0c14475EncodeProjectInternalTransferDeliveryModels2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TransferThread) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD0c14475EncodeProjectInternalTransferDeliveryModels2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TransferThread) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD0c14475DecodeProjectInternalTransferDeliveryModels2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TransferThread) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD0c14475DecodeProjectInternalTransferDeliveryModels2(l, v)
+}
+func easyjsonD0c14475DecodeProjectInternalTransferDeliveryModels3(in *jlexer.Lexer, out *TransferStatsResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "users":
+			out.Users = int64(in.Int64())
+		case "skippedUsers":
+			out.SkippedUsers = int64(in.Int64())
+		case "forums":
+			out.Forums = int64(in.Int64())
+		case "members":
+			out.Members = int64(in.Int64())
+		case "threads":
+			out.Threads = int64(in.Int64())
+		case "posts":
+			out.Posts = int64(in.Int64())
+		case "votes":
+			out.Votes = int64(in.Int64())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD0c14475EncodeProjectInternalTransferDeliveryModels3(out *jwriter.Writer, in TransferStatsResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Users != 0 {
+		const prefix string = ",\"users\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.Users))
+	}
+	if in.SkippedUsers != 0 {
+		const prefix string = ",\"skippedUsers\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.SkippedUsers))
+	}
+	if in.Forums != 0 {
+		const prefix string = ",\"forums\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Forums))
+	}
+	if in.Members != 0 {
+		const prefix string = ",\"members\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Members))
+	}
+	if in.Threads != 0 {
+		const prefix string = ",\"threads\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Threads))
+	}
+	if in.Posts != 0 {
+		const prefix string = ",\"posts\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Posts))
+	}
+	if in.Votes != 0 {
+		const prefix string = ",\"votes\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Votes))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TransferStatsResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD0c14475EncodeProjectInternalTransferDeliveryModels3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TransferStatsResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD0c14475EncodeProjectInternalTransferDeliveryModels3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TransferStatsResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD0c14475DecodeProjectInternalTransferDeliveryModels3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TransferStatsResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD0c14475DecodeProjectInternalTransferDeliveryModels3(l, v)
+}
+func easyjsonD0c14475DecodeProjectInternalTransferDeliveryModels4(in *jlexer.Lexer, out *TransferRecord) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "type":
+			out.Type = string(in.String())
+		case "data":
+			(out.Data).UnmarshalEasyJSON(in)
+		case "count":
+			out.Count = int64(in.Int64())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD0c14475EncodeProjectInternalTransferDeliveryModels4(out *jwriter.Writer, in TransferRecord) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Type != "" {
+		const prefix string = ",\"type\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Type))
+	}
+	if (in.Data).IsDefined() {
+		const prefix string = ",\"data\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		(in.Data).MarshalEasyJSON(out)
+	}
+	if in.Count != 0 {
+		const prefix string = ",\"count\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Count))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TransferRecord) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD0c14475EncodeProjectInternalTransferDeliveryModels4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TransferRecord) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD0c14475EncodeProjectInternalTransferDeliveryModels4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TransferRecord) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD0c14475DecodeProjectInternalTransferDeliveryModels4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TransferRecord) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD0c14475DecodeProjectInternalTransferDeliveryModels4(l, v)
+}
+func easyjsonD0c14475DecodeProjectInternalTransferDeliveryModels5(in *jlexer.Lexer, out *TransferPost) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int64(in.Int64())
+		case "parent":
+			out.Parent = int64(in.Int64())
+		case "author":
+			out.Author = string(in.String())
+		case "message":
+			out.Message = string(in.String())
+		case "isEdited":
+			out.IsEdited = bool(in.Bool())
+		case "forum":
+			out.Forum = string(in.String())
+		case "thread":
+			out.Thread = int64(in.Int64())
+		case "created":
+			out.Created = string(in.String())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD0c14475EncodeProjectInternalTransferDeliveryModels5(out *jwriter.Writer, in TransferPost) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.ID != 0 {
+		const prefix string = ",\"id\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.ID))
+	}
+	if in.Parent != 0 {
+		const prefix string = ",\"parent\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Parent))
+	}
+	if in.Author != "" {
+		const prefix string = ",\"author\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Author))
+	}
+	if in.Message != "" {
+		const prefix string = ",\"message\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Message))
+	}
+	if in.IsEdited {
+		const prefix string = ",\"isEdited\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.IsEdited))
+	}
+	if in.Forum != "" {
+		const prefix string = ",\"forum\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Forum))
+	}
+	if in.Thread != 0 {
+		const prefix string = ",\"thread\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Thread))
+	}
+	if in.Created != "" {
+		const prefix string = ",\"created\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Created))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TransferPost) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD0c14475EncodeProjectInternalTransferDeliveryModels5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TransferPost) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD0c14475EncodeProjectInternalTransferDeliveryModels5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TransferPost) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD0c14475DecodeProjectInternalTransferDeliveryModels5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TransferPost) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD0c14475DecodeProjectInternalTransferDeliveryModels5(l, v)
+}
+func easyjsonD0c14475DecodeProjectInternalTransferDeliveryModels6(in *jlexer.Lexer, out *TransferMember) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "forum":
+			out.Forum = string(in.String())
+		case "nickname":
+			out.Nickname = string(in.String())
+		case "status":
+			out.Status = string(in.String())
+		case "invitedBy":
+			out.InvitedBy = string(in.String())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD0c14475EncodeProjectInternalTransferDeliveryModels6(out *jwriter.Writer, in TransferMember) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Forum != "" {
+		const prefix string = ",\"forum\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Forum))
+	}
+	if in.Nickname != "" {
+		const prefix string = ",\"nickname\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Nickname))
+	}
+	if in.Status != "" {
+		const prefix string = ",\"status\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Status))
+	}
+	if in.InvitedBy != "" {
+		const prefix string = ",\"invitedBy\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.InvitedBy))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TransferMember) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD0c14475EncodeProjectInternalTransferDeliveryModels6(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TransferMember) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD0c14475EncodeProjectInternalTransferDeliveryModels6(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TransferMember) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD0c14475DecodeProjectInternalTransferDeliveryModels6(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TransferMember) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD0c14475DecodeProjectInternalTransferDeliveryModels6(l, v)
+}
+func easyjsonD0c14475DecodeProjectInternalTransferDeliveryModels7(in *jlexer.Lexer, out *TransferForum) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "slug":
+			out.Slug = string(in.String())
+		case "title":
+			out.Title = string(in.String())
+		case "user":
+			out.User = string(in.String())
+		case "parent":
+			out.Parent = string(in.String())
+		case "rollup":
+			out.RollUp = bool(in.Bool())
+		case "visibility":
+			out.Visibility = string(in.String())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD0c14475EncodeProjectInternalTransferDeliveryModels7(out *jwriter.Writer, in TransferForum) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Slug != "" {
+		const prefix string = ",\"slug\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Slug))
+	}
+	if in.Title != "" {
+		const prefix string = ",\"title\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Title))
+	}
+	if in.User != "" {
+		const prefix string = ",\"user\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.User))
+	}
+	if in.Parent != "" {
+		const prefix string = ",\"parent\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Parent))
+	}
+	if in.RollUp {
+		const prefix string = ",\"rollup\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.RollUp))
+	}
+	if in.Visibility != "" {
+		const prefix string = ",\"visibility\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Visibility))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TransferForum) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonD0c14475EncodeProjectInternalTransferDeliveryModels7(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TransferForum) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonD0c14475EncodeProjectInternalTransferDeliveryModels7(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TransferForum) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonD0c14475DecodeProjectInternalTransferDeliveryModels7(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TransferForum) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonD0c14475DecodeProjectInternalTransferDeliveryModels7(l, v)
+}
@@ -0,0 +1,556 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	"project/internal/models"
+	"project/internal/pkg"
+	"project/internal/pkg/sqltools"
+)
+
+type TransferRepository interface {
+	Export(ctx context.Context, forum *models.Forum, write func(record *models.TransferRecord) error) error
+	Import(ctx context.Context, next func() (*models.TransferRecord, error), remap bool) (*models.TransferStats, error)
+}
+
+type transferPostgres struct {
+	conn *sql.DB
+}
+
+func NewTransferPostgres(conn *sql.DB) TransferRepository {
+	return &transferPostgres{
+		conn,
+	}
+}
+
+var exportOptions = &sql.TxOptions{
+	Isolation: sql.LevelRepeatableRead,
+	ReadOnly:  true,
+}
+
+// Export writes users, forums, members, threads, posts and votes, each after everything it refers to, and
+// an end record with their count. An empty forum slug exports everything, otherwise the forum with its
+// sub-forums and the users they involve. The whole export reads one snapshot.
+func (t transferPostgres) Export(ctx context.Context, forum *models.Forum, write func(record *models.TransferRecord) error) error {
+	var count int64
+
+	counted := func(record *models.TransferRecord) error {
+		count++
+
+		return write(record)
+	}
+
+	return sqltools.RunTxOnConn(ctx, exportOptions, t.conn, func(ctx context.Context, tx *sql.Tx) error {
+		forums, err := exportForums(ctx, tx, forum.Slug)
+		if err != nil {
+			return err
+		}
+
+		slugs := make([]string, len(forums))
+		for idx, value := range forums {
+			slugs[idx] = value.Slug
+		}
+
+		err = exportUsers(ctx, tx, forum.Slug == "", slugs, counted)
+		if err != nil {
+			return err
+		}
+
+		for _, value := range forums {
+			err = counted(&models.TransferRecord{Kind: pkg.TransferForum, Forum: value})
+			if err != nil {
+				return err
+			}
+		}
+
+		steps := []func(ctx context.Context, tx *sql.Tx, slugs []string, write func(record *models.TransferRecord) error) error{
+			exportMembers,
+			exportThreads,
+			exportPosts,
+			exportVotes,
+		}
+
+		for _, step := range steps {
+			err = step(ctx, tx, slugs, counted)
+			if err != nil {
+				return err
+			}
+		}
+
+		return write(&models.TransferRecord{Kind: pkg.TransferEnd, Count: count})
+	})
+}
+
+// exportForums returns forums parents first. The parent of an exported sub-tree root is left out, it is not a
+// part of the export.
+func exportForums(ctx context.Context, tx *sql.Tx, slug string) ([]*models.Forum, error) {
+	rows, err := tx.QueryContext(ctx, `WITH RECURSIVE tree AS (
+			SELECT slug, 0 AS depth
+			FROM forums
+			WHERE ($1 = '' AND parent IS NULL) OR slug = $1
+			UNION ALL
+			SELECT f.slug, tree.depth + 1
+			FROM forums f
+				JOIN tree ON f.parent = tree.slug)
+		SELECT f.slug, f.title, f.users_nickname, CASE WHEN f.slug = $1 THEN '' ELSE COALESCE(f.parent, '') END,
+			f.roll_up, f.visibility
+		FROM tree
+			JOIN forums f ON f.slug = tree.slug
+		ORDER BY tree.depth, f.slug;`, slug)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*models.Forum, 0)
+
+	for rows.Next() {
+		forum := &models.Forum{}
+
+		err = rows.Scan(
+			&forum.Slug,
+			&forum.Title,
+			&forum.User,
+			&forum.Parent,
+			&forum.RollUp,
+			&forum.Visibility)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, forum)
+	}
+
+	if slug != "" && len(res) == 0 {
+		return nil, pkg.ErrSuchForumNotFound
+	}
+
+	return res, rows.Err()
+}
+
+func exportUsers(ctx context.Context, tx *sql.Tx, all bool, slugs []string, write func(record *models.TransferRecord) error) error {
+	rows, err := tx.QueryContext(ctx, `SELECT nickname, fullname, COALESCE(about, ''), email
+		FROM users
+		WHERE $1
+		   OR nickname IN (
+			SELECT users_nickname FROM forums WHERE slug = ANY ($2::citext[])
+			UNION
+			SELECT nickname FROM forum_members WHERE forum = ANY ($2::citext[])
+			UNION
+			SELECT invited_by FROM forum_members WHERE forum = ANY ($2::citext[])
+			UNION
+			SELECT author FROM threads WHERE forum = ANY ($2::citext[])
+			UNION
+			SELECT author FROM posts WHERE forum = ANY ($2::citext[])
+			UNION
+			SELECT v.nickname FROM user_votes v JOIN threads t ON t.thread_id = v.thread_id WHERE t.forum = ANY ($2::citext[]))
+		ORDER BY nickname;`, all, pq.Array(slugs))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		user := &models.User{}
+
+		err = rows.Scan(
+			&user.Nickname,
+			&user.FullName,
+			&user.About,
+			&user.Email)
+		if err != nil {
+			return err
+		}
+
+		err = write(&models.TransferRecord{Kind: pkg.TransferUser, User: user})
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func exportMembers(ctx context.Context, tx *sql.Tx, slugs []string, write func(record *models.TransferRecord) error) error {
+	rows, err := tx.QueryContext(ctx, `SELECT forum, nickname, status, COALESCE(invited_by, '')
+		FROM forum_members
+		WHERE forum = ANY ($1::citext[])
+		ORDER BY forum, nickname;`, pq.Array(slugs))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		member := &models.ForumMember{}
+
+		err = rows.Scan(
+			&member.Forum,
+			&member.Nickname,
+			&member.Status,
+			&member.InvitedBy)
+		if err != nil {
+			return err
+		}
+
+		err = write(&models.TransferRecord{Kind: pkg.TransferMember, Member: member})
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func exportThreads(ctx context.Context, tx *sql.Tx, slugs []string, write func(record *models.TransferRecord) error) error {
+	rows, err := tx.QueryContext(ctx, `SELECT thread_id, title, author, forum, COALESCE(slug, ''), message, created
+		FROM threads
+		WHERE forum = ANY ($1::citext[])
+		ORDER BY thread_id;`, pq.Array(slugs))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		thread := &models.Thread{}
+
+		timeTmp := time.Time{}
+
+		err = rows.Scan(
+			&thread.ID,
+			&thread.Title,
+			&thread.Author,
+			&thread.Forum,
+			&thread.Slug,
+			&thread.Message,
+			&timeTmp)
+		if err != nil {
+			return err
+		}
+
+		thread.Created = timeTmp.Format(time.RFC3339Nano)
+
+		err = write(&models.TransferRecord{Kind: pkg.TransferThread, Thread: thread})
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// exportPosts orders posts by id, so parents come before their answers.
+func exportPosts(ctx context.Context, tx *sql.Tx, slugs []string, write func(record *models.TransferRecord) error) error {
+	rows, err := tx.QueryContext(ctx, `SELECT post_id, parent, author, message, is_edited, forum, thread_id, created
+		FROM posts
+		WHERE forum = ANY ($1::citext[])
+		ORDER BY post_id;`, pq.Array(slugs))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		post := &models.Post{}
+
+		timeTmp := time.Time{}
+
+		err = rows.Scan(
+			&post.ID,
+			&post.Parent,
+			&post.Author.Nickname,
+			&post.Message,
+			&post.IsEdited,
+			&post.Forum,
+			&post.Thread,
+			&timeTmp)
+		if err != nil {
+			return err
+		}
+
+		post.Created = timeTmp.Format(time.RFC3339Nano)
+
+		err = write(&models.TransferRecord{Kind: pkg.TransferPost, Post: post})
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func exportVotes(ctx context.Context, tx *sql.Tx, slugs []string, write func(record *models.TransferRecord) error) error {
+	rows, err := tx.QueryContext(ctx, `SELECT v.nickname, v.thread_id, v.voice
+		FROM user_votes v
+			JOIN threads t ON t.thread_id = v.thread_id
+		WHERE t.forum = ANY ($1::citext[])
+		ORDER BY v.thread_id, v.nickname;`, pq.Array(slugs))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		vote := &models.Vote{}
+
+		err = rows.Scan(
+			&vote.Nickname,
+			&vote.Thread,
+			&vote.Voice)
+		if err != nil {
+			return err
+		}
+
+		err = write(&models.TransferRecord{Kind: pkg.TransferVote, Vote: vote})
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// importer inserts records in one transaction. Counters, post paths and user_forums are maintained by the
+// triggers, as for data created through the API; events and webhooks are switched off for the transaction.
+type importer struct {
+	tx      *sql.Tx
+	remap   bool
+	threads map[int64]int64
+	posts   map[int64]int64
+	stats   *models.TransferStats
+}
+
+// Import reads records until io.EOF. Users who already exist are kept as they are; any other conflict aborts
+// the whole import. With remap, threads and posts get new ids and references between them are rewritten,
+// otherwise they keep their ids and the sequences are moved past them.
+func (t transferPostgres) Import(ctx context.Context, next func() (*models.TransferRecord, error), remap bool) (*models.TransferStats, error) {
+	imp := &importer{
+		remap:   remap,
+		threads: make(map[int64]int64),
+		posts:   make(map[int64]int64),
+		stats:   &models.TransferStats{},
+	}
+
+	err := sqltools.RunTxOnConn(ctx, pkg.TxInsertOptions, t.conn, func(ctx context.Context, tx *sql.Tx) error {
+		imp.tx = tx
+
+		_, err := tx.ExecContext(ctx, `SELECT set_config('forum.importing', 'on', true);`)
+		if err != nil {
+			return err
+		}
+
+		var count int64
+
+		ended := false
+
+		for {
+			record, err := next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return err
+			}
+
+			if ended {
+				return errors.Wrap(pkg.ErrImportInvalid, "record after the end")
+			}
+
+			if record.Kind == pkg.TransferEnd {
+				if record.Count != count {
+					return pkg.ErrImportTruncated
+				}
+
+				ended = true
+
+				continue
+			}
+
+			count++
+
+			err = imp.insert(ctx, record)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !ended {
+			return pkg.ErrImportTruncated
+		}
+
+		if !remap {
+			_, err = tx.ExecContext(ctx, `SELECT setval(pg_get_serial_sequence('threads', 'thread_id'), GREATEST((SELECT max(thread_id) FROM threads), 1)),
+				setval(pg_get_serial_sequence('posts', 'post_id'), GREATEST((SELECT max(post_id) FROM posts), 1));`)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if sqltools.IsUniqueViolation(err) || sqltools.IsForeignKeyViolation(err) {
+		return nil, errors.WithMessage(pkg.ErrImportConflict, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return imp.stats, nil
+}
+
+func (i *importer) insert(ctx context.Context, record *models.TransferRecord) error {
+	switch {
+	case record.Kind == pkg.TransferUser && record.User != nil:
+		return i.insertUser(ctx, record.User)
+	case record.Kind == pkg.TransferForum && record.Forum != nil:
+		return i.insertForum(ctx, record.Forum)
+	case record.Kind == pkg.TransferMember && record.Member != nil:
+		return i.insertMember(ctx, record.Member)
+	case record.Kind == pkg.TransferThread && record.Thread != nil:
+		return i.insertThread(ctx, record.Thread)
+	case record.Kind == pkg.TransferPost && record.Post != nil:
+		return i.insertPost(ctx, record.Post)
+	case record.Kind == pkg.TransferVote && record.Vote != nil:
+		return i.insertVote(ctx, record.Vote)
+	default:
+		return errors.Wrap(pkg.ErrImportInvalid, record.Kind)
+	}
+}
+
+func (i *importer) insertUser(ctx context.Context, user *models.User) error {
+	result, err := i.tx.ExecContext(ctx, `INSERT INTO users(nickname, fullname, about, email)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (nickname) DO NOTHING;`, user.Nickname, user.FullName, user.About, user.Email)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		i.stats.SkippedUsers++
+	} else {
+		i.stats.Users++
+	}
+
+	return nil
+}
+
+func (i *importer) insertForum(ctx context.Context, forum *models.Forum) error {
+	_, err := i.tx.ExecContext(ctx, `INSERT INTO forums(title, users_nickname, slug, parent, roll_up, visibility)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6);`, forum.Title, forum.User, forum.Slug, forum.Parent, forum.RollUp, forum.Visibility)
+	if err != nil {
+		return err
+	}
+
+	i.stats.Forums++
+
+	return nil
+}
+
+func (i *importer) insertMember(ctx context.Context, member *models.ForumMember) error {
+	_, err := i.tx.ExecContext(ctx, `INSERT INTO forum_members(forum, nickname, status, invited_by)
+		VALUES ($1, $2, $3, NULLIF($4, ''));`, member.Forum, member.Nickname, member.Status, member.InvitedBy)
+	if err != nil {
+		return err
+	}
+
+	i.stats.Members++
+
+	return nil
+}
+
+func (i *importer) insertThread(ctx context.Context, thread *models.Thread) error {
+	var id int64
+
+	var row *sql.Row
+
+	if i.remap {
+		row = i.tx.QueryRowContext(ctx, `INSERT INTO threads(title, author, forum, message, slug, created)
+			VALUES ($1, $2, $3, $4, $5, $6) RETURNING thread_id;`,
+			thread.Title, thread.Author, thread.Forum, thread.Message, thread.Slug, thread.Created)
+	} else {
+		row = i.tx.QueryRowContext(ctx, `INSERT INTO threads(thread_id, title, author, forum, message, slug, created)
+			VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING thread_id;`,
+			thread.ID, thread.Title, thread.Author, thread.Forum, thread.Message, thread.Slug, thread.Created)
+	}
+
+	err := row.Scan(&id)
+	if err != nil {
+		return err
+	}
+
+	i.threads[thread.ID] = id
+	i.stats.Threads++
+
+	return nil
+}
+
+func (i *importer) insertPost(ctx context.Context, post *models.Post) error {
+	thread, ok := i.threads[post.Thread]
+	if !ok {
+		return errors.Wrap(pkg.ErrImportInvalid, "post of a thread not imported")
+	}
+
+	var parent int64
+
+	if post.Parent != 0 {
+		parent, ok = i.posts[post.Parent]
+		if !ok {
+			return errors.Wrap(pkg.ErrImportInvalid, "answer to a post not imported")
+		}
+	}
+
+	var id int64
+
+	var row *sql.Row
+
+	// The path is built by the trigger from the parent, which is already in place
+	if i.remap {
+		row = i.tx.QueryRowContext(ctx, `INSERT INTO posts(parent, author, message, is_edited, forum, thread_id, created)
+			VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING post_id;`,
+			parent, post.Author.Nickname, post.Message, post.IsEdited, post.Forum, thread, post.Created)
+	} else {
+		row = i.tx.QueryRowContext(ctx, `INSERT INTO posts(post_id, parent, author, message, is_edited, forum, thread_id, created)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING post_id;`,
+			post.ID, parent, post.Author.Nickname, post.Message, post.IsEdited, post.Forum, thread, post.Created)
+	}
+
+	err := row.Scan(&id)
+	if err != nil {
+		return err
+	}
+
+	i.posts[post.ID] = id
+	i.stats.Posts++
+
+	return nil
+}
+
+// insertVote lets the triggers add the voice to the thread rating, which is why threads are imported unrated.
+func (i *importer) insertVote(ctx context.Context, vote *models.Vote) error {
+	thread, ok := i.threads[vote.Thread]
+	if !ok {
+		return errors.Wrap(pkg.ErrImportInvalid, "vote for a thread not imported")
+	}
+
+	_, err := i.tx.ExecContext(ctx, `INSERT INTO user_votes(nickname, thread_id, voice)
+		VALUES ($1, $2, $3);`, vote.Nickname, thread, vote.Voice)
+	if err != nil {
+		return err
+	}
+
+	i.stats.Votes++
+
+	return nil
+}
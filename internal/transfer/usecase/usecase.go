@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"project/internal/models"
+	"project/internal/pkg"
+	"project/internal/pkg/cache"
+	repoTransfer "project/internal/transfer/repository"
+)
+
+type TransferService interface {
+	Export(ctx context.Context, forum *models.Forum, write func(record *models.TransferRecord) error) error
+	Import(ctx context.Context, next func() (*models.TransferRecord, error), ids string) (*models.TransferStats, error)
+}
+
+type transferService struct {
+	transferRepo repoTransfer.TransferRepository
+	caches       *cache.Set
+}
+
+func NewTransferService(r repoTransfer.TransferRepository, caches *cache.Set) TransferService {
+	return &transferService{
+		transferRepo: r,
+		caches:       caches,
+	}
+}
+
+func (t transferService) Export(ctx context.Context, forum *models.Forum, write func(record *models.TransferRecord) error) error {
+	err := t.transferRepo.Export(ctx, forum, write)
+	if err != nil {
+		return errors.Wrap(err, "Export")
+	}
+
+	return nil
+}
+
+// Import purges the caches of this process only, others serve their stale counters until they expire.
+func (t transferService) Import(ctx context.Context, next func() (*models.TransferRecord, error), ids string) (*models.TransferStats, error) {
+	var remap bool
+
+	switch ids {
+	case "", pkg.TransferKeepIDs:
+	case pkg.TransferRemapIDs:
+		remap = true
+	default:
+		return nil, errors.Wrap(pkg.ErrBadRequestParams, "Import")
+	}
+
+	res, err := t.transferRepo.Import(ctx, next, remap)
+
+	// Imported rows change the counters of existing forums and users, even when the import fails half way
+	t.caches.Purge()
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Import")
+	}
+
+	return res, nil
+}
@@ -0,0 +1,64 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"project/internal/models"
+	"project/internal/pb"
+	"project/internal/pkg"
+	"project/internal/pkg/grpctools"
+	"project/internal/user/usecase"
+)
+
+type UserServer struct {
+	pb.UnimplementedUserServiceServer
+
+	userUsecase usecase.UserService
+}
+
+func (s *UserServer) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.User, error) {
+	users, err := s.userUsecase.CreateUser(ctx, req.GetUser().GetModel())
+	if err != nil {
+		if errors.Is(errors.Cause(err), pkg.ErrSuchUserExist) {
+			conflicts := make([]proto.Message, len(users))
+			for idx := range users {
+				conflicts[idx] = pb.NewUser(&users[idx])
+			}
+
+			return nil, grpctools.Error(err, conflicts...)
+		}
+
+		return nil, grpctools.Error(err)
+	}
+
+	return pb.NewUser(&users[0]), nil
+}
+
+func (s *UserServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error) {
+	user, err := s.userUsecase.GetProfile(ctx, &models.User{Nickname: req.GetNickname()})
+	if err != nil {
+		return nil, grpctools.Error(err)
+	}
+
+	return pb.NewUser(&user), nil
+}
+
+func (s *UserServer) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.User, error) {
+	user, err := s.userUsecase.UpdateProfile(ctx, req.GetUser().GetModel())
+	if err != nil {
+		if errors.Is(errors.Cause(err), pkg.ErrVersionConflict) {
+			return nil, grpctools.Error(err, pb.NewUser(&user))
+		}
+
+		return nil, grpctools.Error(err)
+	}
+
+	return pb.NewUser(&user), nil
+}
+
+func NewUserServer(userUsecase usecase.UserService) *UserServer {
+	return &UserServer{userUsecase: userUsecase}
+}
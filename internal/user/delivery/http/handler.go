@@ -42,6 +42,36 @@ func (h *UserHandler) CreateUserHandler(w http.ResponseWriter, r *http.Request)
 	pkg.Response(r.Context(), w, http.StatusCreated, response)
 }
 
+func (h *UserHandler) CreateUsersHandler(w http.ResponseWriter, r *http.Request) {
+	request := models.NewUsersBatchCreateRequest()
+
+	err := request.Bind(r)
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	results, err := h.userUsecase.CreateUsers(r.Context(), request.GetUsers())
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	// 201 only if every user was created, the items tell which ones were not
+	status := http.StatusCreated
+
+	for idx := range results {
+		if !results[idx].Created {
+			status = http.StatusOK
+			break
+		}
+	}
+
+	response := models.NewUsersBatchCreateResponse(results)
+
+	pkg.Response(r.Context(), w, status, response)
+}
+
 func (h *UserHandler) GetProfileHandler(w http.ResponseWriter, r *http.Request) {
 	request := models.NewProfileGetRequest()
 
@@ -65,6 +95,14 @@ func (h *UserHandler) UpdateProfileHandler(w http.ResponseWriter, r *http.Reques
 
 	user, err := h.userUsecase.UpdateProfile(r.Context(), request.GetUser())
 	if err != nil {
+		if errors.Is(errors.Cause(err), pkg.ErrVersionConflict) {
+			response := models.NewProfileUpdateResponse(&user)
+
+			pkg.Response(r.Context(), w, http.StatusConflict, response)
+
+			return
+		}
+
 		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
 		return
 	}
@@ -14,7 +14,7 @@ import (
 
 //easyjson:json
 type UserCreateRequest struct {
-	Nickname string
+	Nickname string `json:"nickname"`
 	FullName string `json:"fullname"`
 	About    string `json:"about"`
 	Email    string `json:"email"`
@@ -33,10 +33,6 @@ func (req *UserCreateRequest) Bind(r *http.Request) error {
 	//	return pkg.ErrUnsupportedMediaType
 	// }
 
-	vars := mux.Vars(r)
-
-	req.Nickname = vars["nickname"]
-
 	body, _ := io.ReadAll(r.Body)
 	// if err != nil {
 	//	return pkg.ErrBadBodyRequest
@@ -58,6 +54,11 @@ func (req *UserCreateRequest) Bind(r *http.Request) error {
 	//	return pkg.ErrJSONUnexpectedEnd
 	// }
 
+	// The nickname of the path wins over the one of the body
+	vars := mux.Vars(r)
+
+	req.Nickname = vars["nickname"]
+
 	return nil
 }
 
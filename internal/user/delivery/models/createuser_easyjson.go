@@ -17,7 +17,7 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjson30bd9105DecodeDbPerformanceProjectInternalUserDeliveryModels(in *jlexer.Lexer, out *UsersList) {
+func easyjson30bd9105DecodeProjectInternalUserDeliveryModels(in *jlexer.Lexer, out *UsersList) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		in.Skip()
@@ -45,7 +45,7 @@ func easyjson30bd9105DecodeDbPerformanceProjectInternalUserDeliveryModels(in *jl
 		in.Consumed()
 	}
 }
-func easyjson30bd9105EncodeDbPerformanceProjectInternalUserDeliveryModels(out *jwriter.Writer, in UsersList) {
+func easyjson30bd9105EncodeProjectInternalUserDeliveryModels(out *jwriter.Writer, in UsersList) {
 	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 		out.RawString("null")
 	} else {
@@ -63,27 +63,27 @@ func easyjson30bd9105EncodeDbPerformanceProjectInternalUserDeliveryModels(out *j
 // MarshalJSON supports json.Marshaler interface
 func (v UsersList) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson30bd9105EncodeDbPerformanceProjectInternalUserDeliveryModels(&w, v)
+	easyjson30bd9105EncodeProjectInternalUserDeliveryModels(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v UsersList) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson30bd9105EncodeDbPerformanceProjectInternalUserDeliveryModels(w, v)
+	easyjson30bd9105EncodeProjectInternalUserDeliveryModels(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *UsersList) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson30bd9105DecodeDbPerformanceProjectInternalUserDeliveryModels(&r, v)
+	easyjson30bd9105DecodeProjectInternalUserDeliveryModels(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *UsersList) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson30bd9105DecodeDbPerformanceProjectInternalUserDeliveryModels(l, v)
+	easyjson30bd9105DecodeProjectInternalUserDeliveryModels(l, v)
 }
-func easyjson30bd9105DecodeDbPerformanceProjectInternalUserDeliveryModels1(in *jlexer.Lexer, out *UserCreateResponse) {
+func easyjson30bd9105DecodeProjectInternalUserDeliveryModels1(in *jlexer.Lexer, out *UserCreateResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -124,7 +124,7 @@ func easyjson30bd9105DecodeDbPerformanceProjectInternalUserDeliveryModels1(in *j
 		in.Consumed()
 	}
 }
-func easyjson30bd9105EncodeDbPerformanceProjectInternalUserDeliveryModels1(out *jwriter.Writer, in UserCreateResponse) {
+func easyjson30bd9105EncodeProjectInternalUserDeliveryModels1(out *jwriter.Writer, in UserCreateResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -170,27 +170,27 @@ func easyjson30bd9105EncodeDbPerformanceProjectInternalUserDeliveryModels1(out *
 // MarshalJSON supports json.Marshaler interface
 func (v UserCreateResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson30bd9105EncodeDbPerformanceProjectInternalUserDeliveryModels1(&w, v)
+	easyjson30bd9105EncodeProjectInternalUserDeliveryModels1(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v UserCreateResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson30bd9105EncodeDbPerformanceProjectInternalUserDeliveryModels1(w, v)
+	easyjson30bd9105EncodeProjectInternalUserDeliveryModels1(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *UserCreateResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson30bd9105DecodeDbPerformanceProjectInternalUserDeliveryModels1(&r, v)
+	easyjson30bd9105DecodeProjectInternalUserDeliveryModels1(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *UserCreateResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson30bd9105DecodeDbPerformanceProjectInternalUserDeliveryModels1(l, v)
+	easyjson30bd9105DecodeProjectInternalUserDeliveryModels1(l, v)
 }
-func easyjson30bd9105DecodeDbPerformanceProjectInternalUserDeliveryModels2(in *jlexer.Lexer, out *UserCreateRequest) {
+func easyjson30bd9105DecodeProjectInternalUserDeliveryModels2(in *jlexer.Lexer, out *UserCreateRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -209,7 +209,7 @@ func easyjson30bd9105DecodeDbPerformanceProjectInternalUserDeliveryModels2(in *j
 			continue
 		}
 		switch key {
-		case "Nickname":
+		case "nickname":
 			out.Nickname = string(in.String())
 		case "fullname":
 			out.FullName = string(in.String())
@@ -231,12 +231,12 @@ func easyjson30bd9105DecodeDbPerformanceProjectInternalUserDeliveryModels2(in *j
 		in.Consumed()
 	}
 }
-func easyjson30bd9105EncodeDbPerformanceProjectInternalUserDeliveryModels2(out *jwriter.Writer, in UserCreateRequest) {
+func easyjson30bd9105EncodeProjectInternalUserDeliveryModels2(out *jwriter.Writer, in UserCreateRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	if in.Nickname != "" {
-		const prefix string = ",\"Nickname\":"
+		const prefix string = ",\"nickname\":"
 		first = false
 		out.RawString(prefix[1:])
 		out.String(string(in.Nickname))
@@ -277,23 +277,23 @@ func easyjson30bd9105EncodeDbPerformanceProjectInternalUserDeliveryModels2(out *
 // MarshalJSON supports json.Marshaler interface
 func (v UserCreateRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson30bd9105EncodeDbPerformanceProjectInternalUserDeliveryModels2(&w, v)
+	easyjson30bd9105EncodeProjectInternalUserDeliveryModels2(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v UserCreateRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson30bd9105EncodeDbPerformanceProjectInternalUserDeliveryModels2(w, v)
+	easyjson30bd9105EncodeProjectInternalUserDeliveryModels2(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *UserCreateRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson30bd9105DecodeDbPerformanceProjectInternalUserDeliveryModels2(&r, v)
+	easyjson30bd9105DecodeProjectInternalUserDeliveryModels2(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *UserCreateRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson30bd9105DecodeDbPerformanceProjectInternalUserDeliveryModels2(l, v)
+	easyjson30bd9105DecodeProjectInternalUserDeliveryModels2(l, v)
 }
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/mailru/easyjson"
+
+	"project/internal/models"
+	"project/internal/pkg"
+)
+
+//go:generate easyjson -disallow_unknown_fields -omit_empty createusers.go
+
+const maxBatchUsers = 10000
+
+//easyjson:json
+type UsersCreateRequestList []UserCreateRequest
+
+type UsersBatchCreateRequest struct {
+	Users UsersCreateRequestList
+}
+
+func NewUsersBatchCreateRequest() *UsersBatchCreateRequest {
+	return &UsersBatchCreateRequest{}
+}
+
+func (req *UsersBatchCreateRequest) Bind(r *http.Request) error {
+	body, _ := io.ReadAll(r.Body)
+
+	err := easyjson.Unmarshal(body, &req.Users)
+	if err != nil {
+		return pkg.ErrBadBodyRequest
+	}
+
+	if len(req.Users) == 0 {
+		return pkg.ErrEmptyBody
+	}
+
+	if len(req.Users) > maxBatchUsers {
+		return pkg.ErrBigRequest
+	}
+
+	return nil
+}
+
+func (req *UsersBatchCreateRequest) GetUsers() []*models.User {
+	res := make([]*models.User, len(req.Users))
+
+	for idx := range req.Users {
+		res[idx] = req.Users[idx].GetUser()
+	}
+
+	return res
+}
+
+//easyjson:json
+type UserBatchCreateResponse struct {
+	Status    string             `json:"status"`
+	User      UserCreateResponse `json:"user"`
+	Conflicts UsersList          `json:"conflicts,omitempty"`
+}
+
+//easyjson:json
+type UsersBatchCreateResponse []UserBatchCreateResponse
+
+// NewUsersBatchCreateResponse lists the outcome of each item in request order. Conflicting items carry the
+// existing users they collide with, like the 409 of a single create.
+func NewUsersBatchCreateResponse(results []models.UserCreateResult) UsersBatchCreateResponse {
+	res := make([]UserBatchCreateResponse, len(results))
+
+	for idx := range results {
+		res[idx] = UserBatchCreateResponse{
+			Status: pkg.UserCreateStatusCreated,
+			User:   *NewUserCreateResponse(&results[idx].User),
+		}
+
+		if !results[idx].Created {
+			res[idx].Status = pkg.UserCreateStatusConflict
+			res[idx].Conflicts = NewUsersCreateResponse(results[idx].Conflicts)
+		}
+	}
+
+	return res
+}
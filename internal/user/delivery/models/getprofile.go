@@ -46,6 +46,7 @@ type ProfileGetResponse struct {
 	FullName string `json:"fullname"`
 	About    string `json:"about"`
 	Email    string `json:"email"`
+	Version  int64  `json:"version,omitempty"`
 }
 
 func NewProfileGetResponse(user *models.User) *ProfileGetResponse {
@@ -54,5 +55,6 @@ func NewProfileGetResponse(user *models.User) *ProfileGetResponse {
 		FullName: user.FullName,
 		About:    user.About,
 		Email:    user.Email,
+		Version:  user.Version,
 	}
 }
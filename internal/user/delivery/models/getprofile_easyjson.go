@@ -17,7 +17,7 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjson412b83ebDecodeDbPerformanceProjectInternalUserDeliveryModels(in *jlexer.Lexer, out *ProfileGetResponse) {
+func easyjson412b83ebDecodeProjectInternalUserDeliveryModels(in *jlexer.Lexer, out *ProfileGetResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -44,6 +44,8 @@ func easyjson412b83ebDecodeDbPerformanceProjectInternalUserDeliveryModels(in *jl
 			out.About = string(in.String())
 		case "email":
 			out.Email = string(in.String())
+		case "version":
+			out.Version = int64(in.Int64())
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -58,7 +60,7 @@ func easyjson412b83ebDecodeDbPerformanceProjectInternalUserDeliveryModels(in *jl
 		in.Consumed()
 	}
 }
-func easyjson412b83ebEncodeDbPerformanceProjectInternalUserDeliveryModels(out *jwriter.Writer, in ProfileGetResponse) {
+func easyjson412b83ebEncodeProjectInternalUserDeliveryModels(out *jwriter.Writer, in ProfileGetResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -98,29 +100,39 @@ func easyjson412b83ebEncodeDbPerformanceProjectInternalUserDeliveryModels(out *j
 		}
 		out.String(string(in.Email))
 	}
+	if in.Version != 0 {
+		const prefix string = ",\"version\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Version))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v ProfileGetResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson412b83ebEncodeDbPerformanceProjectInternalUserDeliveryModels(&w, v)
+	easyjson412b83ebEncodeProjectInternalUserDeliveryModels(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ProfileGetResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson412b83ebEncodeDbPerformanceProjectInternalUserDeliveryModels(w, v)
+	easyjson412b83ebEncodeProjectInternalUserDeliveryModels(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ProfileGetResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson412b83ebDecodeDbPerformanceProjectInternalUserDeliveryModels(&r, v)
+	easyjson412b83ebDecodeProjectInternalUserDeliveryModels(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ProfileGetResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson412b83ebDecodeDbPerformanceProjectInternalUserDeliveryModels(l, v)
+	easyjson412b83ebDecodeProjectInternalUserDeliveryModels(l, v)
 }
@@ -17,6 +17,7 @@ type ProfileUpdateRequest struct {
 	FullName string `json:"fullname"`
 	About    string `json:"about"`
 	Email    string `json:"email"`
+	Version  int64  `json:"version"`
 }
 
 func NewProfileUpdateRequest() *ProfileUpdateRequest {
@@ -66,6 +67,7 @@ func (req *ProfileUpdateRequest) GetUser() *models.User {
 		FullName: req.FullName,
 		About:    req.About,
 		Email:    req.Email,
+		Version:  req.Version,
 	}
 }
 
@@ -74,6 +76,7 @@ type ProfileUpdateResponse struct {
 	FullName string `json:"fullname"`
 	About    string `json:"about"`
 	Email    string `json:"email"`
+	Version  int64  `json:"version,omitempty"`
 }
 
 func NewProfileUpdateResponse(user *models.User) *ProfileUpdateResponse {
@@ -82,5 +85,6 @@ func NewProfileUpdateResponse(user *models.User) *ProfileUpdateResponse {
 		FullName: user.FullName,
 		About:    user.About,
 		Email:    user.Email,
+		Version:  user.Version,
 	}
 }
@@ -17,7 +17,7 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjson672e23fcDecodeDbPerformanceProjectInternalUserDeliveryModels(in *jlexer.Lexer, out *ProfileUpdateResponse) {
+func easyjson672e23fcDecodeProjectInternalUserDeliveryModels(in *jlexer.Lexer, out *ProfileUpdateResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -44,6 +44,8 @@ func easyjson672e23fcDecodeDbPerformanceProjectInternalUserDeliveryModels(in *jl
 			out.About = string(in.String())
 		case "email":
 			out.Email = string(in.String())
+		case "version":
+			out.Version = int64(in.Int64())
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -58,7 +60,7 @@ func easyjson672e23fcDecodeDbPerformanceProjectInternalUserDeliveryModels(in *jl
 		in.Consumed()
 	}
 }
-func easyjson672e23fcEncodeDbPerformanceProjectInternalUserDeliveryModels(out *jwriter.Writer, in ProfileUpdateResponse) {
+func easyjson672e23fcEncodeProjectInternalUserDeliveryModels(out *jwriter.Writer, in ProfileUpdateResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -98,33 +100,43 @@ func easyjson672e23fcEncodeDbPerformanceProjectInternalUserDeliveryModels(out *j
 		}
 		out.String(string(in.Email))
 	}
+	if in.Version != 0 {
+		const prefix string = ",\"version\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Version))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v ProfileUpdateResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson672e23fcEncodeDbPerformanceProjectInternalUserDeliveryModels(&w, v)
+	easyjson672e23fcEncodeProjectInternalUserDeliveryModels(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ProfileUpdateResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson672e23fcEncodeDbPerformanceProjectInternalUserDeliveryModels(w, v)
+	easyjson672e23fcEncodeProjectInternalUserDeliveryModels(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ProfileUpdateResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson672e23fcDecodeDbPerformanceProjectInternalUserDeliveryModels(&r, v)
+	easyjson672e23fcDecodeProjectInternalUserDeliveryModels(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ProfileUpdateResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson672e23fcDecodeDbPerformanceProjectInternalUserDeliveryModels(l, v)
+	easyjson672e23fcDecodeProjectInternalUserDeliveryModels(l, v)
 }
-func easyjson672e23fcDecodeDbPerformanceProjectInternalUserDeliveryModels1(in *jlexer.Lexer, out *ProfileUpdateRequest) {
+func easyjson672e23fcDecodeProjectInternalUserDeliveryModels1(in *jlexer.Lexer, out *ProfileUpdateRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -151,6 +163,8 @@ func easyjson672e23fcDecodeDbPerformanceProjectInternalUserDeliveryModels1(in *j
 			out.About = string(in.String())
 		case "email":
 			out.Email = string(in.String())
+		case "version":
+			out.Version = int64(in.Int64())
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -165,7 +179,7 @@ func easyjson672e23fcDecodeDbPerformanceProjectInternalUserDeliveryModels1(in *j
 		in.Consumed()
 	}
 }
-func easyjson672e23fcEncodeDbPerformanceProjectInternalUserDeliveryModels1(out *jwriter.Writer, in ProfileUpdateRequest) {
+func easyjson672e23fcEncodeProjectInternalUserDeliveryModels1(out *jwriter.Writer, in ProfileUpdateRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -205,29 +219,39 @@ func easyjson672e23fcEncodeDbPerformanceProjectInternalUserDeliveryModels1(out *
 		}
 		out.String(string(in.Email))
 	}
+	if in.Version != 0 {
+		const prefix string = ",\"version\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Version))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v ProfileUpdateRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson672e23fcEncodeDbPerformanceProjectInternalUserDeliveryModels1(&w, v)
+	easyjson672e23fcEncodeProjectInternalUserDeliveryModels1(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ProfileUpdateRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson672e23fcEncodeDbPerformanceProjectInternalUserDeliveryModels1(w, v)
+	easyjson672e23fcEncodeProjectInternalUserDeliveryModels1(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ProfileUpdateRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson672e23fcDecodeDbPerformanceProjectInternalUserDeliveryModels1(&r, v)
+	easyjson672e23fcDecodeProjectInternalUserDeliveryModels1(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ProfileUpdateRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson672e23fcDecodeDbPerformanceProjectInternalUserDeliveryModels1(l, v)
+	easyjson672e23fcDecodeProjectInternalUserDeliveryModels1(l, v)
 }
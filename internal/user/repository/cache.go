@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"project/internal/models"
+	"project/internal/pkg"
+	"project/internal/pkg/cache"
+)
+
+// UserCache serves GetUserByNickname from the cache, except to reads which must see the latest state. Misses are
+// read from the primary, so that a lagging replica does not put back what was invalidated. Nicknames are
+// case-insensitive, so are the keys.
+type UserCache struct {
+	UserRepository
+
+	users cache.Cache[models.User]
+}
+
+func NewUserCache(repo UserRepository, users cache.Cache[models.User]) *UserCache {
+	return &UserCache{
+		UserRepository: repo,
+		users:          users,
+	}
+}
+
+func (u *UserCache) GetUserByNickname(ctx context.Context, user *models.User) (models.User, error) {
+	key := strings.ToLower(user.Nickname)
+
+	if !pkg.ReadsPrimary(ctx) {
+		cached, ok := u.users.Get(key)
+		if ok {
+			return cached, nil
+		}
+	}
+
+	res, err := u.UserRepository.GetUserByNickname(pkg.WithPrimaryReads(ctx), user)
+	if err != nil {
+		return res, err
+	}
+
+	u.users.Set(key, res)
+
+	return res, nil
+}
+
+func (u *UserCache) UpdateUser(ctx context.Context, user *models.User) (models.User, error) {
+	res, err := u.UserRepository.UpdateUser(ctx, user)
+	if err != nil {
+		return res, err
+	}
+
+	u.users.Delete(strings.ToLower(res.Nickname))
+
+	return res, nil
+}
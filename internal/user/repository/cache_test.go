@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"project/internal/models"
+	"project/internal/pkg"
+	"project/internal/pkg/cache"
+)
+
+// stubUsers answers GetUserByNickname with a fixed version, counting the calls.
+type stubUsers struct {
+	UserRepository
+
+	version int64
+	calls   int
+	replica int
+}
+
+func (s *stubUsers) GetUserByNickname(ctx context.Context, user *models.User) (models.User, error) {
+	s.calls++
+
+	if !pkg.ReadsPrimary(ctx) {
+		s.replica++
+	}
+
+	return models.User{Nickname: user.Nickname, Version: s.version}, nil
+}
+
+func TestUserCacheGetUserByNickname(t *testing.T) {
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		wantVersion int64
+		wantCalls   int
+	}{
+		{name: "cached", ctx: context.Background(), wantVersion: 1, wantCalls: 1},
+		{name: "primary reads skip the cache", ctx: pkg.WithPrimaryReads(context.Background()), wantVersion: 2, wantCalls: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &stubUsers{version: 1}
+			users := NewUserCache(repo, cache.NewLRU[models.User](cache.DefaultCapacity, cache.DefaultTTL))
+
+			_, _ = users.GetUserByNickname(context.Background(), &models.User{Nickname: "Alice"})
+
+			repo.version = 2
+
+			got, err := users.GetUserByNickname(tt.ctx, &models.User{Nickname: "alice"})
+			if err != nil {
+				t.Fatalf("GetUserByNickname() error = %v", err)
+			}
+
+			if got.Version != tt.wantVersion || repo.calls != tt.wantCalls {
+				t.Errorf("GetUserByNickname() = version %d after %d calls, want %d after %d",
+					got.Version, repo.calls, tt.wantVersion, tt.wantCalls)
+			}
+
+			if repo.replica != 0 {
+				t.Errorf("GetUserByNickname() filled the cache from a replica %d times", repo.replica)
+			}
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 
+	"github.com/lib/pq"
+
 	"github.com/pkg/errors"
 
 	"project/internal/models"
@@ -17,13 +19,15 @@ type UserRepository interface {
 	GetUserByEmailOrNickname(ctx context.Context, user *models.User) ([]models.User, error)
 	GetUserByNickname(ctx context.Context, user *models.User) (models.User, error)
 	UpdateUser(ctx context.Context, user *models.User) (models.User, error)
+	CreateUsers(ctx context.Context, users []*models.User) ([]models.User, []models.User, error)
+	GetUsersByNicknames(ctx context.Context, nicknames []string) ([]models.User, error)
 }
 
 type userPostgres struct {
-	conn *sql.DB
+	conn *sqltools.Cluster
 }
 
-func NewUserPostgres(conn *sql.DB) UserRepository {
+func NewUserPostgres(conn *sqltools.Cluster) UserRepository {
 	return &userPostgres{
 		conn,
 	}
@@ -32,7 +36,7 @@ func NewUserPostgres(conn *sql.DB) UserRepository {
 func (u userPostgres) CheckFreeEmail(ctx context.Context, user *models.User) (bool, error) {
 	res := false
 
-	row := u.conn.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM users WHERE email = $1);`, user.Email)
+	row := u.conn.Primary(ctx).QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM users WHERE email = $1);`, user.Email)
 	if row.Err() != nil {
 		return false, row.Err()
 	}
@@ -46,14 +50,14 @@ func (u userPostgres) CheckFreeEmail(ctx context.Context, user *models.User) (bo
 }
 
 func (u userPostgres) CreateUser(ctx context.Context, user *models.User) (models.User, error) {
-	err := sqltools.RunTxOnConn(ctx, pkg.TxInsertOptions, u.conn, func(ctx context.Context, tx *sql.Tx) error {
-		row := tx.QueryRowContext(ctx, `INSERT INTO users(nickname, fullname, about, email)
+	err := sqltools.RunTxOnConn(ctx, pkg.TxInsertOptions, u.conn.Write(ctx), func(ctx context.Context, tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `INSERT INTO users(nickname, fullname, about, email)
 			VALUES ($1, $2, $3, $4);`, user.Nickname, user.FullName, user.About, user.Email)
-		if row.Err() != nil {
-			return row.Err()
+		if err != nil {
+			return err
 		}
 
-		return nil
+		return u.auditCreated(ctx, tx, []models.User{*user})
 	})
 	if err != nil {
 		return models.User{}, err
@@ -65,7 +69,7 @@ func (u userPostgres) CreateUser(ctx context.Context, user *models.User) (models
 func (u userPostgres) GetUserByEmailOrNickname(ctx context.Context, user *models.User) ([]models.User, error) {
 	res := make([]models.User, 0)
 
-	row, err := u.conn.QueryContext(ctx, `SELECT nickname, fullname, about, email
+	row, err := u.conn.Primary(ctx).QueryContext(ctx, `SELECT nickname, fullname, about, email
 		FROM users
 		WHERE nickname = $1
 		   OR email = $2;`, user.Nickname, user.Email)
@@ -103,7 +107,7 @@ func (u userPostgres) GetUserByEmailOrNickname(ctx context.Context, user *models
 func (u userPostgres) GetUserByNickname(ctx context.Context, user *models.User) (models.User, error) {
 	res := models.User{}
 
-	row := u.conn.QueryRowContext(ctx, `SELECT fullname, about, email, nickname
+	row := u.conn.Replica(ctx).QueryRowContext(ctx, `SELECT fullname, about, email, nickname, version
 		FROM users
 		WHERE nickname = $1;`, user.Nickname)
 	if row.Err() != nil {
@@ -114,7 +118,8 @@ func (u userPostgres) GetUserByNickname(ctx context.Context, user *models.User)
 		&res.FullName,
 		&res.About,
 		&res.Email,
-		&res.Nickname)
+		&res.Nickname,
+		&res.Version)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return models.User{}, pkg.ErrSuchUserNotFound
@@ -126,28 +131,53 @@ func (u userPostgres) GetUserByNickname(ctx context.Context, user *models.User)
 	return res, nil
 }
 
+// UpdateUser updates the user if it still has the version of the given one, any version being fine when it is 0.
 func (u userPostgres) UpdateUser(ctx context.Context, user *models.User) (models.User, error) {
 	res := models.User{}
 
-	err := sqltools.RunTxOnConn(ctx, pkg.TxInsertOptions, u.conn, func(ctx context.Context, tx *sql.Tx) error {
+	err := sqltools.RunTxOnConn(ctx, pkg.TxInsertOptions, u.conn.Write(ctx), func(ctx context.Context, tx *sql.Tx) error {
+		before, err := sqltools.Snapshot(ctx, tx, userSnapshot+` FOR UPDATE`, pq.Array([]string{user.Nickname}))
+		if err != nil {
+			return err
+		}
+
 		row := tx.QueryRowContext(ctx, `UPDATE users
 			SET fullname = COALESCE(NULLIF(TRIM($1), ''), fullname),
 				about    = COALESCE(NULLIF(TRIM($2), ''), about),
 				email    = COALESCE(NULLIF(TRIM($3), ''), email)
-			WHERE nickname = $4 RETURNING fullname, about, email, nickname;`, user.FullName, user.About, user.Email, user.Nickname)
+			WHERE nickname = $4
+				AND ($5::bigint = 0 OR version = $5)
+			RETURNING fullname, about, email, nickname, version;`,
+			user.FullName, user.About, user.Email, user.Nickname, user.Version)
 		if row.Err() != nil {
 			return row.Err()
 		}
 
-		err := row.Scan(
+		err = row.Scan(
 			&res.FullName,
 			&res.About,
 			&res.Email,
-			&res.Nickname)
+			&res.Nickname,
+			&res.Version)
 		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) && user.Version != 0 {
+				return pkg.ErrVersionConflict
+			}
+
 			return err
 		}
-		return nil
+
+		_, err = tx.ExecContext(ctx, `SELECT function_emit_user_event($1, $2);`, pkg.EventUserUpdated, res.Nickname)
+		if err != nil {
+			return err
+		}
+
+		after, err := sqltools.Snapshot(ctx, tx, userSnapshot, pq.Array([]string{res.Nickname}))
+		if err != nil {
+			return err
+		}
+
+		return sqltools.Audit(ctx, tx, pkg.AuditEntityUser, pkg.AuditActionUpdate, before, after)
 	})
 
 	if err != nil {
@@ -156,3 +186,153 @@ func (u userPostgres) UpdateUser(ctx context.Context, user *models.User) (models
 
 	return res, nil
 }
+
+// userSnapshot selects the states of the users with the nicknames for the audit log
+const userSnapshot = `SELECT nickname::text, to_jsonb(u) FROM users u WHERE nickname = ANY ($1::citext[])`
+
+func (u userPostgres) auditCreated(ctx context.Context, tx *sql.Tx, created []models.User) error {
+	if len(created) == 0 {
+		return nil
+	}
+
+	nicknames := make([]string, len(created))
+	for idx, user := range created {
+		nicknames[idx] = user.Nickname
+	}
+
+	after, err := sqltools.Snapshot(ctx, tx, userSnapshot, pq.Array(nicknames))
+	if err != nil {
+		return err
+	}
+
+	return sqltools.Audit(ctx, tx, pkg.AuditEntityUser, pkg.AuditActionCreate, nil, after)
+}
+
+// createUsersChunk keeps a multi-row insert below the limit of bind parameters of a statement.
+const createUsersChunk = 1000
+
+// CreateUsers inserts the users which conflict with nobody and returns them, together with the existing users
+// the others conflict with. Earlier users of the batch count as existing for later ones.
+func (u userPostgres) CreateUsers(ctx context.Context, users []*models.User) ([]models.User, []models.User, error) {
+	created := make([]models.User, 0, len(users))
+	existing := make([]models.User, 0)
+
+	err := sqltools.RunTxOnConn(ctx, pkg.TxInsertOptions, u.conn.Write(ctx), func(ctx context.Context, tx *sql.Tx) error {
+		for start := 0; start < len(users); start += createUsersChunk {
+			end := start + createUsersChunk
+			if end > len(users) {
+				end = len(users)
+			}
+
+			chunk := users[start:end]
+
+			query := sqltools.CreateFullQuery(`INSERT INTO users(nickname, fullname, about, email) VALUES`, len(chunk), 4)
+			query += ` ON CONFLICT DO NOTHING RETURNING nickname, fullname, about, email;`
+
+			values := make([]interface{}, 0, len(chunk)*4)
+			for _, user := range chunk {
+				values = append(values, user.Nickname, user.FullName, user.About, user.Email)
+			}
+
+			rows, err := tx.QueryContext(ctx, query, values...)
+			if err != nil {
+				return err
+			}
+
+			for rows.Next() {
+				user := models.User{}
+
+				err = rows.Scan(
+					&user.Nickname,
+					&user.FullName,
+					&user.About,
+					&user.Email)
+				if err != nil {
+					rows.Close()
+					return err
+				}
+
+				created = append(created, user)
+			}
+
+			rows.Close()
+		}
+
+		err := u.auditCreated(ctx, tx, created)
+		if err != nil {
+			return err
+		}
+
+		if len(created) == len(users) {
+			return nil
+		}
+
+		nicknames := make([]string, 0, len(users))
+		emails := make([]string, 0, len(users))
+
+		for _, user := range users {
+			nicknames = append(nicknames, user.Nickname)
+			emails = append(emails, user.Email)
+		}
+
+		rows, err := tx.QueryContext(ctx, `SELECT nickname, fullname, about, email
+			FROM users
+			WHERE nickname = ANY ($1::citext[])
+			   OR email = ANY ($2::citext[]);`, pq.Array(nicknames), pq.Array(emails))
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			user := models.User{}
+
+			err = rows.Scan(
+				&user.Nickname,
+				&user.FullName,
+				&user.About,
+				&user.Email)
+			if err != nil {
+				return err
+			}
+
+			existing = append(existing, user)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return created, existing, nil
+}
+
+func (u userPostgres) GetUsersByNicknames(ctx context.Context, nicknames []string) ([]models.User, error) {
+	res := make([]models.User, 0, len(nicknames))
+
+	rows, err := u.conn.Replica(ctx).QueryContext(ctx, `SELECT nickname, fullname, about, email
+		FROM users
+		WHERE nickname = ANY ($1::citext[]);`, pq.Array(nicknames))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		user := models.User{}
+
+		err = rows.Scan(
+			&user.Nickname,
+			&user.FullName,
+			&user.About,
+			&user.Email)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, user)
+	}
+
+	return res, nil
+}
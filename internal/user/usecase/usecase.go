@@ -5,6 +5,7 @@ import (
 	"project/internal/models"
 	"project/internal/pkg"
 	"project/internal/user/repository"
+	"strings"
 
 	"github.com/pkg/errors"
 )
@@ -13,6 +14,8 @@ type UserService interface {
 	CreateUser(ctx context.Context, user *models.User) ([]models.User, error)
 	GetProfile(ctx context.Context, user *models.User) (models.User, error)
 	UpdateProfile(ctx context.Context, user *models.User) (models.User, error)
+	CreateUsers(ctx context.Context, users []*models.User) ([]models.UserCreateResult, error)
+	GetProfiles(ctx context.Context, nicknames []string) ([]models.User, error)
 }
 
 type userService struct {
@@ -50,21 +53,89 @@ func (u userService) GetProfile(ctx context.Context, user *models.User) (models.
 	return res, nil
 }
 
+// UpdateProfile returns the current state of the user along with ErrVersionConflict when the expected version of
+// the user is given and outdated.
 func (u userService) UpdateProfile(ctx context.Context, user *models.User) (models.User, error) {
-	_, err := u.userRepo.GetUserByNickname(ctx, user)
+	// The version is checked against the latest state
+	primary := pkg.WithPrimaryReads(ctx)
+
+	current, err := u.userRepo.GetUserByNickname(primary, user)
 	if err != nil {
 		return models.User{}, errors.Wrap(err, "UpdateUser")
 	}
 
+	if user.Version != 0 && user.Version != current.Version {
+		return current, errors.Wrap(pkg.ErrVersionConflict, "UpdateUser")
+	}
+
 	exist, _ := u.userRepo.CheckFreeEmail(ctx, user)
 	if exist {
 		return models.User{}, errors.Wrap(pkg.ErrUpdateUserDataConflict, "UpdateUser")
 	}
 
 	resUpdate, err := u.userRepo.UpdateUser(ctx, user)
+	if errors.Is(err, pkg.ErrVersionConflict) {
+		current, err = u.userRepo.GetUserByNickname(primary, user)
+		if err != nil {
+			return models.User{}, errors.Wrap(err, "UpdateUser")
+		}
+
+		return current, errors.Wrap(pkg.ErrVersionConflict, "UpdateUser")
+	}
 	if err != nil {
 		return models.User{}, errors.Wrap(err, "UpdateUser")
 	}
 
 	return resUpdate, nil
 }
+
+// GetProfiles returns the users found among the nicknames, in no particular order.
+func (u userService) GetProfiles(ctx context.Context, nicknames []string) ([]models.User, error) {
+	res, err := u.userRepo.GetUsersByNicknames(ctx, nicknames)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetProfiles")
+	}
+
+	return res, nil
+}
+
+// CreateUsers creates the users in one pass and reports, in request order, which were created and which
+// conflict with existing users, earlier users of the batch included.
+func (u userService) CreateUsers(ctx context.Context, users []*models.User) ([]models.UserCreateResult, error) {
+	created, existing, err := u.userRepo.CreateUsers(ctx, users)
+	if err != nil {
+		return nil, errors.Wrap(err, "CreateUsers")
+	}
+
+	byNickname := make(map[string]models.User, len(created))
+	for _, user := range created {
+		byNickname[strings.ToLower(user.Nickname)] = user
+	}
+
+	res := make([]models.UserCreateResult, len(users))
+
+	for idx, user := range users {
+		res[idx].User = *user
+
+		key := strings.ToLower(user.Nickname)
+
+		value, ok := byNickname[key]
+		if ok && strings.EqualFold(value.Email, user.Email) {
+			// A duplicate later in the batch conflicts with this one
+			delete(byNickname, key)
+
+			res[idx].User = value
+			res[idx].Created = true
+
+			continue
+		}
+
+		for _, other := range existing {
+			if pkg.EqualNicknames(other.Nickname, user.Nickname) || strings.EqualFold(other.Email, user.Email) {
+				res[idx].Conflicts = append(res[idx].Conflicts, other)
+			}
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,32 @@
+package grpc
+
+import (
+	"context"
+
+	"project/internal/pb"
+	"project/internal/pkg"
+	"project/internal/pkg/grpctools"
+	"project/internal/vote/usecase"
+)
+
+type VoteServer struct {
+	pb.UnimplementedVoteServiceServer
+
+	voteUsecase usecase.VoteService
+}
+
+func (s *VoteServer) Vote(ctx context.Context, req *pb.VoteRequest) (*pb.Thread, error) {
+	thread, err := s.voteUsecase.Vote(ctx, pb.NewThreadSlugOrID(req.GetSlugOrId()), &pkg.VoteParams{
+		Nickname: req.GetNickname(),
+		Voice:    req.GetVoice(),
+	})
+	if err != nil {
+		return nil, grpctools.Error(err)
+	}
+
+	return pb.NewThread(&thread), nil
+}
+
+func NewVoteServer(voteUsecase usecase.VoteService) *VoteServer {
+	return &VoteServer{voteUsecase: voteUsecase}
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+
+	"project/internal/models"
+	"project/internal/pkg"
+	threadRepo "project/internal/thread/repository"
+)
+
+// VoteCache invalidates the cached thread after a vote, since the triggers of user_votes change its votes.
+type VoteCache struct {
+	VoteRepository
+
+	threads threadRepo.Invalidator
+}
+
+func NewVoteCache(repo VoteRepository, threads threadRepo.Invalidator) *VoteCache {
+	return &VoteCache{
+		VoteRepository: repo,
+		threads:        threads,
+	}
+}
+
+func (v *VoteCache) UpdateVote(ctx context.Context, thread *models.Thread, params *pkg.VoteParams) error {
+	err := v.VoteRepository.UpdateVote(ctx, thread, params)
+	if err != nil {
+		return err
+	}
+
+	v.threads.InvalidateThread(ctx, thread)
+
+	return nil
+}
+
+func (v *VoteCache) CreateVote(ctx context.Context, thread *models.Thread, params *pkg.VoteParams) error {
+	err := v.VoteRepository.CreateVote(ctx, thread, params)
+	if err != nil {
+		return err
+	}
+
+	v.threads.InvalidateThread(ctx, thread)
+
+	return nil
+}
@@ -16,10 +16,10 @@ type VoteRepository interface {
 }
 
 type votePostgres struct {
-	conn *sql.DB
+	conn *sqltools.Cluster
 }
 
-func NewVotePostgres(conn *sql.DB) VoteRepository {
+func NewVotePostgres(conn *sqltools.Cluster) VoteRepository {
 	return &votePostgres{
 		conn,
 	}
@@ -28,7 +28,7 @@ func NewVotePostgres(conn *sql.DB) VoteRepository {
 func (v votePostgres) CheckExistVote(ctx context.Context, thread *models.Thread, params *pkg.VoteParams) (bool, error) {
 	res := false
 
-	row := v.conn.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM user_votes WHERE nickname = $1 AND thread_id = $2);`, params.Nickname, thread.ID)
+	row := v.conn.Primary(ctx).QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM user_votes WHERE nickname = $1 AND thread_id = $2);`, params.Nickname, thread.ID)
 	if row.Err() != nil {
 		return false, row.Err()
 	}
@@ -41,32 +41,50 @@ func (v votePostgres) CheckExistVote(ctx context.Context, thread *models.Thread,
 	return res, nil
 }
 
+// voteSnapshot selects the vote of the user for the thread for the audit log, its ID being thread/nickname
+const voteSnapshot = `SELECT thread_id || '/' || nickname, to_jsonb(v) FROM user_votes v WHERE thread_id = $1 AND nickname = $2`
+
 func (v votePostgres) UpdateVote(ctx context.Context, thread *models.Thread, params *pkg.VoteParams) error {
-	err := sqltools.RunTxOnConn(ctx, pkg.TxInsertOptions, v.conn, func(ctx context.Context, tx *sql.Tx) error {
-		row := tx.QueryRowContext(ctx, `UPDATE user_votes
+	err := sqltools.RunTxOnConn(ctx, pkg.TxInsertOptions, v.conn.Write(ctx), func(ctx context.Context, tx *sql.Tx) error {
+		before, err := sqltools.Snapshot(ctx, tx, voteSnapshot+` FOR UPDATE`, thread.ID, params.Nickname)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE user_votes
 			SET voice = $3
 			WHERE thread_id = $1
 			  AND nickname = $2
 			  AND voice != $3;`, thread.ID, params.Nickname, params.Voice)
-		if row.Err() != nil {
-			return row.Err()
+		if err != nil {
+			return err
 		}
 
-		return nil
+		after, err := sqltools.Snapshot(ctx, tx, voteSnapshot, thread.ID, params.Nickname)
+		if err != nil {
+			return err
+		}
+
+		return sqltools.Audit(ctx, tx, pkg.AuditEntityVote, pkg.AuditActionUpdate, before, after)
 	})
 
 	return err
 }
 
 func (v votePostgres) CreateVote(ctx context.Context, thread *models.Thread, params *pkg.VoteParams) error {
-	err := sqltools.RunTxOnConn(ctx, pkg.TxInsertOptions, v.conn, func(ctx context.Context, tx *sql.Tx) error {
-		row := tx.QueryRowContext(ctx, `INSERT INTO user_votes(nickname, thread_id, voice)
+	err := sqltools.RunTxOnConn(ctx, pkg.TxInsertOptions, v.conn.Write(ctx), func(ctx context.Context, tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `INSERT INTO user_votes(nickname, thread_id, voice)
 			VALUES ($1, $2, $3);`, params.Nickname, thread.ID, params.Voice)
-		if row.Err() != nil {
-			return row.Err()
+		if err != nil {
+			return err
+		}
+
+		after, err := sqltools.Snapshot(ctx, tx, voteSnapshot, thread.ID, params.Nickname)
+		if err != nil {
+			return err
 		}
 
-		return nil
+		return sqltools.Audit(ctx, tx, pkg.AuditEntityVote, pkg.AuditActionCreate, nil, after)
 	})
 
 	return err
@@ -5,6 +5,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	forumRepo "project/internal/forum/repository"
 	"project/internal/models"
 	"project/internal/pkg"
 	threadRepo "project/internal/thread/repository"
@@ -20,13 +21,15 @@ type voteService struct {
 	voteRepo   voteRepo.VoteRepository
 	threadRepo threadRepo.ThreadRepository
 	userRepo   userRepo.UserRepository
+	forumRepo  forumRepo.ForumRepository
 }
 
-func NewVoteService(vr voteRepo.VoteRepository, tr threadRepo.ThreadRepository, ur userRepo.UserRepository) VoteService {
+func NewVoteService(vr voteRepo.VoteRepository, tr threadRepo.ThreadRepository, ur userRepo.UserRepository, fr forumRepo.ForumRepository) VoteService {
 	return &voteService{
 		voteRepo:   vr,
 		threadRepo: tr,
 		userRepo:   ur,
+		forumRepo:  fr,
 	}
 }
 
@@ -45,6 +48,22 @@ func (v voteService) Vote(ctx context.Context, thread *models.Thread, params *pk
 		return models.Thread{}, errors.Wrap(err, "Vote")
 	}
 
+	// CheckAccess, public forums being open to everybody
+	if resThread.Visibility != pkg.ForumVisibilityPublic {
+		access, err := v.forumRepo.GetAccessForum(ctx, &models.Forum{Slug: resThread.Forum}, pkg.GetNickname(ctx))
+		if err != nil {
+			return models.Thread{}, errors.Wrap(err, "Vote")
+		}
+
+		if !pkg.CanReadForum(access) {
+			return models.Thread{}, errors.Wrap(pkg.ErrSuchThreadNotFound, "Vote")
+		}
+
+		if !pkg.CanWriteForum(access) {
+			return models.Thread{}, errors.Wrap(pkg.ErrForumReadOnly, "Vote")
+		}
+	}
+
 	// CheckUser
 	resUser, err := v.userRepo.GetUserByNickname(ctx, &models.User{Nickname: params.Nickname})
 	if err != nil {
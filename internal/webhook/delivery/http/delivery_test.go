@@ -0,0 +1,316 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	repoForum "project/internal/forum/repository"
+	coreModels "project/internal/models"
+	"project/internal/pkg"
+	repoWebhook "project/internal/webhook/repository"
+	"project/internal/webhook/usecase"
+)
+
+func allowLoopback(ip net.IP) bool {
+	return ip.IsLoopback()
+}
+
+// receiver answers deliveries with status and keeps what it was sent.
+type receiver struct {
+	mu       sync.Mutex
+	status   int
+	requests []*http.Request
+	bodies   []string
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.requests = append(rc.requests, r)
+	rc.bodies = append(rc.bodies, string(body))
+
+	w.WriteHeader(rc.status)
+}
+
+func (rc *receiver) answer(status int) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.status = status
+}
+
+func (rc *receiver) received() int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	return len(rc.requests)
+}
+
+// memoryWebhooks keeps one webhook with one delivery, due at the time of a clock the test moves.
+type memoryWebhooks struct {
+	repoWebhook.WebhookRepository
+
+	mu       sync.Mutex
+	now      time.Time
+	due      time.Time
+	webhook  coreModels.Webhook
+	delivery coreModels.WebhookDelivery
+	delays   []time.Duration
+}
+
+func (m *memoryWebhooks) advance(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.now = m.now.Add(d)
+}
+
+func (m *memoryWebhooks) state() coreModels.WebhookDelivery {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.delivery
+}
+
+func (m *memoryWebhooks) GetWebhook(ctx context.Context, webhook *coreModels.Webhook) (*coreModels.Webhook, error) {
+	if webhook.ID != m.webhook.ID {
+		return nil, pkg.ErrSuchWebhookNotFound
+	}
+
+	res := m.webhook
+
+	return &res, nil
+}
+
+func (m *memoryWebhooks) ClaimDeliveries(ctx context.Context, limit int64, lease time.Duration) ([]*coreModels.WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.delivery.Status != pkg.WebhookDeliveryPending || m.due.After(m.now) {
+		return nil, nil
+	}
+
+	m.due = m.now.Add(lease)
+
+	res := m.delivery
+
+	return []*coreModels.WebhookDelivery{&res}, nil
+}
+
+func (m *memoryWebhooks) UpdateDelivery(ctx context.Context, delivery *coreModels.WebhookDelivery, retryAfter time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.delivery = *delivery
+	m.due = m.now.Add(retryAfter)
+
+	if delivery.Status == pkg.WebhookDeliveryPending {
+		m.delays = append(m.delays, retryAfter)
+	}
+
+	return nil
+}
+
+func (m *memoryWebhooks) ReplayDelivery(ctx context.Context, delivery *coreModels.WebhookDelivery) (*coreModels.WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if delivery.ID != m.delivery.ID || delivery.Webhook != m.webhook.ID {
+		return nil, pkg.ErrSuchDeliveryNotFound
+	}
+
+	m.delivery.Status = pkg.WebhookDeliveryPending
+	m.delivery.Attempts = 0
+	m.due = m.now
+
+	res := m.delivery
+
+	return &res, nil
+}
+
+// ownedForums makes every forum owned by owner.
+type ownedForums struct {
+	repoForum.ForumRepository
+}
+
+const owner = "owner"
+
+func (ownedForums) GetDetailsForumBySlug(ctx context.Context, forum *coreModels.Forum) (*coreModels.Forum, error) {
+	return &coreModels.Forum{Slug: forum.Slug, User: owner}, nil
+}
+
+func TestSendGuard(t *testing.T) {
+	tests := []struct {
+		name    string
+		allow   func(ip net.IP) bool
+		wantErr error
+	}{
+		{
+			name:  "loopback allowed",
+			allow: allowLoopback,
+		},
+		{
+			name:    "public addresses only",
+			allow:   usecase.PublicAddress,
+			wantErr: pkg.ErrWebhookURLForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc := &receiver{status: http.StatusNoContent}
+
+			server := httptest.NewServer(rc)
+			defer server.Close()
+
+			status, err := NewSender(tt.allow).Send(context.Background(), &coreModels.WebhookDelivery{
+				ID:      1,
+				URL:     server.URL,
+				Secret:  "secret",
+				Kind:    pkg.EventPostCreated,
+				Payload: `{"id":1}`,
+			})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Send() = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				if rc.received() != 0 {
+					t.Errorf("refused delivery reached the receiver")
+				}
+
+				return
+			}
+
+			if status != http.StatusNoContent {
+				t.Errorf("status = %d, want %d", status, http.StatusNoContent)
+			}
+		})
+	}
+}
+
+// TestDeliveryLifecycle has a receiver failing every attempt until the delivery is dead, then replays it to
+// the receiver answering again.
+func TestDeliveryLifecycle(t *testing.T) {
+	rc := &receiver{status: http.StatusInternalServerError}
+
+	server := httptest.NewServer(rc)
+	defer server.Close()
+
+	const payload = `{"id":7,"event":"post.created"}`
+
+	repo := &memoryWebhooks{
+		now:     time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		webhook: coreModels.Webhook{ID: 3, Forum: "forum", URL: server.URL, Secret: "secret"},
+		delivery: coreModels.WebhookDelivery{
+			ID:      7,
+			Webhook: 3,
+			Forum:   "forum",
+			URL:     server.URL,
+			Secret:  "secret",
+			Kind:    pkg.EventPostCreated,
+			Payload: payload,
+			Status:  pkg.WebhookDeliveryPending,
+		},
+	}
+	repo.due = repo.now
+
+	service := usecase.NewWebhookService(repo, ownedForums{}, NewSender(allowLoopback), allowLoopback)
+
+	ctx := context.Background()
+
+	dispatch := func(want int) {
+		t.Helper()
+
+		got, err := service.DispatchDue(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != want {
+			t.Fatalf("dispatched %d deliveries, want %d", got, want)
+		}
+	}
+
+	// The delay doubles from 10 seconds after every failed attempt, the eighth one gives up
+	wantDelays := []time.Duration{
+		10 * time.Second, 20 * time.Second, 40 * time.Second, 80 * time.Second,
+		160 * time.Second, 320 * time.Second, 640 * time.Second,
+	}
+
+	for idx, delay := range wantDelays {
+		dispatch(1)
+
+		if repo.delays[idx] != delay {
+			t.Fatalf("retry %d after %v, want %v", idx+1, repo.delays[idx], delay)
+		}
+
+		got := repo.state()
+		if got.Status != pkg.WebhookDeliveryPending || got.Attempts != int64(idx+1) || got.LastStatus != http.StatusInternalServerError {
+			t.Fatalf("after attempt %d: status %s, attempts %d, last status %d", idx+1, got.Status, got.Attempts, got.LastStatus)
+		}
+
+		repo.advance(delay - time.Second)
+		dispatch(0)
+
+		repo.advance(time.Second)
+	}
+
+	dispatch(1)
+
+	got := repo.state()
+	if got.Status != pkg.WebhookDeliveryDead || got.Attempts != int64(len(wantDelays)+1) || got.LastError == "" {
+		t.Fatalf("after the last attempt: status %s, attempts %d, last error %q", got.Status, got.Attempts, got.LastError)
+	}
+
+	repo.advance(24 * time.Hour)
+	dispatch(0)
+
+	rc.answer(http.StatusOK)
+
+	ownerCtx := context.WithValue(ctx, pkg.NicknameKey, owner)
+
+	_, err := service.ReplayDelivery(ownerCtx, &coreModels.Webhook{ID: 3, Forum: "forum"}, &coreModels.WebhookDelivery{ID: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dispatch(1)
+
+	got = repo.state()
+	if got.Status != pkg.WebhookDeliveryDelivered || got.Attempts != 1 || got.LastStatus != http.StatusOK {
+		t.Fatalf("after the replay: status %s, attempts %d, last status %d", got.Status, got.Attempts, got.LastStatus)
+	}
+
+	if rc.received() != len(wantDelays)+2 {
+		t.Fatalf("receiver got %d requests, want %d", rc.received(), len(wantDelays)+2)
+	}
+
+	for idx, r := range rc.requests {
+		timestamp := r.Header.Get(pkg.HeaderWebhookTimestamp)
+
+		switch {
+		case rc.bodies[idx] != payload:
+			t.Errorf("request %d body = %s, want %s", idx, rc.bodies[idx], payload)
+		case r.Header.Get("Content-Type") != pkg.ContentTypeJSON:
+			t.Errorf("request %d Content-Type = %s", idx, r.Header.Get("Content-Type"))
+		case r.Header.Get(pkg.HeaderWebhookEvent) != pkg.EventPostCreated:
+			t.Errorf("request %d event = %s", idx, r.Header.Get(pkg.HeaderWebhookEvent))
+		case r.Header.Get(pkg.HeaderWebhookDelivery) != "7":
+			t.Errorf("request %d delivery = %s", idx, r.Header.Get(pkg.HeaderWebhookDelivery))
+		case r.Header.Get(pkg.HeaderWebhookSignature) != Sign("secret", timestamp, []byte(payload)):
+			t.Errorf("request %d signature %s does not match its timestamp %s", idx, r.Header.Get(pkg.HeaderWebhookSignature), timestamp)
+		}
+	}
+}
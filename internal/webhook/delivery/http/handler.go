@@ -0,0 +1,114 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"project/internal/pkg"
+	"project/internal/webhook/delivery/models"
+	"project/internal/webhook/usecase"
+)
+
+type WebhookHandler struct {
+	webhookUsecase usecase.WebhookService
+}
+
+func (h *WebhookHandler) CreateWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	request := models.NewWebhookCreateRequest()
+
+	request.Bind(r)
+
+	webhook, err := h.webhookUsecase.CreateWebhook(r.Context(), request.GetWebhook())
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	response := models.NewWebhookResponse(webhook)
+
+	pkg.Response(r.Context(), w, http.StatusCreated, response)
+}
+
+func (h *WebhookHandler) GetWebhooksHandler(w http.ResponseWriter, r *http.Request) {
+	request := models.NewWebhookRequest()
+
+	err := request.Bind(r)
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	webhooks, err := h.webhookUsecase.GetWebhooks(r.Context(), request.GetForum())
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	response := models.NewWebhooksResponse(webhooks)
+
+	pkg.Response(r.Context(), w, http.StatusOK, response)
+}
+
+func (h *WebhookHandler) DeleteWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	request := models.NewWebhookRequest()
+
+	err := request.Bind(r)
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	err = h.webhookUsecase.DeleteWebhook(r.Context(), request.GetWebhook())
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	pkg.NoBody(w, http.StatusOK)
+}
+
+func (h *WebhookHandler) GetDeliveriesHandler(w http.ResponseWriter, r *http.Request) {
+	request := models.NewWebhookRequest()
+
+	err := request.Bind(r)
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	deliveries, err := h.webhookUsecase.GetDeliveries(r.Context(), request.GetWebhook(), request.GetParams())
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	response := models.NewDeliveriesResponse(deliveries)
+
+	pkg.Response(r.Context(), w, http.StatusOK, response)
+}
+
+func (h *WebhookHandler) ReplayDeliveryHandler(w http.ResponseWriter, r *http.Request) {
+	request := models.NewWebhookRequest()
+
+	err := request.Bind(r)
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	delivery, err := h.webhookUsecase.ReplayDelivery(r.Context(), request.GetWebhook(), request.GetDelivery())
+	if err != nil {
+		pkg.DefaultHandlerHTTPError(r.Context(), w, err)
+		return
+	}
+
+	response := models.NewDeliveryResponse(delivery)
+
+	pkg.Response(r.Context(), w, http.StatusAccepted, response)
+}
+
+func NewWebhookHandler(webhookUsecase usecase.WebhookService, r *mux.Router) *WebhookHandler {
+	h := &WebhookHandler{webhookUsecase: webhookUsecase}
+	return h
+}
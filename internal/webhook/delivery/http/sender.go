@@ -0,0 +1,138 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/mailru/easyjson"
+
+	eventModels "project/internal/event/delivery/models"
+	coreModels "project/internal/models"
+	"project/internal/pkg"
+	"project/internal/webhook/delivery/models"
+	"project/internal/webhook/usecase"
+)
+
+const (
+	sendTimeout = 10 * time.Second
+	dialTimeout = 5 * time.Second
+)
+
+type sender struct {
+	client *http.Client
+}
+
+// guardDial refuses connections to addresses allow does not accept. It runs after the name is resolved, on
+// every connection, so neither a changed DNS record nor a redirect gets around it.
+func guardDial(allow func(ip net.IP) bool) func(network string, address string, _ syscall.RawConn) error {
+	return func(network string, address string, _ syscall.RawConn) error {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return err
+		}
+
+		ip := net.ParseIP(host)
+		if ip == nil || !allow(ip) {
+			return fmt.Errorf("refused to connect to %s: %w", address, pkg.ErrWebhookURLForbidden)
+		}
+
+		return nil
+	}
+}
+
+// NewSender connects to the addresses allow accepts only, usecase.PublicAddress outside of tests.
+func NewSender(allow func(ip net.IP) bool) usecase.Sender {
+	dialer := &net.Dialer{
+		Timeout: dialTimeout,
+		Control: guardDial(allow),
+	}
+
+	return &sender{
+		client: &http.Client{
+			Timeout: sendTimeout,
+			// No proxy: the guard must see the address of the receiver itself
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: dialTimeout,
+				MaxIdleConnsPerHost: 2,
+				IdleConnTimeout:     90 * time.Second,
+			},
+			// Redirects are not followed, a receiver has to answer itself
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Sign returns the signature of a delivery body: hex HMAC-SHA256 of "{timestamp}.{body}" keyed with the webhook
+// secret. Receivers should compare it in constant time and reject stale timestamps to stop replays.
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *sender) Encode(delivery *coreModels.WebhookDelivery, event *coreModels.Event) (string, error) {
+	data, err := easyjson.Marshal(eventModels.NewGatewayEventData(event))
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := easyjson.Marshal(&models.WebhookPayload{
+		ID:     delivery.ID,
+		Event:  delivery.Kind,
+		Forum:  delivery.Forum,
+		Thread: delivery.Thread,
+		Seq:    delivery.Seq,
+		Data:   data,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(payload), nil
+}
+
+func (s *sender) Send(ctx context.Context, delivery *coreModels.WebhookDelivery) (int64, error) {
+	body := []byte(delivery.Payload)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", pkg.ContentTypeJSON)
+	req.Header.Set(pkg.HeaderWebhookEvent, delivery.Kind)
+	req.Header.Set(pkg.HeaderWebhookDelivery, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(pkg.HeaderWebhookTimestamp, timestamp)
+	req.Header.Set(pkg.HeaderWebhookSignature, Sign(delivery.Secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return int64(resp.StatusCode), fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return int64(resp.StatusCode), nil
+}
@@ -0,0 +1,112 @@
+package http
+
+import (
+	"errors"
+	"testing"
+
+	coreModels "project/internal/models"
+	"project/internal/pkg"
+	"project/internal/webhook/usecase"
+)
+
+func TestSign(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		body      string
+		want      string
+	}{
+		{
+			name:      "empty body",
+			secret:    "secret",
+			timestamp: "1700000000",
+			body:      "",
+			want:      "sha256=4bc5f74d868b97888288889c5d9d65df02526f94c1592a79fdf4fe8b26e311e5",
+		},
+		{
+			name:      "json body",
+			secret:    "secret",
+			timestamp: "1700000000",
+			body:      `{"id":1}`,
+			want:      "sha256=3dd1b9aef568d75f6790a84bd2e5dfa1f44409eef3cbdbd3f10b837376100c11",
+		},
+		{
+			name:      "other secret",
+			secret:    "other",
+			timestamp: "1700000000",
+			body:      `{"id":1}`,
+			want:      "sha256=e0cb77fc6d5b2877ec062213c262d236b5dd5a833d29fdc5a058c5fbfa287b47",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sign(tt.secret, tt.timestamp, []byte(tt.body)); got != tt.want {
+				t.Errorf("Sign() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGuardDial(t *testing.T) {
+	tests := []struct {
+		address string
+		allowed bool
+	}{
+		{"93.184.216.34:443", true},
+		{"[2606:2800:220:1:248:1893:25c8:1946]:80", true},
+		{"127.0.0.1:8080", false},
+		{"[::1]:80", false},
+		{"10.0.0.5:80", false},
+		{"169.254.169.254:80", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			err := guardDial(usecase.PublicAddress)("tcp", tt.address, nil)
+			if tt.allowed && err != nil {
+				t.Errorf("guardDial(%s) = %v, want nil", tt.address, err)
+			}
+
+			if !tt.allowed && !errors.Is(err, pkg.ErrWebhookURLForbidden) {
+				t.Errorf("guardDial(%s) = %v, want %v", tt.address, err, pkg.ErrWebhookURLForbidden)
+			}
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	delivery := &coreModels.WebhookDelivery{
+		ID:     7,
+		Kind:   pkg.EventVoteChanged,
+		Forum:  "forum",
+		Thread: 3,
+		Seq:    12,
+	}
+
+	tests := []struct {
+		name  string
+		event *coreModels.Event
+		want  string
+	}{
+		{
+			name:  "vote",
+			event: &coreModels.Event{Kind: pkg.EventVoteChanged, Thread: 3, Votes: 5},
+			want:  `{"id":7,"event":"vote.changed","forum":"forum","thread":3,"seq":12,"data":{"id":3,"votes":5}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := (&sender{}).Encode(delivery, tt.event)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Encode() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
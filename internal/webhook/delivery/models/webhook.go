@@ -0,0 +1,230 @@
+package models
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/mailru/easyjson"
+
+	"project/internal/models"
+	"project/internal/pkg"
+)
+
+//go:generate easyjson -disallow_unknown_fields -omit_empty webhook.go
+
+//easyjson:json
+type WebhookCreateRequest struct {
+	Slug   string
+	URL    string   `json:"url"`
+	Secret string   `json:"secret"`
+	Events []string `json:"events"`
+}
+
+func NewWebhookCreateRequest() *WebhookCreateRequest {
+	return &WebhookCreateRequest{}
+}
+
+func (req *WebhookCreateRequest) Bind(r *http.Request) error {
+	body, _ := io.ReadAll(r.Body)
+
+	if len(body) > 0 {
+		easyjson.Unmarshal(body, req)
+	}
+
+	vars := mux.Vars(r)
+
+	req.Slug = vars["slug"]
+
+	return nil
+}
+
+func (req *WebhookCreateRequest) GetWebhook() *models.Webhook {
+	return &models.Webhook{
+		Forum:  req.Slug,
+		URL:    req.URL,
+		Secret: req.Secret,
+		Events: req.Events,
+	}
+}
+
+type WebhookRequest struct {
+	Slug     string
+	ID       int64
+	Delivery int64
+	Limit    int64
+	Since    int64
+	Status   string
+}
+
+func NewWebhookRequest() *WebhookRequest {
+	return &WebhookRequest{}
+}
+
+func (req *WebhookRequest) Bind(r *http.Request) error {
+	vars := mux.Vars(r)
+
+	req.Slug = vars["slug"]
+
+	var err error
+
+	req.ID, err = strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil && vars["id"] != "" {
+		return pkg.ErrConvertQueryType
+	}
+
+	req.Delivery, err = strconv.ParseInt(vars["delivery"], 10, 64)
+	if err != nil && vars["delivery"] != "" {
+		return pkg.ErrConvertQueryType
+	}
+
+	req.Limit = 100
+
+	param := r.FormValue("limit")
+	if param != "" {
+		req.Limit, err = strconv.ParseInt(param, 10, 64)
+		if err != nil {
+			return pkg.ErrConvertQueryType
+		}
+	}
+
+	param = r.FormValue("since")
+	if param != "" {
+		req.Since, err = strconv.ParseInt(param, 10, 64)
+		if err != nil {
+			return pkg.ErrConvertQueryType
+		}
+	}
+
+	req.Status = r.FormValue("status")
+
+	return nil
+}
+
+func (req *WebhookRequest) GetForum() *models.Forum {
+	return &models.Forum{
+		Slug: req.Slug,
+	}
+}
+
+func (req *WebhookRequest) GetWebhook() *models.Webhook {
+	return &models.Webhook{
+		ID:    req.ID,
+		Forum: req.Slug,
+	}
+}
+
+func (req *WebhookRequest) GetDelivery() *models.WebhookDelivery {
+	return &models.WebhookDelivery{
+		ID:      req.Delivery,
+		Webhook: req.ID,
+	}
+}
+
+func (req *WebhookRequest) GetParams() *pkg.GetDeliveriesParams {
+	return &pkg.GetDeliveriesParams{
+		Limit:  req.Limit,
+		Since:  req.Since,
+		Status: req.Status,
+	}
+}
+
+//easyjson:json
+type WebhookResponse struct {
+	ID      int64    `json:"id"`
+	Forum   string   `json:"forum"`
+	URL     string   `json:"url"`
+	Events  []string `json:"events"`
+	Created string   `json:"created"`
+}
+
+//easyjson:json
+type WebhooksList []WebhookResponse
+
+// NewWebhookResponse leaves the secret out, it is write-only.
+func NewWebhookResponse(webhook *models.Webhook) *WebhookResponse {
+	return &WebhookResponse{
+		ID:      webhook.ID,
+		Forum:   webhook.Forum,
+		URL:     webhook.URL,
+		Events:  webhook.Events,
+		Created: webhook.Created,
+	}
+}
+
+func NewWebhooksResponse(webhooks []*models.Webhook) WebhooksList {
+	res := make([]WebhookResponse, len(webhooks))
+
+	for idx, value := range webhooks {
+		res[idx] = *NewWebhookResponse(value)
+	}
+
+	return res
+}
+
+//easyjson:json
+type DeliveryResponse struct {
+	ID          int64               `json:"id"`
+	Webhook     int64               `json:"webhook"`
+	Event       string              `json:"event"`
+	Thread      int64               `json:"thread"`
+	Entity      int64               `json:"entity"`
+	Status      string              `json:"status"`
+	Attempts    int64               `json:"attempts"`
+	NextAttempt string              `json:"nextAttempt,omitempty"`
+	LastStatus  int64               `json:"lastStatus,omitempty"`
+	LastError   string              `json:"lastError,omitempty"`
+	Created     string              `json:"created"`
+	Payload     easyjson.RawMessage `json:"payload,omitempty"`
+}
+
+//easyjson:json
+type DeliveriesList []DeliveryResponse
+
+func NewDeliveryResponse(delivery *models.WebhookDelivery) *DeliveryResponse {
+	res := &DeliveryResponse{
+		ID:         delivery.ID,
+		Webhook:    delivery.Webhook,
+		Event:      delivery.Kind,
+		Thread:     delivery.Thread,
+		Entity:     delivery.Entity,
+		Status:     delivery.Status,
+		Attempts:   delivery.Attempts,
+		LastStatus: delivery.LastStatus,
+		LastError:  delivery.LastError,
+		Created:    delivery.Created,
+	}
+
+	if delivery.Status == pkg.WebhookDeliveryPending {
+		res.NextAttempt = delivery.NextAttempt
+	}
+
+	if delivery.Payload != "" {
+		res.Payload = easyjson.RawMessage(delivery.Payload)
+	}
+
+	return res
+}
+
+func NewDeliveriesResponse(deliveries []*models.WebhookDelivery) DeliveriesList {
+	res := make([]DeliveryResponse, len(deliveries))
+
+	for idx, value := range deliveries {
+		res[idx] = *NewDeliveryResponse(value)
+	}
+
+	return res
+}
+
+// WebhookPayload is the body of a delivery. Data has the shape of the response model of the entity.
+//
+//easyjson:json
+type WebhookPayload struct {
+	ID     int64               `json:"id"`
+	Event  string              `json:"event"`
+	Forum  string              `json:"forum"`
+	Thread int64               `json:"thread"`
+	Seq    int64               `json:"seq"`
+	Data   easyjson.RawMessage `json:"data"`
+}
@@ -0,0 +1,384 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	"project/internal/models"
+	"project/internal/pkg"
+	"project/internal/pkg/sqltools"
+)
+
+type WebhookRepository interface {
+	CreateWebhook(ctx context.Context, webhook *models.Webhook) (*models.Webhook, error)
+	GetWebhook(ctx context.Context, webhook *models.Webhook) (*models.Webhook, error)
+	GetWebhooks(ctx context.Context, forum *models.Forum) ([]*models.Webhook, error)
+	DeleteWebhook(ctx context.Context, webhook *models.Webhook) error
+	GetDeliveries(ctx context.Context, webhook *models.Webhook, params *pkg.GetDeliveriesParams) ([]*models.WebhookDelivery, error)
+	ReplayDelivery(ctx context.Context, delivery *models.WebhookDelivery) (*models.WebhookDelivery, error)
+	ClaimDeliveries(ctx context.Context, limit int64, lease time.Duration) ([]*models.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery, retryAfter time.Duration) error
+}
+
+type webhookPostgres struct {
+	conn *sql.DB
+}
+
+func NewWebhookPostgres(conn *sql.DB) WebhookRepository {
+	return &webhookPostgres{
+		conn,
+	}
+}
+
+const deliveryColumns = `d.delivery_id, d.webhook_id, w.forum, w.url, w.secret, d.kind, d.thread_id, d.seq, d.entity_id,
+	COALESCE(d.data::text, ''), COALESCE(d.payload, ''), d.status, d.attempts, d.next_attempt, d.last_status, d.last_error, d.created`
+
+// deliverySnapshot is a row of posts or threads as to_jsonb writes it.
+type deliverySnapshot struct {
+	PostID   int64     `json:"post_id"`
+	ThreadID int64     `json:"thread_id"`
+	Parent   int64     `json:"parent"`
+	Author   string    `json:"author"`
+	Forum    string    `json:"forum"`
+	Title    string    `json:"title"`
+	Message  string    `json:"message"`
+	IsEdited bool      `json:"is_edited"`
+	Slug     *string   `json:"slug"`
+	Votes    int64     `json:"votes"`
+	Version  int64     `json:"version"`
+	Created  time.Time `json:"created"`
+}
+
+// newDeliveryEvent restores the event of a delivery from the snapshot taken when it was queued.
+func newDeliveryEvent(delivery *models.WebhookDelivery, data []byte) (*models.Event, error) {
+	snapshot := &deliverySnapshot{}
+
+	err := json.Unmarshal(data, snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &models.Event{
+		ID:      delivery.Seq,
+		Kind:    delivery.Kind,
+		Thread:  delivery.Thread,
+		Forum:   delivery.Forum,
+		Entity:  delivery.Entity,
+		Created: delivery.Created,
+	}
+
+	switch delivery.Kind {
+	case pkg.EventPostCreated, pkg.EventPostUpdated:
+		res.Post = models.Post{
+			ID:       snapshot.PostID,
+			Parent:   snapshot.Parent,
+			Author:   models.User{Nickname: snapshot.Author},
+			Message:  snapshot.Message,
+			IsEdited: snapshot.IsEdited,
+			Forum:    snapshot.Forum,
+			Thread:   snapshot.ThreadID,
+			Created:  snapshot.Created.Format(time.RFC3339),
+		}
+	default:
+		res.ThreadData = models.Thread{
+			ID:      snapshot.ThreadID,
+			Title:   snapshot.Title,
+			Author:  snapshot.Author,
+			Forum:   snapshot.Forum,
+			Message: snapshot.Message,
+			Created: snapshot.Created.Format(time.RFC3339Nano),
+			Votes:   snapshot.Votes,
+			Version: snapshot.Version,
+		}
+		if snapshot.Slug != nil {
+			res.ThreadData.Slug = *snapshot.Slug
+		}
+
+		res.Votes = snapshot.Votes
+	}
+
+	return res, nil
+}
+
+func scanDelivery(rows *sql.Rows) (*models.WebhookDelivery, error) {
+	res := &models.WebhookDelivery{}
+
+	var nextAttempt, created time.Time
+
+	var data string
+
+	err := rows.Scan(
+		&res.ID,
+		&res.Webhook,
+		&res.Forum,
+		&res.URL,
+		&res.Secret,
+		&res.Kind,
+		&res.Thread,
+		&res.Seq,
+		&res.Entity,
+		&data,
+		&res.Payload,
+		&res.Status,
+		&res.Attempts,
+		&nextAttempt,
+		&res.LastStatus,
+		&res.LastError,
+		&created)
+	if err != nil {
+		return nil, err
+	}
+
+	res.NextAttempt = nextAttempt.Format(time.RFC3339Nano)
+	res.Created = created.Format(time.RFC3339Nano)
+
+	if data != "" {
+		res.Event, err = newDeliveryEvent(res, []byte(data))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+func (w webhookPostgres) CreateWebhook(ctx context.Context, webhook *models.Webhook) (*models.Webhook, error) {
+	res := &models.Webhook{}
+
+	err := sqltools.RunTxOnConn(ctx, pkg.TxInsertOptions, w.conn, func(ctx context.Context, tx *sql.Tx) error {
+		row := tx.QueryRowContext(ctx, `INSERT INTO webhooks(forum, url, secret, events)
+			VALUES ($1, $2, $3, $4)
+			RETURNING webhook_id, forum, url, secret, events, created;`,
+			webhook.Forum, webhook.URL, webhook.Secret, pq.Array(webhook.Events))
+		if row.Err() != nil {
+			return row.Err()
+		}
+
+		var created time.Time
+
+		err := row.Scan(
+			&res.ID,
+			&res.Forum,
+			&res.URL,
+			&res.Secret,
+			pq.Array(&res.Events),
+			&created)
+		if err != nil {
+			return err
+		}
+
+		res.Created = created.Format(time.RFC3339Nano)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (w webhookPostgres) GetWebhook(ctx context.Context, webhook *models.Webhook) (*models.Webhook, error) {
+	res := &models.Webhook{}
+
+	var created time.Time
+
+	row := w.conn.QueryRowContext(ctx, `SELECT webhook_id, forum, url, secret, events, created
+		FROM webhooks WHERE webhook_id = $1 AND forum = $2;`, webhook.ID, webhook.Forum)
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	err := row.Scan(
+		&res.ID,
+		&res.Forum,
+		&res.URL,
+		&res.Secret,
+		pq.Array(&res.Events),
+		&created)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, pkg.ErrSuchWebhookNotFound
+		}
+
+		return nil, err
+	}
+
+	res.Created = created.Format(time.RFC3339Nano)
+
+	return res, nil
+}
+
+func (w webhookPostgres) GetWebhooks(ctx context.Context, forum *models.Forum) ([]*models.Webhook, error) {
+	res := make([]*models.Webhook, 0)
+
+	rows, err := w.conn.QueryContext(ctx, `SELECT webhook_id, forum, url, secret, events, created
+		FROM webhooks WHERE forum = $1 ORDER BY webhook_id;`, forum.Slug)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		webhook := &models.Webhook{}
+
+		var created time.Time
+
+		err = rows.Scan(
+			&webhook.ID,
+			&webhook.Forum,
+			&webhook.URL,
+			&webhook.Secret,
+			pq.Array(&webhook.Events),
+			&created)
+		if err != nil {
+			return nil, err
+		}
+
+		webhook.Created = created.Format(time.RFC3339Nano)
+
+		res = append(res, webhook)
+	}
+
+	return res, nil
+}
+
+func (w webhookPostgres) DeleteWebhook(ctx context.Context, webhook *models.Webhook) error {
+	return sqltools.RunTxOnConn(ctx, pkg.TxInsertOptions, w.conn, func(ctx context.Context, tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `DELETE FROM webhooks WHERE webhook_id = $1 AND forum = $2;`, webhook.ID, webhook.Forum)
+		if err != nil {
+			return err
+		}
+
+		count, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if count == 0 {
+			return pkg.ErrSuchWebhookNotFound
+		}
+
+		return nil
+	})
+}
+
+func (w webhookPostgres) GetDeliveries(ctx context.Context, webhook *models.Webhook, params *pkg.GetDeliveriesParams) ([]*models.WebhookDelivery, error) {
+	res := make([]*models.WebhookDelivery, 0)
+
+	rows, err := w.conn.QueryContext(ctx, `SELECT `+deliveryColumns+`
+		FROM webhook_deliveries d JOIN webhooks w ON w.webhook_id = d.webhook_id
+		WHERE d.webhook_id = $1 AND ($2 = 0 OR d.delivery_id < $2) AND ($3 = '' OR d.status = $3)
+		ORDER BY d.delivery_id DESC LIMIT $4;`, webhook.ID, params.Since, params.Status, params.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, delivery)
+	}
+
+	return res, nil
+}
+
+// ReplayDelivery queues the delivery again with a fresh attempt budget. The payload is kept, so a replay sends
+// exactly what the first attempt did.
+func (w webhookPostgres) ReplayDelivery(ctx context.Context, delivery *models.WebhookDelivery) (*models.WebhookDelivery, error) {
+	var res *models.WebhookDelivery
+
+	err := sqltools.RunTxOnConn(ctx, pkg.TxInsertOptions, w.conn, func(ctx context.Context, tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, `UPDATE webhook_deliveries d
+			SET status = 'pending', attempts = 0, next_attempt = now()
+			FROM webhooks w
+			WHERE w.webhook_id = d.webhook_id AND d.delivery_id = $1 AND d.webhook_id = $2
+			RETURNING `+deliveryColumns+`;`, delivery.ID, delivery.Webhook)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		if !rows.Next() {
+			return pkg.ErrSuchDeliveryNotFound
+		}
+
+		res, err = scanDelivery(rows)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ClaimDeliveries takes pending deliveries which are due and postpones them by lease, so that other dispatchers
+// skip them. A dispatcher which dies before reporting leaves them to be retried once the lease expires.
+func (w webhookPostgres) ClaimDeliveries(ctx context.Context, limit int64, lease time.Duration) ([]*models.WebhookDelivery, error) {
+	res := make([]*models.WebhookDelivery, 0)
+
+	err := sqltools.RunTxOnConn(ctx, pkg.TxInsertOptions, w.conn, func(ctx context.Context, tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, `UPDATE webhook_deliveries d
+			SET next_attempt = now() + $2 * interval '1 millisecond'
+			FROM webhooks w
+			WHERE w.webhook_id = d.webhook_id AND d.delivery_id IN (
+				SELECT delivery_id FROM webhook_deliveries
+				WHERE status = 'pending' AND next_attempt <= now()
+				ORDER BY next_attempt LIMIT $1
+				FOR UPDATE SKIP LOCKED)
+			RETURNING `+deliveryColumns+`;`, limit, lease.Milliseconds())
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			delivery, err := scanDelivery(rows)
+			if err != nil {
+				return err
+			}
+
+			res = append(res, delivery)
+		}
+
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// UpdateDelivery records the outcome of an attempt. A pending delivery is scheduled retryAfter from now.
+func (w webhookPostgres) UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery, retryAfter time.Duration) error {
+	err := sqltools.RunTxOnConn(ctx, pkg.TxInsertOptions, w.conn, func(ctx context.Context, tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `UPDATE webhook_deliveries
+			SET payload = NULLIF($2, ''), status = $3, attempts = $4, last_status = $5, last_error = $6,
+				next_attempt = now() + $7 * interval '1 millisecond'
+			WHERE delivery_id = $1;`,
+			delivery.ID, delivery.Payload, delivery.Status, delivery.Attempts, delivery.LastStatus, delivery.LastError,
+			retryAfter.Milliseconds())
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"testing"
+
+	"project/internal/models"
+	"project/internal/pkg"
+)
+
+func TestNewDeliveryEvent(t *testing.T) {
+	tests := []struct {
+		name     string
+		kind     string
+		data     string
+		wantPost models.Post
+		wantThr  models.Thread
+	}{
+		{
+			name: "post created",
+			kind: pkg.EventPostCreated,
+			data: `{"post_id": 9, "parent": 4, "author": "alice", "message": "hi", "is_edited": false,
+				"forum": "go", "thread_id": 3, "created": "2024-05-01T10:00:00.123+00:00", "path": [4, 9]}`,
+			wantPost: models.Post{
+				ID:      9,
+				Parent:  4,
+				Author:  models.User{Nickname: "alice"},
+				Message: "hi",
+				Forum:   "go",
+				Thread:  3,
+				Created: "2024-05-01T10:00:00Z",
+			},
+		},
+		{
+			name: "thread created without slug",
+			kind: pkg.EventThreadCreated,
+			data: `{"thread_id": 3, "title": "t", "author": "bob", "forum": "go", "message": "m", "votes": 2,
+				"slug": null, "version": 1, "created": "2024-05-01T10:00:00+00:00"}`,
+			wantThr: models.Thread{
+				ID:      3,
+				Title:   "t",
+				Author:  "bob",
+				Forum:   "go",
+				Message: "m",
+				Votes:   2,
+				Version: 1,
+				Created: "2024-05-01T10:00:00Z",
+			},
+		},
+		{
+			name: "thread created with slug",
+			kind: pkg.EventThreadCreated,
+			data: `{"thread_id": 3, "title": "t", "author": "bob", "forum": "go", "message": "m", "votes": 0,
+				"slug": "intro", "version": 1, "created": "2024-05-01T10:00:00+00:00"}`,
+			wantThr: models.Thread{
+				ID:      3,
+				Title:   "t",
+				Author:  "bob",
+				Forum:   "go",
+				Slug:    "intro",
+				Message: "m",
+				Version: 1,
+				Created: "2024-05-01T10:00:00Z",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delivery := &models.WebhookDelivery{Kind: tt.kind, Thread: 3, Seq: 5}
+
+			got, err := newDeliveryEvent(delivery, []byte(tt.data))
+			if err != nil {
+				t.Fatalf("newDeliveryEvent() error = %v", err)
+			}
+
+			if got.ID != delivery.Seq || got.Kind != tt.kind {
+				t.Errorf("newDeliveryEvent() = event %d %s, want %d %s", got.ID, got.Kind, delivery.Seq, tt.kind)
+			}
+
+			if got.Post.ID != tt.wantPost.ID || got.Post.Parent != tt.wantPost.Parent ||
+				got.Post.Author != tt.wantPost.Author || got.Post.Message != tt.wantPost.Message ||
+				got.Post.Forum != tt.wantPost.Forum || got.Post.Thread != tt.wantPost.Thread ||
+				got.Post.Created != tt.wantPost.Created {
+				t.Errorf("newDeliveryEvent() post = %+v, want %+v", got.Post, tt.wantPost)
+			}
+
+			if got.ThreadData != tt.wantThr {
+				t.Errorf("newDeliveryEvent() thread = %+v, want %+v", got.ThreadData, tt.wantThr)
+			}
+		})
+	}
+}
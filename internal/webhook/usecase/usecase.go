@@ -0,0 +1,358 @@
+package usecase
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	repoForum "project/internal/forum/repository"
+	"project/internal/models"
+	"project/internal/pkg"
+	repoWebhook "project/internal/webhook/repository"
+)
+
+const (
+	dispatchInterval  = time.Second
+	dispatchBatchSize = 50
+	dispatchWorkers   = 8
+
+	// deliveryLease must exceed the send timeout, or a slow delivery is claimed twice
+	deliveryLease = time.Minute
+
+	retryBase   = 10 * time.Second
+	retryMax    = time.Hour
+	maxAttempts = 8
+)
+
+// Sender encodes and sends deliveries. Encode is called once per delivery, later attempts and replays send
+// the stored payload.
+type Sender interface {
+	Encode(delivery *models.WebhookDelivery, event *models.Event) (string, error)
+	Send(ctx context.Context, delivery *models.WebhookDelivery) (int64, error)
+}
+
+type WebhookService interface {
+	Run(ctx context.Context) error
+	DispatchDue(ctx context.Context) (int, error)
+	CreateWebhook(ctx context.Context, webhook *models.Webhook) (*models.Webhook, error)
+	GetWebhooks(ctx context.Context, forum *models.Forum) ([]*models.Webhook, error)
+	DeleteWebhook(ctx context.Context, webhook *models.Webhook) error
+	GetDeliveries(ctx context.Context, webhook *models.Webhook, params *pkg.GetDeliveriesParams) ([]*models.WebhookDelivery, error)
+	ReplayDelivery(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery) (*models.WebhookDelivery, error)
+}
+
+type webhookService struct {
+	webhookRepo repoWebhook.WebhookRepository
+	forumRepo   repoForum.ForumRepository
+	sender      Sender
+	allow       func(ip net.IP) bool
+}
+
+// NewWebhookService accepts webhook urls resolving to addresses allow accepts, PublicAddress outside of tests.
+func NewWebhookService(rw repoWebhook.WebhookRepository, rf repoForum.ForumRepository, sender Sender,
+	allow func(ip net.IP) bool) WebhookService {
+	return &webhookService{
+		webhookRepo: rw,
+		forumRepo:   rf,
+		sender:      sender,
+		allow:       allow,
+	}
+}
+
+// sharedAddressSpace is the carrier-grade NAT range, private in practice though not in net.IP.IsPrivate.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// PublicAddress tells whether webhooks may be sent to ip. Loopback, private, link-local and the like are refused
+// so that a webhook cannot reach the internal network of the server.
+func PublicAddress(ip net.IP) bool {
+	return !ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified() &&
+		!sharedAddressSpace.Contains(ip)
+}
+
+// checkTarget refuses webhook urls which resolve to addresses not allowed. The sender checks again on connect,
+// as the name may resolve differently later.
+func (w webhookService) checkTarget(ctx context.Context, target *url.URL) error {
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, target.Hostname())
+	if err != nil || len(addrs) == 0 {
+		return pkg.ErrWebhookURLForbidden
+	}
+
+	for _, addr := range addrs {
+		if !w.allow(addr.IP) {
+			return pkg.ErrWebhookURLForbidden
+		}
+	}
+
+	return nil
+}
+
+// checkOwner allows webhooks of a forum to be managed by its owner only.
+func (w webhookService) checkOwner(ctx context.Context, slug string) error {
+	nickname := pkg.GetNickname(ctx)
+	if nickname == "" {
+		return pkg.ErrAuthRequired
+	}
+
+	forum, err := w.forumRepo.GetDetailsForumBySlug(ctx, &models.Forum{Slug: slug})
+	if err != nil {
+		return err
+	}
+
+	if !pkg.EqualNicknames(forum.User, nickname) {
+		return pkg.ErrForumAccessDenied
+	}
+
+	return nil
+}
+
+func (w webhookService) CreateWebhook(ctx context.Context, webhook *models.Webhook) (*models.Webhook, error) {
+	target, err := url.Parse(webhook.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return nil, errors.Wrap(pkg.ErrBadRequestParams, "CreateWebhook")
+	}
+
+	if webhook.Secret == "" || len(webhook.Events) == 0 {
+		return nil, errors.Wrap(pkg.ErrBadRequestParamsEmptyRequiredFields, "CreateWebhook")
+	}
+
+	events := make([]string, 0, len(webhook.Events))
+	seen := make(map[string]bool)
+
+	for _, event := range webhook.Events {
+		switch event {
+		case pkg.EventThreadCreated, pkg.EventPostCreated, pkg.EventPostUpdated, pkg.EventVoteChanged:
+		default:
+			return nil, errors.Wrap(pkg.ErrWebhookEventUnknown, "CreateWebhook")
+		}
+
+		if !seen[event] {
+			seen[event] = true
+			events = append(events, event)
+		}
+	}
+
+	webhook.Events = events
+
+	err = w.checkOwner(ctx, webhook.Forum)
+	if err != nil {
+		return nil, errors.Wrap(err, "CreateWebhook")
+	}
+
+	err = w.checkTarget(ctx, target)
+	if err != nil {
+		return nil, errors.Wrap(err, "CreateWebhook")
+	}
+
+	res, err := w.webhookRepo.CreateWebhook(ctx, webhook)
+	if err != nil {
+		return nil, errors.Wrap(err, "CreateWebhook")
+	}
+
+	return res, nil
+}
+
+func (w webhookService) GetWebhooks(ctx context.Context, forum *models.Forum) ([]*models.Webhook, error) {
+	err := w.checkOwner(ctx, forum.Slug)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetWebhooks")
+	}
+
+	res, err := w.webhookRepo.GetWebhooks(ctx, forum)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetWebhooks")
+	}
+
+	return res, nil
+}
+
+func (w webhookService) DeleteWebhook(ctx context.Context, webhook *models.Webhook) error {
+	err := w.checkOwner(ctx, webhook.Forum)
+	if err != nil {
+		return errors.Wrap(err, "DeleteWebhook")
+	}
+
+	err = w.webhookRepo.DeleteWebhook(ctx, webhook)
+	if err != nil {
+		return errors.Wrap(err, "DeleteWebhook")
+	}
+
+	return nil
+}
+
+func (w webhookService) GetDeliveries(ctx context.Context, webhook *models.Webhook, params *pkg.GetDeliveriesParams) ([]*models.WebhookDelivery, error) {
+	switch params.Status {
+	case "", pkg.WebhookDeliveryPending, pkg.WebhookDeliveryDelivered, pkg.WebhookDeliveryDead:
+	default:
+		return nil, errors.Wrap(pkg.ErrBadRequestParams, "GetDeliveries")
+	}
+
+	err := w.checkOwner(ctx, webhook.Forum)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetDeliveries")
+	}
+
+	_, err = w.webhookRepo.GetWebhook(ctx, webhook)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetDeliveries")
+	}
+
+	res, err := w.webhookRepo.GetDeliveries(ctx, webhook, params)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetDeliveries")
+	}
+
+	return res, nil
+}
+
+func (w webhookService) ReplayDelivery(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery) (*models.WebhookDelivery, error) {
+	err := w.checkOwner(ctx, webhook.Forum)
+	if err != nil {
+		return nil, errors.Wrap(err, "ReplayDelivery")
+	}
+
+	_, err = w.webhookRepo.GetWebhook(ctx, webhook)
+	if err != nil {
+		return nil, errors.Wrap(err, "ReplayDelivery")
+	}
+
+	delivery.Webhook = webhook.ID
+
+	res, err := w.webhookRepo.ReplayDelivery(ctx, delivery)
+	if err != nil {
+		return nil, errors.Wrap(err, "ReplayDelivery")
+	}
+
+	return res, nil
+}
+
+// Run sends due deliveries until ctx is done. Deliveries are written by the database in the transaction of
+// the change they are about, so nothing is lost if the process stops between the change and the send.
+func (w webhookService) Run(ctx context.Context) error {
+	ticker := time.NewTicker(dispatchInterval)
+	defer ticker.Stop()
+
+	for {
+		sent, err := w.DispatchDue(ctx)
+		if err != nil && ctx.Err() == nil {
+			logrus.Error(errors.Wrap(err, "Run"))
+		}
+
+		// A full batch means there may be more due right now
+		if sent == dispatchBatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchDue attempts a batch of the deliveries which are due and returns how many it attempted.
+func (w webhookService) DispatchDue(ctx context.Context) (int, error) {
+	deliveries, err := w.webhookRepo.ClaimDeliveries(ctx, dispatchBatchSize, deliveryLease)
+	if err != nil {
+		return 0, errors.Wrap(err, "DispatchDue")
+	}
+
+	w.dispatch(ctx, deliveries)
+
+	return len(deliveries), nil
+}
+
+func (w webhookService) dispatch(ctx context.Context, deliveries []*models.WebhookDelivery) {
+	var wg sync.WaitGroup
+
+	workers := make(chan struct{}, dispatchWorkers)
+
+	for _, delivery := range deliveries {
+		workers <- struct{}{}
+		wg.Add(1)
+
+		go func(delivery *models.WebhookDelivery) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+
+			w.attempt(ctx, delivery)
+		}(delivery)
+	}
+
+	wg.Wait()
+}
+
+func (w webhookService) attempt(ctx context.Context, delivery *models.WebhookDelivery) {
+	delivery.Attempts++
+
+	status, err := w.send(ctx, delivery)
+
+	delivery.LastStatus = status
+	delivery.LastError = ""
+
+	var retryAfter time.Duration
+
+	switch {
+	case err == nil:
+		delivery.Status = pkg.WebhookDeliveryDelivered
+	case delivery.Attempts >= maxAttempts:
+		delivery.Status = pkg.WebhookDeliveryDead
+		delivery.LastError = err.Error()
+	default:
+		delivery.Status = pkg.WebhookDeliveryPending
+		delivery.LastError = err.Error()
+		retryAfter = backoff(delivery.Attempts)
+	}
+
+	err = w.webhookRepo.UpdateDelivery(ctx, delivery, retryAfter)
+	if err != nil {
+		logrus.Error(errors.Wrap(err, "attempt"))
+	}
+}
+
+// send encodes the payload from the snapshot taken when the delivery was queued, so a retry sends the event
+// even after it left the events table.
+func (w webhookService) send(ctx context.Context, delivery *models.WebhookDelivery) (int64, error) {
+	if delivery.Payload == "" {
+		if delivery.Event == nil {
+			return 0, pkg.ErrWebhookNoSnapshot
+		}
+
+		var err error
+
+		delivery.Payload, err = w.sender.Encode(delivery, delivery.Event)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return w.sender.Send(ctx, delivery)
+}
+
+// backoff doubles the delay after every failed attempt, starting from retryBase.
+func backoff(attempts int64) time.Duration {
+	res := retryBase
+
+	for i := int64(1); i < attempts && res < retryMax; i++ {
+		res *= 2
+	}
+
+	if res > retryMax {
+		res = retryMax
+	}
+
+	return res
+}
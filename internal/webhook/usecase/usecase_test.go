@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempts int64
+		want     time.Duration
+	}{
+		{"first attempt", 1, retryBase},
+		{"second attempt", 2, 2 * retryBase},
+		{"third attempt", 3, 4 * retryBase},
+		{"seventh attempt", 7, 64 * retryBase},
+		{"capped", 10, retryMax},
+		{"far beyond the cap", 100, retryMax},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := backoff(tt.attempts); got != tt.want {
+				t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPublicAddress(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fc00::1", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"::", false},
+		{"224.0.0.1", false},
+		{"::ffff:127.0.0.1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			if got := PublicAddress(net.ParseIP(tt.ip)); got != tt.want {
+				t.Errorf("PublicAddress(%s) = %v, want %v", tt.ip, got, tt.want)
+			}
+		})
+	}
+}